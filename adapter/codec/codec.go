@@ -0,0 +1,82 @@
+package codec
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mapoio/hyperion"
+)
+
+// NewMsgpackCodec returns a hyperion.Codec using MessagePack.
+// Struct fields use their msgpack tags, falling back to the json tags,
+// so types already shaped for JSON encode the same way.
+func NewMsgpackCodec() hyperion.Codec {
+	return msgpackCodec{}
+}
+
+// NewProtoCodec returns a hyperion.Codec for protobuf messages.
+//
+// Marshal accepts a proto.Message. Unmarshal accepts a proto.Message, or a
+// pointer to a nil message pointer, which it allocates; this is the shape
+// TypedCache passes for a message type:
+//
+//	users := hyperion.NewTypedCache[*pb.User](cache, hyperion.WithCodec(codec.NewProtoCodec()))
+func NewProtoCodec() hyperion.Codec {
+	return protoCodec{}
+}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string { return "msgpack" }
+
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+type protoCodec struct{}
+
+func (protoCodec) Name() string { return "protobuf" }
+
+func (protoCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("protobuf codec: %T is not a proto.Message", v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (protoCodec) Unmarshal(data []byte, v any) error {
+	if msg, ok := v.(proto.Message); ok {
+		return proto.Unmarshal(data, msg)
+	}
+
+	// A pointer to a message pointer: allocate the message
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Pointer {
+		elem := reflect.New(rv.Elem().Type().Elem())
+		if msg, ok := elem.Interface().(proto.Message); ok {
+			if err := proto.Unmarshal(data, msg); err != nil {
+				return err
+			}
+			rv.Elem().Set(elem)
+			return nil
+		}
+	}
+	return fmt.Errorf("protobuf codec: cannot decode into %T", v)
+}
@@ -0,0 +1,121 @@
+package codec_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/adapter/codec"
+)
+
+type session struct {
+	Expires time.Time `json:"expires"`
+	UserID  string    `json:"user_id"`
+	Roles   []string  `json:"roles"`
+}
+
+func TestMsgpackCodec(t *testing.T) {
+	c := codec.NewMsgpackCodec()
+	if c.Name() != "msgpack" {
+		t.Errorf("Name() = %q, want msgpack", c.Name())
+	}
+
+	in := session{UserID: "u1", Roles: []string{"admin"}, Expires: time.Unix(1700000000, 0).UTC()}
+	data, err := c.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var out session
+	if err := c.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if out.UserID != in.UserID || len(out.Roles) != 1 || !out.Expires.Equal(in.Expires) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+
+	// Fields are keyed by their json names
+	var raw map[string]any
+	if err := c.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Unmarshal into map failed: %v", err)
+	}
+	if raw["user_id"] != "u1" {
+		t.Errorf("encoded keys = %v, want user_id", raw)
+	}
+}
+
+func TestProtoCodec(t *testing.T) {
+	c := codec.NewProtoCodec()
+	if c.Name() != "protobuf" {
+		t.Errorf("Name() = %q, want protobuf", c.Name())
+	}
+
+	data, err := c.Marshal(wrapperspb.String("hello"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	// Into an existing message
+	msg := &wrapperspb.StringValue{}
+	if err := c.Unmarshal(data, msg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if msg.GetValue() != "hello" {
+		t.Errorf("Unmarshal = %q, want hello", msg.GetValue())
+	}
+
+	// Into a nil message pointer, as TypedCache does
+	var ptr *wrapperspb.StringValue
+	if err := c.Unmarshal(data, &ptr); err != nil {
+		t.Fatalf("Unmarshal into **StringValue failed: %v", err)
+	}
+	if ptr.GetValue() != "hello" {
+		t.Errorf("Unmarshal = %q, want hello", ptr.GetValue())
+	}
+
+	if _, err := c.Marshal("not a message"); err == nil {
+		t.Error("Marshal accepted a non-message")
+	}
+	var s string
+	if err := c.Unmarshal(data, &s); err == nil {
+		t.Error("Unmarshal accepted a non-message target")
+	}
+}
+
+// mapCache is a minimal hyperion.Cache for codec round trips.
+type mapCache struct {
+	hyperion.Cache
+	items map[string][]byte
+}
+
+func (c *mapCache) Get(_ context.Context, key string) ([]byte, error) {
+	if v, ok := c.items[key]; ok {
+		return v, nil
+	}
+	return nil, hyperion.ErrCacheMiss
+}
+
+func (c *mapCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	c.items[key] = value
+	return nil
+}
+
+func TestTypedCacheWithProto(t *testing.T) {
+	ctx := context.Background()
+	cache := &mapCache{Cache: hyperion.NewNoOpCache(), items: make(map[string][]byte)}
+	values := hyperion.NewTypedCache[*wrapperspb.StringValue](cache, hyperion.WithCodec(codec.NewProtoCodec()))
+
+	if err := values.Set(ctx, "key", wrapperspb.String("hello"), 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	got, err := values.Get(ctx, "key")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.GetValue() != "hello" {
+		t.Errorf("Get = %q, want hello", got.GetValue())
+	}
+}
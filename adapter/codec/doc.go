@@ -0,0 +1,15 @@
+// Package codec provides hyperion.Codec implementations that need
+// third-party libraries: MessagePack and protobuf.
+//
+// JSON and gob codecs are built into hyperion. Use these with
+// hyperion.TypedCache when values should be more compact or shared with
+// services in other languages:
+//
+//	sessions := hyperion.NewTypedCache[Session](cache,
+//	    hyperion.WithCodec(codec.NewMsgpackCodec()),
+//	)
+//
+//	users := hyperion.NewTypedCache[*pb.User](cache,
+//	    hyperion.WithCodec(codec.NewProtoCodec()),
+//	)
+package codec
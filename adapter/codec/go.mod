@@ -0,0 +1,21 @@
+module github.com/mapoio/hyperion/adapter/codec
+
+go 1.24
+
+require (
+	github.com/mapoio/hyperion v0.0.0-00010101000000-000000000000
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	google.golang.org/protobuf v1.36.9
+)
+
+require (
+	github.com/google/go-cmp v0.7.0 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	go.uber.org/dig v1.19.0 // indirect
+	go.uber.org/fx v1.24.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	go.uber.org/zap v1.27.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
+
+replace github.com/mapoio/hyperion => ../../hyperion
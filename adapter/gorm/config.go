@@ -32,31 +32,31 @@ const (
 // Fields are ordered for optimal memory alignment (larger types first).
 type Config struct {
 	// String fields (16 bytes on 64-bit: 8-byte pointer + 8-byte length)
-	Driver   string `json:"driver" yaml:"driver" validate:"required,oneof=postgres mysql sqlite"` // Driver specifies the database driver (postgres, mysql, sqlite)
-	DSN      string `json:"dsn" yaml:"dsn"`                                                       // DSN allows providing a complete connection string
-	Host     string `json:"host" yaml:"host" validate:"omitempty,hostname|ip"`                    // Connection host
-	Username string `json:"username" yaml:"username"`                                             // Connection username
-	Password string `json:"password" yaml:"password"`                                             // Connection password
-	Database string `json:"database" yaml:"database"`                                             // Database name (required for non-SQLite unless DSN provided)
-	SSLMode  string `json:"sslmode" yaml:"sslmode" validate:"omitempty,oneof=disable require verify-ca verify-full"`
-	Charset  string `json:"charset" yaml:"charset"`     // MySQL charset
-	LogLevel string `json:"log_level" yaml:"log_level"` // Log level: silent, error, warn, info
+	Driver   string `mapstructure:"driver" json:"driver" yaml:"driver" validate:"required,oneof=postgres mysql sqlite"` // Driver specifies the database driver (postgres, mysql, sqlite)
+	DSN      string `mapstructure:"dsn" json:"dsn" yaml:"dsn"`                                                          // DSN allows providing a complete connection string
+	Host     string `mapstructure:"host" json:"host" yaml:"host" validate:"omitempty,hostname|ip"`                      // Connection host
+	Username string `mapstructure:"username" json:"username" yaml:"username"`                                           // Connection username
+	Password string `mapstructure:"password" json:"password" yaml:"password"`                                           // Connection password
+	Database string `mapstructure:"database" json:"database" yaml:"database"`                                           // Database name (required for non-SQLite unless DSN provided)
+	SSLMode  string `mapstructure:"sslmode" json:"sslmode" yaml:"sslmode" validate:"omitempty,oneof=disable require verify-ca verify-full"`
+	Charset  string `mapstructure:"charset" json:"charset" yaml:"charset"`       // MySQL charset
+	LogLevel string `mapstructure:"log_level" json:"log_level" yaml:"log_level"` // Log level: silent, error, warn, info
 
 	// Duration fields (8 bytes each)
-	ConnMaxLifetime time.Duration `json:"conn_max_lifetime" yaml:"conn_max_lifetime" validate:"omitempty,min=0"`
-	ConnMaxIdleTime time.Duration `json:"conn_max_idle_time" yaml:"conn_max_idle_time" validate:"omitempty,min=0"`
-	SlowThreshold   time.Duration `json:"slow_threshold" yaml:"slow_threshold" validate:"omitempty,min=0"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime" json:"conn_max_lifetime" yaml:"conn_max_lifetime" validate:"omitempty,min=0"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time" json:"conn_max_idle_time" yaml:"conn_max_idle_time" validate:"omitempty,min=0"`
+	SlowThreshold   time.Duration `mapstructure:"slow_threshold" json:"slow_threshold" yaml:"slow_threshold" validate:"omitempty,min=0"`
 
 	// Int fields (8 bytes on 64-bit)
-	MaxOpenConns int `json:"max_open_conns" yaml:"max_open_conns" validate:"omitempty,min=0"`
-	MaxIdleConns int `json:"max_idle_conns" yaml:"max_idle_conns" validate:"omitempty,min=0"`
-	Port         int `json:"port" yaml:"port" validate:"omitempty,min=1,max=65535"`
+	MaxOpenConns int `mapstructure:"max_open_conns" json:"max_open_conns" yaml:"max_open_conns" validate:"omitempty,min=0"`
+	MaxIdleConns int `mapstructure:"max_idle_conns" json:"max_idle_conns" yaml:"max_idle_conns" validate:"omitempty,min=0"`
+	Port         int `mapstructure:"port" json:"port" yaml:"port" validate:"omitempty,min=1,max=65535"`
 
 	// Bool fields (1 byte each) - use pointers to distinguish unset from false
 	// Using pointers allows us to differentiate between "not provided" (nil) and "explicitly set to false"
-	SkipDefaultTransaction *bool `json:"skip_default_transaction" yaml:"skip_default_transaction"`
-	PrepareStmt            *bool `json:"prepare_stmt" yaml:"prepare_stmt"`
-	AutoMigrate            *bool `json:"auto_migrate" yaml:"auto_migrate"`
+	SkipDefaultTransaction *bool `mapstructure:"skip_default_transaction" json:"skip_default_transaction" yaml:"skip_default_transaction"`
+	PrepareStmt            *bool `mapstructure:"prepare_stmt" json:"prepare_stmt" yaml:"prepare_stmt"`
+	AutoMigrate            *bool `mapstructure:"auto_migrate" json:"auto_migrate" yaml:"auto_migrate"`
 }
 
 // DefaultConfig returns a configuration with sensible defaults.
@@ -107,6 +107,32 @@ func NewGormDatabase(cfg hyperion.Config) (hyperion.Database, error) {
 	return &gormDatabase{db: db}, nil
 }
 
+// ValidateConfig loads the database configuration from cfg, with defaults,
+// and validates it. It is registered as a hyperion.ConfigValidator by Module
+// so that config reloads with an invalid database section are rejected.
+func ValidateConfig(cfg hyperion.Config) error {
+	dbConfig := DefaultConfig()
+	if err := loadConfig(cfg, dbConfig); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid database config: %w", err)
+	}
+	return nil
+}
+
+// ConfigSections describes the "database" section for JSON Schema
+// generation and linting. Module contributes it to the
+// "hyperion.config_sections" group.
+func ConfigSections() []hyperion.ConfigSection {
+	return []hyperion.ConfigSection{{
+		Key:         "database",
+		Type:        Config{},
+		Validate:    ValidateConfig,
+		Description: "Database connection and pool settings (adapter/gorm)",
+	}}
+}
+
 // NewGormUnitOfWork creates a new UnitOfWork from a Database instance.
 func NewGormUnitOfWork(db hyperion.Database) hyperion.UnitOfWork {
 	gdb, ok := db.(*gormDatabase)
@@ -232,6 +258,11 @@ func (c *Config) Open() (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Keep the sql.Result of raw statements for Executor.Exec
+	if err := db.Callback().Raw().Replace("gorm:raw", rawExec); err != nil {
+		return nil, fmt.Errorf("failed to register exec callback: %w", err)
+	}
+
 	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
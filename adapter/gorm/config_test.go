@@ -346,6 +346,43 @@ func TestNewGormDatabase(t *testing.T) {
 	}
 }
 
+func TestValidateConfig(t *testing.T) {
+	valid := &mockConfig{data: map[string]any{
+		"database": map[string]any{"driver": DriverSQLite, "database": ":memory:"},
+	}}
+	if err := ValidateConfig(valid); err != nil {
+		t.Errorf("ValidateConfig() error = %v, want nil", err)
+	}
+
+	invalid := &mockConfig{data: map[string]any{
+		"database": map[string]any{"driver": DriverPostgres, "port": 70000},
+	}}
+	if err := ValidateConfig(invalid); err == nil {
+		t.Error("ValidateConfig() should reject an out-of-range port")
+	}
+}
+
+func TestBindConfig(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"database": map[string]any{
+			"driver":   DriverPostgres,
+			"host":     "db.internal",
+			"database": "app",
+		},
+	}))
+	if err != nil {
+		t.Fatalf("NewLayeredConfig() error = %v", err)
+	}
+
+	bound, err := hyperion.Bind[Config](cfg, "database")
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if bound.Host != "db.internal" {
+		t.Errorf("Host = %q, want %q", bound.Host, "db.internal")
+	}
+}
+
 func TestNewGormUnitOfWork(t *testing.T) {
 	// Test with valid gormDatabase
 	cfg := &mockConfig{
@@ -142,18 +142,32 @@
 //
 // # Nested Transactions
 //
-// GORM automatically handles nested transactions using savepoints:
+// A WithTransaction call inside a transaction joins it. Set
+// TransactionOptions.Propagation to run under a savepoint instead
+// (PropagationNested), start an independent transaction
+// (PropagationRequiresNew), or require (PropagationMandatory) or forbid
+// (PropagationNever) an existing one:
 //
 //	err := uow.WithTransaction(ctx, func(txCtx hyperion.Context) error {
 //	    // Outer transaction
 //
 //	    // Nested transaction (creates savepoint)
-//	    return uow.WithTransaction(txCtx, func(nestedCtx hyperion.Context) error {
+//	    opts := &hyperion.TransactionOptions{Propagation: hyperion.PropagationNested}
+//	    return uow.WithTransactionOptions(txCtx, opts, func(nestedCtx hyperion.Context) error {
 //	        // Inner transaction
 //	        return userRepo.Create(nestedCtx, user)
 //	    })
 //	})
 //
+// # Transaction Hooks
+//
+// hyperion.OnCommit and hyperion.OnRollback register callbacks on the
+// transaction context, run after the outermost commit or rollback:
+//
+//	hyperion.OnCommit(txCtx, func(ctx hyperion.Context) error {
+//	    return cache.Delete(ctx, "user:"+user.ID)
+//	})
+//
 // # Panic Recovery
 //
 // Transactions are automatically rolled back on panic:
@@ -3,7 +3,10 @@ package gorm
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"reflect"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -13,8 +16,9 @@ import (
 // gormExecutor implements hyperion.Executor interface using GORM.
 // It wraps a *gorm.DB instance and tracks whether it represents a transaction.
 type gormExecutor struct {
-	db   *gorm.DB
-	isTx bool // Tracks if this executor is a transaction
+	db    *gorm.DB
+	isTx  bool // Tracks if this executor is a transaction
+	depth int  // Number of enclosing savepoints (PropagationNested)
 }
 
 // Ensure interface compliance at compile time.
@@ -22,12 +26,15 @@ var _ hyperion.Executor = (*gormExecutor)(nil)
 
 // Exec executes a SQL statement without returning rows.
 // It's typically used for INSERT, UPDATE, DELETE, or DDL statements.
-func (e *gormExecutor) Exec(ctx context.Context, sql string, args ...any) error {
-	result := e.db.WithContext(ctx).Exec(sql, args...)
-	if result.Error != nil {
-		return fmt.Errorf("exec failed: %w", result.Error)
+func (e *gormExecutor) Exec(ctx context.Context, sql string, args ...any) (hyperion.Result, error) {
+	tx := e.db.WithContext(ctx).Exec(sql, args...)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("exec failed: %w", tx.Error)
 	}
-	return nil
+	if result, ok := tx.InstanceGet(execResultKey); ok {
+		return result.(hyperion.Result), nil
+	}
+	return rowsAffectedResult(tx.RowsAffected), nil
 }
 
 // Query executes a SQL query and scans the results into dest.
@@ -40,6 +47,37 @@ func (e *gormExecutor) Query(ctx context.Context, dest any, sql string, args ...
 	return nil
 }
 
+// QueryRow scans the first row of a query into dest.
+// It returns an error wrapping hyperion.ErrNotFound if there are no rows.
+func (e *gormExecutor) QueryRow(ctx context.Context, dest any, sql string, args ...any) error {
+	rows, err := e.db.WithContext(ctx).Raw(sql, args...).Rows()
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("query failed: %w", err)
+		}
+		return fmt.Errorf("query row: %w", hyperion.ErrNotFound)
+	}
+	if err := scanRow(e.db, rows, dest); err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+	return rows.Close()
+}
+
+// QueryIter executes a SQL query and returns a cursor over its rows.
+// The cursor holds a connection until it is closed.
+func (e *gormExecutor) QueryIter(ctx context.Context, sql string, args ...any) (hyperion.Rows, error) {
+	rows, err := e.db.WithContext(ctx).Raw(sql, args...).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	return &gormRows{db: e.db, rows: rows}, nil
+}
+
 // Begin starts a new transaction and returns a transaction executor.
 // The returned executor will have isTx set to true.
 func (e *gormExecutor) Begin(ctx context.Context) (hyperion.Executor, error) {
@@ -101,3 +139,72 @@ func (e *gormExecutor) Rollback() error {
 func (e *gormExecutor) Unwrap() any {
 	return e.db
 }
+
+// execResultKey is the statement setting under which rawExec stores the
+// sql.Result of an Exec.
+const execResultKey = "hyperion:exec_result"
+
+// rawExec replaces GORM's "gorm:raw" callback, which discards the
+// sql.Result of raw statements. It keeps the result so Exec can report
+// LastInsertId. Registered by Config.Open.
+func rawExec(db *gorm.DB) {
+	if db.Error != nil || db.DryRun {
+		return
+	}
+	result, err := db.Statement.ConnPool.ExecContext(db.Statement.Context, db.Statement.SQL.String(), db.Statement.Vars...)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	db.RowsAffected, _ = result.RowsAffected()
+	db.InstanceSet(execResultKey, result)
+}
+
+// rowsAffectedResult is the Result of an Exec on a *gorm.DB not opened by
+// Config.Open, where only the affected row count is known.
+type rowsAffectedResult int64
+
+func (r rowsAffectedResult) LastInsertId() (int64, error) {
+	return 0, errors.New("LastInsertId is not available: database not opened by adapter/gorm")
+}
+
+func (r rowsAffectedResult) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+// gormRows implements hyperion.Rows over *sql.Rows, scanning rows with
+// GORM's column mapping.
+type gormRows struct {
+	db   *gorm.DB
+	rows *sql.Rows
+}
+
+// Ensure interface compliance at compile time.
+var _ hyperion.Rows = (*gormRows)(nil)
+
+func (r *gormRows) Next() bool   { return r.rows.Next() }
+func (r *gormRows) Err() error   { return r.rows.Err() }
+func (r *gormRows) Close() error { return r.rows.Close() }
+
+// Scan copies the current row into dest.
+func (r *gormRows) Scan(dest any) error {
+	if err := scanRow(r.db, r.rows, dest); err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+	return nil
+}
+
+// scanRow scans the current row into dest. Structs and maps go through
+// GORM's ScanRows for its column mapping; anything else is scanned
+// directly, since ScanRows would consume the remaining rows for scalars.
+func scanRow(db *gorm.DB, rows *sql.Rows, dest any) error {
+	if _, ok := dest.(sql.Scanner); !ok {
+		if t := reflect.TypeOf(dest); t != nil && t.Kind() == reflect.Pointer {
+			elem := t.Elem()
+			if (elem.Kind() == reflect.Struct && elem != reflect.TypeOf(time.Time{})) || elem.Kind() == reflect.Map {
+				return db.ScanRows(rows, dest)
+			}
+		}
+	}
+	return rows.Scan(dest)
+}
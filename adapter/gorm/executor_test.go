@@ -2,17 +2,43 @@ package gorm
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"gorm.io/gorm"
 
 	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/hyperiontest"
 )
 
 func TestGormExecutor_InterfaceCompliance(t *testing.T) {
 	var _ hyperion.Executor = (*gormExecutor)(nil)
 }
 
+// TestConformance runs the shared executor suite against a SQLite file, so
+// transactions and the outer executor see the same database.
+func TestConformance(t *testing.T) {
+	hyperiontest.RunExecutorSuite(t, hyperiontest.ExecutorHarness{
+		New: func(t *testing.T) hyperion.Database {
+			db, err := NewGormDatabase(&mockConfig{
+				data: map[string]any{
+					"database": map[string]any{
+						"driver":    DriverSQLite,
+						"database":  filepath.Join(t.TempDir(), "test.db"),
+						"log_level": "silent",
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewGormDatabase() error = %v", err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			return db
+		},
+		UnitOfWork: NewGormUnitOfWork,
+	})
+}
+
 func TestGormExecutor_Exec(t *testing.T) {
 	cfg := newSQLiteConfig()
 	db, err := NewGormDatabase(cfg)
@@ -31,13 +57,13 @@ func TestGormExecutor_Exec(t *testing.T) {
 		email TEXT UNIQUE NOT NULL
 	)`
 
-	if err := executor.Exec(ctx, sql); err != nil {
+	if _, err := executor.Exec(ctx, sql); err != nil {
 		t.Errorf("Exec() error = %v, want nil", err)
 	}
 
 	// Insert data
 	insertSQL := "INSERT INTO users (name, email) VALUES (?, ?)"
-	if err := executor.Exec(ctx, insertSQL, "John Doe", "john@example.com"); err != nil {
+	if _, err := executor.Exec(ctx, insertSQL, "John Doe", "john@example.com"); err != nil {
 		t.Errorf("Exec() insert error = %v, want nil", err)
 	}
 }
@@ -89,6 +115,64 @@ func TestGormExecutor_Query(t *testing.T) {
 	}
 }
 
+func TestGormExecutor_ExecResult(t *testing.T) {
+	db, err := NewGormDatabase(newSQLiteConfig())
+	if err != nil {
+		t.Fatalf("NewGormDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	executor := db.Executor()
+	ctx := context.Background()
+	if _, err := executor.Exec(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT)`); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+
+	for want := int64(1); want <= 2; want++ {
+		result, err := executor.Exec(ctx, "INSERT INTO users (name) VALUES (?)", "user")
+		if err != nil {
+			t.Fatalf("Exec() insert error = %v", err)
+		}
+		if id, err := result.LastInsertId(); err != nil || id != want {
+			t.Errorf("LastInsertId() = %d, %v; want %d", id, err, want)
+		}
+	}
+
+	// Without the callback registered by Config.Open, only the row count is known
+	var result hyperion.Result = rowsAffectedResult(2)
+	if n, err := result.RowsAffected(); err != nil || n != 2 {
+		t.Errorf("RowsAffected() = %d, %v; want 2", n, err)
+	}
+	if _, err := result.LastInsertId(); err == nil {
+		t.Error("LastInsertId() succeeded without the exec callback")
+	}
+}
+
+func TestGormExecutor_QueryRowMap(t *testing.T) {
+	db, err := NewGormDatabase(newSQLiteConfig())
+	if err != nil {
+		t.Fatalf("NewGormDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	executor := db.Executor()
+	ctx := context.Background()
+	if _, err := executor.Exec(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)`); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	if _, err := executor.Exec(ctx, "INSERT INTO users (id, name) VALUES (1, 'Alice'), (2, 'Bob')"); err != nil {
+		t.Fatalf("Exec() insert error = %v", err)
+	}
+
+	row := map[string]any{}
+	if err := executor.QueryRow(ctx, &row, "SELECT id, name FROM users ORDER BY id"); err != nil {
+		t.Fatalf("QueryRow() error = %v", err)
+	}
+	if row["name"] != "Alice" {
+		t.Errorf("QueryRow() = %v, want the first row", row)
+	}
+}
+
 func TestGormExecutor_Begin(t *testing.T) {
 	cfg := newSQLiteConfig()
 	db, err := NewGormDatabase(cfg)
@@ -138,7 +222,7 @@ func TestGormExecutor_Commit(t *testing.T) {
 	}
 
 	// Insert in transaction
-	if err := txExecutor.Exec(ctx, "INSERT INTO users (name) VALUES (?)", "Alice"); err != nil {
+	if _, err := txExecutor.Exec(ctx, "INSERT INTO users (name) VALUES (?)", "Alice"); err != nil {
 		t.Errorf("Exec() in transaction error = %v", err)
 	}
 
@@ -176,7 +260,7 @@ func TestGormExecutor_Rollback(t *testing.T) {
 	}
 
 	// Insert in transaction
-	if err := txExecutor.Exec(ctx, "INSERT INTO users (name) VALUES (?)", "Bob"); err != nil {
+	if _, err := txExecutor.Exec(ctx, "INSERT INTO users (name) VALUES (?)", "Bob"); err != nil {
 		t.Errorf("Exec() in transaction error = %v", err)
 	}
 
@@ -37,13 +37,13 @@ func TestIntegration_SQLite(t *testing.T) {
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)
 	`
-	if err := executor.Exec(ctx, createTableSQL); err != nil {
+	if _, err := executor.Exec(ctx, createTableSQL); err != nil {
 		t.Fatalf("Failed to create table: %v", err)
 	}
 
 	// Test CRUD operations
 	t.Run("Insert", func(t *testing.T) {
-		err := executor.Exec(ctx, "INSERT INTO users (name, email) VALUES (?, ?)", "Alice", "alice@example.com")
+		_, err := executor.Exec(ctx, "INSERT INTO users (name, email) VALUES (?, ?)", "Alice", "alice@example.com")
 		if err != nil {
 			t.Errorf("Insert failed: %v", err)
 		}
@@ -95,10 +95,10 @@ func TestIntegration_Transaction(t *testing.T) {
 		err := uow.WithTransaction(hctx, func(txCtx hyperion.Context) error {
 			// Transfer 200 from account 1 to account 2
 			txDB := txCtx.DB()
-			if err := txDB.Exec(txCtx, "UPDATE accounts SET balance = balance - 200 WHERE id = 1"); err != nil {
+			if _, err := txDB.Exec(txCtx, "UPDATE accounts SET balance = balance - 200 WHERE id = 1"); err != nil {
 				return err
 			}
-			if err := txDB.Exec(txCtx, "UPDATE accounts SET balance = balance + 200 WHERE id = 2"); err != nil {
+			if _, err := txDB.Exec(txCtx, "UPDATE accounts SET balance = balance + 200 WHERE id = 2"); err != nil {
 				return err
 			}
 			return nil
@@ -128,7 +128,7 @@ func TestIntegration_Transaction(t *testing.T) {
 
 		err := uow.WithTransaction(hctx, func(txCtx hyperion.Context) error {
 			txDB := txCtx.DB()
-			if err := txDB.Exec(txCtx, "UPDATE accounts SET balance = balance - 200 WHERE id = 1"); err != nil {
+			if _, err := txDB.Exec(txCtx, "UPDATE accounts SET balance = balance - 200 WHERE id = 1"); err != nil {
 				return err
 			}
 			// Simulate error before second update
@@ -185,7 +185,8 @@ func TestIntegration_IsolationLevels(t *testing.T) {
 
 			err := uow.WithTransactionOptions(hctx, opts, func(txCtx hyperion.Context) error {
 				txDB := txCtx.DB()
-				return txDB.Exec(txCtx, "UPDATE counters SET value = value + 1 WHERE id = 1")
+				_, err := txDB.Exec(txCtx, "UPDATE counters SET value = value + 1 WHERE id = 1")
+				return err
 			})
 
 			if err != nil {
@@ -229,7 +230,8 @@ func TestIntegration_ConcurrentTransactions(t *testing.T) {
 				txDB := txCtx.DB()
 				// Small sleep to increase chance of concurrent execution
 				time.Sleep(10 * time.Millisecond)
-				return txDB.Exec(txCtx, "UPDATE counters SET value = value + 1 WHERE id = 1")
+				_, err := txDB.Exec(txCtx, "UPDATE counters SET value = value + 1 WHERE id = 1")
+				return err
 			})
 			if err != nil {
 				t.Errorf("Concurrent transaction failed: %v", err)
@@ -303,17 +305,17 @@ func TestIntegration_NestedTransactions(t *testing.T) {
 
 	err = uow.WithTransaction(hctx, func(txCtx1 hyperion.Context) error {
 		txDB1 := txCtx1.DB()
-		if err := txDB1.Exec(txCtx1, "INSERT INTO logs (message) VALUES (?)", "outer-1"); err != nil {
+		if _, err := txDB1.Exec(txCtx1, "INSERT INTO logs (message) VALUES (?)", "outer-1"); err != nil {
 			return err
 		}
 
 		// Nested transaction (savepoint)
 		err := uow.WithTransaction(txCtx1, func(txCtx2 hyperion.Context) error {
 			txDB2 := txCtx2.DB()
-			if err := txDB2.Exec(txCtx2, "INSERT INTO logs (message) VALUES (?)", "inner-1"); err != nil {
+			if _, err := txDB2.Exec(txCtx2, "INSERT INTO logs (message) VALUES (?)", "inner-1"); err != nil {
 				return err
 			}
-			if err := txDB2.Exec(txCtx2, "INSERT INTO logs (message) VALUES (?)", "inner-2"); err != nil {
+			if _, err := txDB2.Exec(txCtx2, "INSERT INTO logs (message) VALUES (?)", "inner-2"); err != nil {
 				return err
 			}
 			return nil
@@ -322,7 +324,7 @@ func TestIntegration_NestedTransactions(t *testing.T) {
 			return err
 		}
 
-		if err := txDB1.Exec(txCtx1, "INSERT INTO logs (message) VALUES (?)", "outer-2"); err != nil {
+		if _, err := txDB1.Exec(txCtx1, "INSERT INTO logs (message) VALUES (?)", "outer-2"); err != nil {
 			return err
 		}
 
@@ -0,0 +1,38 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/hyperiontest"
+)
+
+// TestDatabaseLocker_Conformance runs the lock table through gormExecutor.
+func TestDatabaseLocker_Conformance(t *testing.T) {
+	hyperiontest.RunLockerSuite(t, hyperiontest.LockerHarness{
+		New: func(t *testing.T) hyperion.Locker {
+			// One connection, so every query sees the same in-memory database
+			db, err := NewGormDatabase(&mockConfig{
+				data: map[string]any{
+					"database": map[string]any{
+						"driver":         DriverSQLite,
+						"database":       ":memory:",
+						"max_open_conns": 1,
+						"log_level":      "silent",
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewGormDatabase() error = %v", err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+
+			locker := hyperion.NewDatabaseLocker(db.Executor())
+			if err := locker.CreateTable(context.Background()); err != nil {
+				t.Fatalf("CreateTable() error = %v", err)
+			}
+			return locker
+		},
+	})
+}
@@ -41,6 +41,18 @@ var Module = fx.Module("hyperion.adapter.gorm",
 			fx.As(new(hyperion.UnitOfWork)),
 		),
 	),
+	fx.Provide(
+		fx.Annotate(
+			func() hyperion.ConfigValidator { return ValidateConfig },
+			fx.ResultTags(`group:"hyperion.config_validators"`),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			ConfigSections,
+			fx.ResultTags(`group:"hyperion.config_sections,flatten"`),
+		),
+	),
 	fx.Invoke(registerLifecycle),
 )
 
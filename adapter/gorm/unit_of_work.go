@@ -2,6 +2,8 @@ package gorm
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 
 	"gorm.io/gorm"
 
@@ -35,13 +37,14 @@ func (u *gormUnitOfWork) WithTransaction(ctx hyperion.Context, fn func(txCtx hyp
 }
 
 // WithTransactionOptions executes fn within a database transaction with custom options.
-// It supports setting isolation level and read-only mode.
+// It supports setting isolation level, read-only mode and propagation.
+// A transaction already in ctx is recognized by its executor.
 //
 // Example:
 //
 //	opts := &hyperion.TransactionOptions{
-//	    Isolation: hyperion.IsolationLevelSerializable,
-//	    ReadOnly:  false,
+//	    Isolation:   hyperion.IsolationLevelSerializable,
+//	    Propagation: hyperion.PropagationNested,
 //	}
 //	err := uow.WithTransactionOptions(ctx, opts, func(txCtx hyperion.Context) error {
 //	    return orderRepo.Create(txCtx, order)
@@ -50,6 +53,46 @@ func (u *gormUnitOfWork) WithTransactionOptions(
 	ctx hyperion.Context,
 	opts *hyperion.TransactionOptions,
 	fn func(txCtx hyperion.Context) error,
+) error {
+	var propagation hyperion.Propagation
+	if opts != nil {
+		propagation = opts.Propagation
+	}
+	current, inTx := ctx.DB().(*gormExecutor)
+	inTx = inTx && current.isTx
+
+	switch propagation {
+	case hyperion.PropagationRequired:
+		if inTx {
+			return fn(ctx)
+		}
+	case hyperion.PropagationRequiresNew:
+	case hyperion.PropagationNested:
+		if inTx {
+			return u.withSavepoint(ctx, current, fn)
+		}
+	case hyperion.PropagationMandatory:
+		if !inTx {
+			return hyperion.ErrNoTransaction
+		}
+		return fn(ctx)
+	case hyperion.PropagationNever:
+		if inTx {
+			return hyperion.ErrTransactionExists
+		}
+		return fn(ctx)
+	default:
+		return fmt.Errorf("unknown transaction propagation: %d", propagation)
+	}
+
+	return u.begin(ctx, opts, fn)
+}
+
+// begin executes fn in a new transaction.
+func (u *gormUnitOfWork) begin(
+	ctx hyperion.Context,
+	opts *hyperion.TransactionOptions,
+	fn func(txCtx hyperion.Context) error,
 ) error {
 	// Convert options if provided
 	var txOpts *sql.TxOptions
@@ -60,8 +103,17 @@ func (u *gormUnitOfWork) WithTransactionOptions(
 		}
 	}
 
+	// Collect OnCommit and OnRollback hooks, run once the outcome is known
+	hooksCtx, hooks := hyperion.WithTransactionHooks(ctx)
+	defer func() {
+		if p := recover(); p != nil {
+			hooks.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
 	// Use GORM's transaction helper which handles panic recovery
-	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Create transaction executor
 		txExecutor := &gormExecutor{
 			db:   tx,
@@ -69,12 +121,66 @@ func (u *gormUnitOfWork) WithTransactionOptions(
 		}
 
 		// Inject transaction executor into context
-		txCtx := hyperion.WithDB(ctx, txExecutor)
+		txCtx := hyperion.WithDB(hooksCtx, txExecutor)
 
 		// Execute user function
 		// GORM's Transaction() already handles panic recovery and rollback
 		return fn(txCtx)
 	}, txOpts)
+	if err != nil {
+		hooks.Rollback(ctx)
+		return err
+	}
+
+	hooks.Commit(ctx)
+	return nil
+}
+
+// withSavepoint executes fn under a savepoint of the transaction in current.
+// If fn returns an error or panics, only its changes are rolled back.
+func (u *gormUnitOfWork) withSavepoint(
+	ctx hyperion.Context,
+	current *gormExecutor,
+	fn func(txCtx hyperion.Context) error,
+) error {
+	// Savepoints are named by depth: siblings may reuse a name, but a
+	// savepoint inside another must not shadow it.
+	name := fmt.Sprintf("hyperion_sp_%d", current.depth+1)
+	tx := current.db.WithContext(ctx)
+	if err := tx.SavePoint(name).Error; err != nil {
+		return fmt.Errorf("savepoint failed: %w", err)
+	}
+
+	// Roll back to the savepoint and re-panic
+	spCtx, hooks := hyperion.WithSavepointHooks(ctx)
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.RollbackTo(name).Error
+			hooks.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	nested := &gormExecutor{
+		db:    current.db,
+		isTx:  true,
+		depth: current.depth + 1,
+	}
+	if err := fn(hyperion.WithDB(spCtx, nested)); err != nil {
+		rbErr := tx.RollbackTo(name).Error
+		hooks.Rollback(ctx)
+		if rbErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback to savepoint failed: %w", rbErr))
+		}
+		return err
+	}
+
+	// The savepoint's work now belongs to the enclosing transaction
+	hooks.Commit(ctx)
+	if err := tx.Exec("RELEASE SAVEPOINT " + name).Error; err != nil {
+		return fmt.Errorf("release savepoint failed: %w", err)
+	}
+	return nil
 }
 
 // toSQLIsolation converts hyperion.IsolationLevel to sql.IsolationLevel.
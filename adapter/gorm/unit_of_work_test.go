@@ -108,7 +108,7 @@ func TestGormUnitOfWork_WithTransaction_Commit(t *testing.T) {
 	// Setup table
 	executor := db.Executor()
 	ctx := context.Background()
-	if execErr := executor.Exec(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)`); execErr != nil {
+	if _, execErr := executor.Exec(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)`); execErr != nil {
 		t.Fatalf("Failed to create table: %v", execErr)
 	}
 
@@ -129,7 +129,8 @@ func TestGormUnitOfWork_WithTransaction_Commit(t *testing.T) {
 		}
 
 		// Insert data
-		return txDB.Exec(txCtx, "INSERT INTO users (name) VALUES (?)", "Alice")
+		_, err := txDB.Exec(txCtx, "INSERT INTO users (name) VALUES (?)", "Alice")
+		return err
 	})
 
 	if err != nil {
@@ -157,7 +158,7 @@ func TestGormUnitOfWork_WithTransaction_Rollback(t *testing.T) {
 	// Setup table
 	executor := db.Executor()
 	ctx := context.Background()
-	if execErr := executor.Exec(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)`); execErr != nil {
+	if _, execErr := executor.Exec(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)`); execErr != nil {
 		t.Fatalf("Failed to create table: %v", execErr)
 	}
 
@@ -169,7 +170,7 @@ func TestGormUnitOfWork_WithTransaction_Rollback(t *testing.T) {
 	expectedErr := errors.New("test error")
 	err = uow.WithTransaction(hctx, func(txCtx hyperion.Context) error {
 		txDB := txCtx.DB()
-		if execErr := txDB.Exec(txCtx, "INSERT INTO users (name) VALUES (?)", "Bob"); execErr != nil {
+		if _, execErr := txDB.Exec(txCtx, "INSERT INTO users (name) VALUES (?)", "Bob"); execErr != nil {
 			t.Logf("Insert failed as expected: %v", execErr)
 		}
 		return expectedErr
@@ -200,7 +201,7 @@ func TestGormUnitOfWork_WithTransaction_Panic(t *testing.T) {
 	// Setup table
 	executor := db.Executor()
 	ctx := context.Background()
-	if execErr := executor.Exec(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)`); execErr != nil {
+	if _, execErr := executor.Exec(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)`); execErr != nil {
 		t.Fatalf("Failed to create table: %v", execErr)
 	}
 
@@ -217,7 +218,7 @@ func TestGormUnitOfWork_WithTransaction_Panic(t *testing.T) {
 
 	_ = uow.WithTransaction(hctx, func(txCtx hyperion.Context) error {
 		txDB := txCtx.DB()
-		if execErr := txDB.Exec(txCtx, "INSERT INTO users (name) VALUES (?)", "Charlie"); execErr != nil {
+		if _, execErr := txDB.Exec(txCtx, "INSERT INTO users (name) VALUES (?)", "Charlie"); execErr != nil {
 			t.Logf("Insert failed: %v", execErr)
 		}
 		panic("test panic")
@@ -244,7 +245,7 @@ func TestGormUnitOfWork_WithTransactionOptions(t *testing.T) {
 	// Setup table
 	executor := db.Executor()
 	ctx := context.Background()
-	if execErr := executor.Exec(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)`); execErr != nil {
+	if _, execErr := executor.Exec(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)`); execErr != nil {
 		t.Fatalf("Failed to create table: %v", execErr)
 	}
 
@@ -260,7 +261,8 @@ func TestGormUnitOfWork_WithTransactionOptions(t *testing.T) {
 
 	err = uow.WithTransactionOptions(hctx, opts, func(txCtx hyperion.Context) error {
 		txDB := txCtx.DB()
-		return txDB.Exec(txCtx, "INSERT INTO users (name) VALUES (?)", "David")
+		_, err := txDB.Exec(txCtx, "INSERT INTO users (name) VALUES (?)", "David")
+		return err
 	})
 
 	if err != nil {
@@ -278,11 +280,6 @@ func TestGormUnitOfWork_WithTransactionOptions(t *testing.T) {
 }
 
 func TestGormUnitOfWork_NestedTransaction(t *testing.T) {
-	// Note: This test demonstrates nested transaction support which relies on
-	// database savepoint capabilities. SQLite in-memory databases may have
-	// limitations with savepoints in GORM's transaction handling.
-	// For full nested transaction testing, use PostgreSQL or MySQL in integration tests.
-
 	cfg := newSQLiteConfig()
 	db, err := NewGormDatabase(cfg)
 	if err != nil {
@@ -303,38 +300,50 @@ func TestGormUnitOfWork_NestedTransaction(t *testing.T) {
 	uow := NewGormUnitOfWork(db)
 	hctx := newTestContext(executor)
 
-	// Test that nested WithTransaction calls don't fail (they may or may not create savepoints)
+	// A nested WithTransaction joins the outer transaction (PropagationRequired)
 	err = uow.WithTransaction(hctx, func(txCtx1 hyperion.Context) error {
 		gormDB := txCtx1.DB().Unwrap().(*gorm.DB)
 		if execErr := gormDB.Exec("INSERT INTO nested_users (name) VALUES (?)", "Eve").Error; execErr != nil {
 			return execErr
 		}
 
-		// This may create a savepoint or reuse the existing transaction depending on GORM/DB behavior
-		innerErr := uow.WithTransaction(txCtx1, func(txCtx2 hyperion.Context) error {
+		return uow.WithTransaction(txCtx1, func(txCtx2 hyperion.Context) error {
+			if txCtx2.DB() != txCtx1.DB() {
+				t.Error("nested WithTransaction did not join the outer transaction")
+			}
 			gormDB2 := txCtx2.DB().Unwrap().(*gorm.DB)
 			return gormDB2.Exec("INSERT INTO nested_users (name) VALUES (?)", "Frank").Error
 		})
-
-		// For SQLite in-memory, nested transactions might not work as expected
-		// The test verifies that the API doesn't panic, actual savepoint behavior
-		// is database-dependent
-		return innerErr
 	})
+	if err != nil {
+		t.Fatalf("Nested transaction failed: %v", err)
+	}
 
-	// If the operation succeeded, verify data was committed
-	// If it failed due to savepoint limitations, that's also acceptable for SQLite
+	var count int64
+	if queryErr := executor.Query(ctx, &count, "SELECT COUNT(*) FROM nested_users"); queryErr != nil {
+		t.Fatalf("Failed to query count: %v", queryErr)
+	}
+	if count != 2 {
+		t.Errorf("After nested transaction, count = %d, want 2", count)
+	}
+}
+
+func TestGormUnitOfWork_UnknownPropagation(t *testing.T) {
+	cfg := newSQLiteConfig()
+	db, err := NewGormDatabase(cfg)
+	if err != nil {
+		t.Fatalf("NewGormDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	uow := NewGormUnitOfWork(db)
+	opts := &hyperion.TransactionOptions{Propagation: hyperion.Propagation(99)}
+	err = uow.WithTransactionOptions(newTestContext(db.Executor()), opts, func(hyperion.Context) error {
+		t.Error("fn ran with an unknown propagation")
+		return nil
+	})
 	if err == nil {
-		var count int64
-		if queryErr := executor.Query(ctx, &count, "SELECT COUNT(*) FROM nested_users"); queryErr != nil {
-			t.Fatalf("Failed to query count: %v", queryErr)
-		}
-		// At least the outer transaction should have committed
-		if count < 1 {
-			t.Errorf("After nested transaction, count = %d, want at least 1", count)
-		}
-	} else {
-		t.Logf("Nested transaction not fully supported (expected for SQLite in-memory): %v", err)
+		t.Error("WithTransactionOptions() error = nil, want unknown propagation error")
 	}
 }
 
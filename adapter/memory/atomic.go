@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+// Ensure memoryCache implements hyperion.AtomicCache interface.
+var _ hyperion.AtomicCache = (*memoryCache)(nil)
+
+// incrBy adds delta to the integer stored under key, creating it with ttl.
+func (s *store) incrBy(key string, delta int64, ttl time.Duration, ev *evictions) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	n, expiresAt := delta, expiry(now, ttl)
+	if e, ok := s.lookupLocked(key, now, ev); ok {
+		current, err := strconv.ParseInt(string(e.value), 10, 64)
+		if err != nil {
+			return 0, hyperion.ErrNotInteger
+		}
+		if (delta > 0 && current > math.MaxInt64-delta) || (delta < 0 && current < math.MinInt64-delta) {
+			return 0, fmt.Errorf("%w: increment would overflow", hyperion.ErrNotInteger)
+		}
+		n, expiresAt = current+delta, e.expiresAt
+	}
+	s.putLocked(key, strconv.AppendInt(nil, n, 10), expiresAt, nil, false, now, ev)
+	return n, nil
+}
+
+// setNX stores value under key unless it holds an unexpired entry.
+func (s *store) setNX(key string, value []byte, ttl time.Duration, ev *evictions) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if _, ok := s.lookupLocked(key, now, ev); ok {
+		return false
+	}
+	s.putLocked(key, value, expiry(now, ttl), nil, false, now, ev)
+	return true
+}
+
+// compareAndSwap stores value under key if it currently holds old.
+func (s *store) compareAndSwap(key string, old, value []byte, ttl time.Duration, ev *evictions) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	e, ok := s.lookupLocked(key, now, ev)
+	if !ok || !bytes.Equal(e.value, old) {
+		return false
+	}
+	s.putLocked(key, value, expiry(now, ttl), nil, false, now, ev)
+	return true
+}
+
+// expire sets the TTL of an unexpired entry and reports whether it exists.
+func (s *store) expire(key string, ttl time.Duration, ev *evictions) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	e, ok := s.lookupLocked(key, now, ev)
+	if ok {
+		e.expiresAt = expiry(now, ttl)
+	}
+	return ok
+}
+
+// ttl returns the remaining TTL of an unexpired entry, 0 if it does not expire.
+func (s *store) ttl(key string, ev *evictions) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	e, ok := s.lookupLocked(key, now, ev)
+	if !ok || e.expiresAt.IsZero() {
+		return 0, ok
+	}
+	return e.expiresAt.Sub(now), true
+}
+
+// getSet stores value under key and returns a copy of the previous value.
+func (s *store) getSet(key string, value []byte, ttl time.Duration, ev *evictions) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var old []byte
+	e, ok := s.lookupLocked(key, now, ev)
+	if ok {
+		old = append([]byte(nil), e.value...)
+	}
+	s.putLocked(key, value, expiry(now, ttl), nil, false, now, ev)
+	return old, ok
+}
+
+// checkSize returns ErrValueTooLarge if value cannot fit in the cache.
+func (c *memoryCache) checkSize(value []byte) error {
+	if c.maxCost > 0 && int64(len(value)) > c.maxCost {
+		return fmt.Errorf("%w: %d bytes", ErrValueTooLarge, len(value))
+	}
+	return nil
+}
+
+// Incr increments the integer stored under key by 1.
+func (c *memoryCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return c.IncrBy(ctx, key, 1, ttl)
+}
+
+// IncrBy adds delta to the integer stored under key, creating it with ttl.
+// Atomic writes bypass the TinyLFU admission filter, so a new counter is
+// always stored.
+func (c *memoryCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	var ev evictions
+	n, err := c.store.incrBy(key, delta, ttl, &ev)
+	c.record(ctx, &ev)
+	return n, err
+}
+
+// SetNX stores value only if key does not exist.
+func (c *memoryCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	if err := c.checkSize(value); err != nil {
+		return false, err
+	}
+	var ev evictions
+	ok := c.store.setNX(key, value, ttl, &ev)
+	c.record(ctx, &ev)
+	return ok, nil
+}
+
+// CompareAndSwap stores value only if key currently holds old.
+func (c *memoryCache) CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error) {
+	if err := c.checkSize(value); err != nil {
+		return false, err
+	}
+	var ev evictions
+	ok := c.store.compareAndSwap(key, old, value, ttl, &ev)
+	c.record(ctx, &ev)
+	return ok, nil
+}
+
+// Expire sets the TTL of an existing key.
+func (c *memoryCache) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	var ev evictions
+	ok := c.store.expire(key, ttl, &ev)
+	c.record(ctx, &ev)
+	return ok, nil
+}
+
+// TTL returns the remaining time to live of key.
+// Returns ErrNotFound if the key is missing or expired.
+func (c *memoryCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	var ev evictions
+	ttl, ok := c.store.ttl(key, &ev)
+	c.record(ctx, &ev)
+	if !ok {
+		return 0, ErrNotFound
+	}
+	return ttl, nil
+}
+
+// GetSet stores value and returns the previous value.
+func (c *memoryCache) GetSet(ctx context.Context, key string, value []byte, ttl time.Duration) ([]byte, bool, error) {
+	if err := c.checkSize(value); err != nil {
+		return nil, false, err
+	}
+	var ev evictions
+	old, found := c.store.getSet(key, value, ttl, &ev)
+	c.record(ctx, &ev)
+	return old, found, nil
+}
@@ -0,0 +1,242 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+var (
+	// ErrNotFound is returned by Get for keys that are missing or expired.
+	// It is hyperion.ErrCacheMiss.
+	ErrNotFound = hyperion.ErrCacheMiss
+
+	// ErrValueTooLarge is returned by Set for values larger than MaxCost.
+	ErrValueTooLarge = errors.New("memory cache: value exceeds max_cost")
+)
+
+// memoryCache implements hyperion.Cache on top of an in-process store.
+type memoryCache struct {
+	store     *store
+	maxCost   int64
+	evictions hyperion.Counter
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Ensure memoryCache implements hyperion.TaggedCache interface.
+var _ hyperion.TaggedCache = (*memoryCache)(nil)
+
+// NewMemoryCache creates an in-memory cache configured from the "cache"
+// section of cfg. Evictions are recorded with meter; hits, misses and
+// latency come from hyperion.InstrumentCache.
+//
+// The returned cache runs a background sweep of expired entries; Module
+// stops it on shutdown. Callers constructing the cache directly should
+// call its Close method, available through an io.Closer assertion.
+func NewMemoryCache(cfg hyperion.Config, meter hyperion.Meter) (hyperion.Cache, error) {
+	cacheCfg, err := LoadConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cache config: %w", err)
+	}
+	return New(cacheCfg, meter)
+}
+
+// New creates an in-memory cache from cacheCfg.
+// A nil meter disables metrics.
+func New(cacheCfg Config, meter hyperion.Meter) (hyperion.Cache, error) {
+	if err := cacheCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cache config: %w", err)
+	}
+	if meter == nil {
+		meter = hyperion.NewNoOpMeter()
+	}
+
+	var sketch *frequencySketch
+	if cacheCfg.Policy == PolicyTinyLFU {
+		sketch = newFrequencySketch(sketchCapacity(cacheCfg))
+	}
+
+	c := &memoryCache{
+		store:   newStore(cacheCfg.MaxCost, cacheCfg.MaxItems, sketch),
+		maxCost: cacheCfg.MaxCost,
+		evictions: meter.Counter("cache.evictions",
+			hyperion.WithMetricDescription("Entries removed or refused by the cache, by reason"),
+			hyperion.WithMetricUnit("1"),
+		),
+	}
+
+	if cacheCfg.CleanupInterval > 0 {
+		c.stop = make(chan struct{})
+		c.done = make(chan struct{})
+		go c.sweep(cacheCfg.CleanupInterval)
+	}
+	return c, nil
+}
+
+// sketchCapacity estimates how many keys the frequency sketch must track.
+func sketchCapacity(cacheCfg Config) int {
+	if cacheCfg.MaxItems > 0 {
+		return cacheCfg.MaxItems
+	}
+	// Assume values of about 1 KiB when only the size is limited
+	if capacity := cacheCfg.MaxCost / 1024; capacity > 0 && capacity < 1<<20 {
+		return int(capacity)
+	}
+	return 1 << 20
+}
+
+// Get retrieves the value for the given key.
+// Returns ErrNotFound if the key is missing or expired.
+func (c *memoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	var ev evictions
+	value, ok := c.store.get(key, &ev)
+	c.record(ctx, &ev)
+
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return value, nil
+}
+
+// Set stores the value for the given key with the specified TTL.
+// A TTL of 0 means no expiration.
+//
+// With the TinyLFU policy a full cache may refuse a new key that is used
+// less often than the entries it would displace; Set still returns nil.
+func (c *memoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.checkSize(value); err != nil {
+		return err
+	}
+
+	var ev evictions
+	c.store.set(key, value, ttl, nil, &ev)
+	c.record(ctx, &ev)
+	return nil
+}
+
+// SetWithTags stores the value like Set and associates key with tags.
+// Tags are kept until the entry is removed, and accumulate when the key
+// is set again.
+func (c *memoryCache) SetWithTags(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	if err := c.checkSize(value); err != nil {
+		return err
+	}
+
+	var ev evictions
+	c.store.set(key, value, ttl, tags, &ev)
+	c.record(ctx, &ev)
+	return nil
+}
+
+// InvalidateTags removes every entry associated with any of tags.
+func (c *memoryCache) InvalidateTags(_ context.Context, tags ...string) error {
+	c.store.invalidateTags(tags)
+	return nil
+}
+
+// DeletePrefix removes every entry whose key starts with prefix.
+// It scans all entries.
+func (c *memoryCache) DeletePrefix(_ context.Context, prefix string) error {
+	c.store.deletePrefix(prefix)
+	return nil
+}
+
+// Delete removes the value for the given key.
+func (c *memoryCache) Delete(_ context.Context, key string) error {
+	c.store.delete(key)
+	return nil
+}
+
+// Exists checks if the key exists in the cache.
+// It does not count as an access for TinyLFU.
+func (c *memoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	var ev evictions
+	ok := c.store.exists(key, &ev)
+	c.record(ctx, &ev)
+	return ok, nil
+}
+
+// MGet retrieves multiple values for the given keys.
+// Missing keys are omitted from the result.
+func (c *memoryCache) MGet(ctx context.Context, keys ...string) (map[string][]byte, error) {
+	var ev evictions
+	result := c.store.getMany(keys, &ev)
+	c.record(ctx, &ev)
+	return result, nil
+}
+
+// MSet stores multiple key-value pairs with the specified TTL.
+// No item is stored if any value is larger than MaxCost.
+func (c *memoryCache) MSet(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	if c.maxCost > 0 {
+		for key, value := range items {
+			if int64(len(value)) > c.maxCost {
+				return fmt.Errorf("%w: %q is %d bytes", ErrValueTooLarge, key, len(value))
+			}
+		}
+	}
+
+	var ev evictions
+	c.store.setMany(items, ttl, &ev)
+	c.record(ctx, &ev)
+	return nil
+}
+
+// Clear removes all entries from the cache.
+func (c *memoryCache) Clear(_ context.Context) error {
+	c.store.clear()
+	return nil
+}
+
+// Close stops the background sweep of expired entries.
+// The cache remains usable after Close.
+func (c *memoryCache) Close() error {
+	c.closeOnce.Do(func() {
+		if c.stop != nil {
+			close(c.stop)
+			<-c.done
+		}
+	})
+	return nil
+}
+
+// sweep periodically removes expired entries until Close is called.
+func (c *memoryCache) sweep(interval time.Duration) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			var ev evictions
+			c.store.removeExpired(&ev)
+			c.record(context.Background(), &ev)
+		}
+	}
+}
+
+// cacheAttr identifies this adapter in metrics.
+var cacheAttr = hyperion.String("cache.adapter", "memory")
+
+// record reports the evictions counted during an operation.
+func (c *memoryCache) record(ctx context.Context, ev *evictions) {
+	if ev.size > 0 {
+		c.evictions.Add(ctx, ev.size, cacheAttr, hyperion.String("reason", "size"))
+	}
+	if ev.expired > 0 {
+		c.evictions.Add(ctx, ev.expired, cacheAttr, hyperion.String("reason", "expired"))
+	}
+	if ev.rejected > 0 {
+		c.evictions.Add(ctx, ev.rejected, cacheAttr, hyperion.String("reason", "rejected"))
+	}
+}
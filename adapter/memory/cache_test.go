@@ -0,0 +1,332 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/adapter/memory"
+	"github.com/mapoio/hyperion/hyperiontest"
+)
+
+func newCache(t *testing.T, cfg memory.Config, meter hyperion.Meter) hyperion.Cache {
+	t.Helper()
+	cache, err := memory.New(cfg, meter)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cache.(io.Closer).Close() })
+	return cache
+}
+
+func TestConformance(t *testing.T) {
+	for _, policy := range []string{memory.PolicyLRU, memory.PolicyTinyLFU} {
+		t.Run(policy, func(t *testing.T) {
+			h := hyperiontest.CacheHarness{
+				New: func(t *testing.T) hyperion.Cache {
+					cfg := memory.DefaultConfig()
+					cfg.Policy = policy
+					return newCache(t, cfg, nil)
+				},
+			}
+			hyperiontest.RunCacheSuite(t, h)
+			hyperiontest.RunTaggedCacheSuite(t, h)
+			hyperiontest.RunAtomicCacheSuite(t, h)
+		})
+	}
+}
+
+func TestAtomicBypassesAdmission(t *testing.T) {
+	ctx := context.Background()
+	cache := newCache(t, memory.Config{Policy: memory.PolicyTinyLFU, MaxItems: 2}, nil).(hyperion.AtomicCache)
+
+	// Make the resident keys hot, so the filter would reject new ones
+	for _, key := range []string{"hot1", "hot2"} {
+		_ = cache.Set(ctx, key, []byte("v"), 0)
+		for i := 0; i < 10; i++ {
+			_, _ = cache.Get(ctx, key)
+		}
+	}
+
+	if n, err := cache.Incr(ctx, "counter", 0); err != nil || n != 1 {
+		t.Fatalf("Incr = %d, %v, want 1", n, err)
+	}
+	if n, err := cache.Incr(ctx, "counter", 0); err != nil || n != 2 {
+		t.Errorf("second Incr = %d, %v, want 2: the counter was not stored", n, err)
+	}
+	if ok, err := cache.SetNX(ctx, "lock", []byte("a"), 0); err != nil || !ok {
+		t.Fatalf("SetNX = %v, %v, want true", ok, err)
+	}
+	if ok, _ := cache.SetNX(ctx, "lock", []byte("b"), 0); ok {
+		t.Error("second SetNX succeeded: the first was not stored")
+	}
+}
+
+func TestIncrOverflow(t *testing.T) {
+	ctx := context.Background()
+	cache := newCache(t, memory.DefaultConfig(), nil).(hyperion.AtomicCache)
+	_ = cache.Set(ctx, "max", []byte("9223372036854775807"), 0)
+
+	if _, err := cache.Incr(ctx, "max", 0); !errors.Is(err, hyperion.ErrNotInteger) {
+		t.Errorf("Incr past MaxInt64 = %v, want ErrNotInteger", err)
+	}
+	if _, err := cache.SetNX(ctx, "big", make([]byte, 128<<20), 0); !errors.Is(err, memory.ErrValueTooLarge) {
+		t.Errorf("SetNX of a value larger than max_cost = %v, want ErrValueTooLarge", err)
+	}
+}
+
+func TestTagsDroppedOnEviction(t *testing.T) {
+	ctx := context.Background()
+	cache := newCache(t, memory.Config{Policy: memory.PolicyLRU, MaxItems: 1}, nil).(hyperion.TaggedCache)
+
+	_ = cache.SetWithTags(ctx, "a", []byte("1"), 0, "tag")
+	_ = cache.Set(ctx, "b", []byte("2"), 0) // evicts a
+	_ = cache.Set(ctx, "a", []byte("3"), 0) // evicts b, untagged this time
+
+	if err := cache.InvalidateTags(ctx, "tag"); err != nil {
+		t.Fatalf("InvalidateTags failed: %v", err)
+	}
+	if got, err := cache.Get(ctx, "a"); err != nil || string(got) != "3" {
+		t.Errorf("Get(a) = %q, %v, want 3: the evicted entry's tag must not follow the key", got, err)
+	}
+}
+
+// countingMeter records the sum added to each counter, keyed by
+// name and "reason" attribute.
+type countingMeter struct {
+	hyperion.Meter
+	counts map[string]int64
+	mu     sync.Mutex
+}
+
+func newCountingMeter() *countingMeter {
+	return &countingMeter{Meter: hyperion.NewNoOpMeter(), counts: make(map[string]int64)}
+}
+
+func (m *countingMeter) Counter(name string, _ ...hyperion.MetricOption) hyperion.Counter {
+	return &countingCounter{meter: m, name: name}
+}
+
+func (m *countingMeter) count(name string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counts[name]
+}
+
+type countingCounter struct {
+	meter *countingMeter
+	name  string
+}
+
+func (c *countingCounter) Add(_ context.Context, value int64, attrs ...hyperion.Attribute) {
+	name := c.name
+	for _, attr := range attrs {
+		if attr.Key == "reason" {
+			name += "/" + fmt.Sprint(attr.Value)
+		}
+	}
+	c.meter.mu.Lock()
+	defer c.meter.mu.Unlock()
+	c.meter.counts[name] += value
+}
+
+func TestLRUEviction(t *testing.T) {
+	ctx := context.Background()
+	meter := newCountingMeter()
+	cache := newCache(t, memory.Config{Policy: memory.PolicyLRU, MaxItems: 2}, meter)
+
+	_ = cache.Set(ctx, "a", []byte("1"), 0)
+	_ = cache.Set(ctx, "b", []byte("2"), 0)
+	_, _ = cache.Get(ctx, "a") // b is now least recently used
+	_ = cache.Set(ctx, "c", []byte("3"), 0)
+
+	got, _ := cache.MGet(ctx, "a", "b", "c")
+	if len(got) != 2 || got["a"] == nil || got["c"] == nil {
+		t.Errorf("MGet = %q, want a and c", got)
+	}
+	if n := meter.count("cache.evictions/size"); n != 1 {
+		t.Errorf("size evictions = %d, want 1", n)
+	}
+}
+
+func TestMaxCost(t *testing.T) {
+	ctx := context.Background()
+	cache := newCache(t, memory.Config{Policy: memory.PolicyLRU, MaxCost: 10}, nil)
+
+	_ = cache.Set(ctx, "a", []byte("12345"), 0)
+	_ = cache.Set(ctx, "b", []byte("12345"), 0)
+	_ = cache.Set(ctx, "c", []byte("123"), 0)
+
+	if ok, _ := cache.Exists(ctx, "a"); ok {
+		t.Error("a should have been evicted to fit c")
+	}
+	for _, key := range []string{"b", "c"} {
+		if ok, _ := cache.Exists(ctx, key); !ok {
+			t.Errorf("%s should still be cached", key)
+		}
+	}
+
+	err := cache.Set(ctx, "big", []byte(strings.Repeat("x", 11)), 0)
+	if !errors.Is(err, memory.ErrValueTooLarge) {
+		t.Errorf("Set of a value larger than max_cost returned %v, want ErrValueTooLarge", err)
+	}
+	err = cache.MSet(ctx, map[string][]byte{"d": []byte("1"), "big": []byte(strings.Repeat("x", 11))}, 0)
+	if !errors.Is(err, memory.ErrValueTooLarge) {
+		t.Errorf("MSet with a value larger than max_cost returned %v, want ErrValueTooLarge", err)
+	}
+	if ok, _ := cache.Exists(ctx, "d"); ok {
+		t.Error("MSet should store nothing when a value is too large")
+	}
+}
+
+func TestTinyLFUKeepsHotKeys(t *testing.T) {
+	ctx := context.Background()
+	meter := newCountingMeter()
+	cache := newCache(t, memory.Config{Policy: memory.PolicyTinyLFU, MaxItems: 10}, meter)
+
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("hot-%d", i)
+		_ = cache.Set(ctx, key, []byte(key), 0)
+		for j := 0; j < 10; j++ {
+			_, _ = cache.Get(ctx, key)
+		}
+	}
+
+	// A scan of one-off keys must not displace the hot set
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("scan-%d", i)
+		_ = cache.Set(ctx, key, []byte(key), 0)
+	}
+
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("hot-%d", i)
+		if _, err := cache.Get(ctx, key); err != nil {
+			t.Errorf("Get(%s) failed after scan: %v", key, err)
+		}
+	}
+	if n := meter.count("cache.evictions/rejected"); n != 100 {
+		t.Errorf("rejected = %d, want 100", n)
+	}
+
+	// A key requested often enough is admitted
+	for i := 0; i < 14; i++ {
+		_, _ = cache.Get(ctx, "popular")
+	}
+	_ = cache.Set(ctx, "popular", []byte("v"), 0)
+	if _, err := cache.Get(ctx, "popular"); err != nil {
+		t.Errorf("Get(popular) failed: %v", err)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+	meter := newCountingMeter()
+	cache := newCache(t, memory.DefaultConfig(), meter)
+
+	_ = cache.Set(ctx, "a", []byte("1"), 0)
+	_ = cache.Set(ctx, "short", []byte("1"), time.Millisecond)
+	_, _ = cache.Get(ctx, "a")
+	_, _ = cache.Get(ctx, "missing")
+	_, _ = cache.MGet(ctx, "a", "b", "c")
+	_, _ = cache.Exists(ctx, "a")
+
+	time.Sleep(5 * time.Millisecond)
+	_, _ = cache.Get(ctx, "short")
+
+	if n := meter.count("cache.hits") + meter.count("cache.misses"); n != 0 {
+		t.Errorf("lookups counted = %d, want 0, left to hyperion.InstrumentCache", n)
+	}
+	if n := meter.count("cache.evictions/expired"); n != 1 {
+		t.Errorf("expired evictions = %d, want 1", n)
+	}
+}
+
+func TestCleanupSweep(t *testing.T) {
+	ctx := context.Background()
+	meter := newCountingMeter()
+	cfg := memory.DefaultConfig()
+	cfg.CleanupInterval = 10 * time.Millisecond
+	cache := newCache(t, cfg, meter)
+
+	_ = cache.Set(ctx, "short", []byte("1"), time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	for meter.count("cache.evictions/expired") == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expired entry was not swept")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := cache.(io.Closer).Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if err := cache.(io.Closer).Close(); err != nil {
+		t.Errorf("second Close failed: %v", err)
+	}
+}
+
+func TestNewMemoryCache_Config(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"cache": map[string]any{"policy": "lru", "max_items": 1, "cleanup_interval": "0s"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := memory.LoadConfig(cfg)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	want := memory.Config{Policy: "lru", MaxCost: 64 << 20, MaxItems: 1}
+	if loaded != want {
+		t.Errorf("LoadConfig = %+v, want %+v", loaded, want)
+	}
+
+	if _, err := memory.NewMemoryCache(cfg, nil); err != nil {
+		t.Errorf("NewMemoryCache failed: %v", err)
+	}
+
+	bad, _ := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"cache": map[string]any{"policy": "fifo"},
+	}))
+	if _, err := memory.NewMemoryCache(bad, nil); err == nil {
+		t.Error("NewMemoryCache accepted policy fifo")
+	}
+	if err := memory.ValidateConfig(bad); err == nil {
+		t.Error("ValidateConfig accepted policy fifo")
+	}
+	if _, err := memory.New(memory.Config{Policy: "lru", MaxItems: -1}, nil); err == nil {
+		t.Error("New accepted negative max_items")
+	}
+}
+
+func TestModule(t *testing.T) {
+	var cache hyperion.Cache
+	app := fxtest.New(t,
+		fx.Provide(func() hyperion.Config { return hyperion.NewNoOpConfig() }),
+		fx.Provide(func() hyperion.Meter { return hyperion.NewNoOpMeter() }),
+		memory.Module,
+		fx.Populate(&cache),
+	)
+	app.RequireStart()
+
+	ctx := context.Background()
+	if err := cache.Set(ctx, "key", []byte("value"), 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, err := cache.Get(ctx, "key"); err != nil || string(got) != "value" {
+		t.Errorf("Get = %q, %v, want value", got, err)
+	}
+
+	app.RequireStop()
+}
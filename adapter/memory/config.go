@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+const (
+	// PolicyLRU evicts the least recently used entries.
+	PolicyLRU = "lru"
+
+	// PolicyTinyLFU evicts like PolicyLRU, but only admits a new entry if it
+	// is accessed more often than the entries it would evict. This keeps
+	// one-off keys from flushing out popular ones.
+	PolicyTinyLFU = "tinylfu"
+)
+
+// Config holds configuration for the in-memory cache, read from the
+// "cache" section.
+type Config struct {
+	// Policy selects the eviction policy: lru or tinylfu.
+	Policy string `mapstructure:"policy" json:"policy" default:"tinylfu" validate:"oneof=lru tinylfu"`
+
+	// MaxCost limits the total size of cached values in bytes. 0 means unlimited.
+	MaxCost int64 `mapstructure:"max_cost" json:"max_cost" default:"67108864" validate:"min=0"`
+
+	// MaxItems limits the number of entries. 0 means unlimited.
+	MaxItems int `mapstructure:"max_items" json:"max_items" validate:"min=0"`
+
+	// CleanupInterval is how often expired entries are swept.
+	// 0 disables the sweep; expired entries are then only removed on
+	// access or when room is needed.
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval" json:"cleanup_interval" default:"1m" validate:"min=0"`
+}
+
+// DefaultConfig returns a configuration with sensible defaults:
+// TinyLFU with a 64 MiB limit and a sweep every minute.
+func DefaultConfig() Config {
+	return Config{
+		Policy:          PolicyTinyLFU,
+		MaxCost:         64 << 20,
+		CleanupInterval: time.Minute,
+	}
+}
+
+// Validate checks the configuration.
+func (c *Config) Validate() error {
+	if c.Policy != PolicyLRU && c.Policy != PolicyTinyLFU {
+		return fmt.Errorf("unsupported policy %q", c.Policy)
+	}
+	if c.MaxCost < 0 || c.MaxItems < 0 || c.CleanupInterval < 0 {
+		return fmt.Errorf("max_cost, max_items and cleanup_interval must not be negative")
+	}
+	return nil
+}
+
+// LoadConfig reads the "cache" section of cfg over the defaults.
+func LoadConfig(cfg hyperion.Config) (Config, error) {
+	if cfg == nil {
+		return DefaultConfig(), nil
+	}
+	return hyperion.Bind[Config](cfg, "cache")
+}
+
+// ValidateConfig loads the cache configuration from cfg and validates it.
+// It is registered as a hyperion.ConfigValidator by Module so that config
+// reloads with an invalid cache section are rejected.
+func ValidateConfig(cfg hyperion.Config) error {
+	_, err := LoadConfig(cfg)
+	return err
+}
+
+// ConfigSections describes the "cache" section for JSON Schema generation
+// and linting. Module contributes it to the "hyperion.config_sections" group.
+func ConfigSections() []hyperion.ConfigSection {
+	return []hyperion.ConfigSection{{
+		Key:         "cache",
+		Type:        Config{},
+		Validate:    ValidateConfig,
+		Description: "In-memory cache limits and eviction policy (adapter/memory)",
+	}}
+}
@@ -0,0 +1,44 @@
+// Package memory provides an in-process implementation of the
+// hyperion.Cache interface.
+//
+// # Features
+//
+//   - Size (max_cost, in bytes) and entry count (max_items) limits
+//   - LRU or TinyLFU eviction
+//   - Per-entry TTLs with lazy expiry and a periodic sweep
+//   - Tag and prefix invalidation through hyperion.TaggedCache
+//   - Atomic counters and conditional writes through hyperion.AtomicCache
+//   - Eviction counters through hyperion.Meter
+//
+// Values are copied on Set and Get, so callers may reuse their buffers.
+//
+// # Eviction Policies
+//
+// Both policies evict the least recently used entries when a new entry
+// does not fit. TinyLFU (the default) additionally tracks approximate
+// access frequencies in a count-min sketch, and only admits a new key if
+// it has been requested more often than every entry it would displace.
+// A scan of one-off keys therefore cannot flush out hot entries. Keys
+// refused this way are counted as evictions with reason "rejected".
+//
+// # Configuration
+//
+// The cache reads configuration from the provided hyperion.Config under
+// the "cache" key:
+//
+//	cache:
+//	  policy: tinylfu         # lru or tinylfu
+//	  max_cost: 67108864      # bytes of cached values, 0 = unlimited
+//	  max_items: 0            # 0 = unlimited
+//	  cleanup_interval: 1m    # expired entry sweep, 0 = disabled
+//
+// # Metrics
+//
+// Recorded with the "cache.adapter" attribute set to "memory":
+//
+//   - cache.evictions: entries removed or refused, by "reason"
+//     (size, expired or rejected)
+//
+// Hits, misses, latency and spans are recorded by wrapping the cache with
+// hyperion.InstrumentCache, or by hyperion.CacheInstrumentationModule.
+package memory
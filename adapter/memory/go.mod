@@ -0,0 +1,17 @@
+module github.com/mapoio/hyperion/adapter/memory
+
+go 1.24
+
+require (
+	github.com/mapoio/hyperion v0.0.0-00010101000000-000000000000
+	go.uber.org/fx v1.24.0
+)
+
+require (
+	go.uber.org/dig v1.19.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	go.uber.org/zap v1.27.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
+
+replace github.com/mapoio/hyperion => ../../hyperion
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"io"
+
+	"go.uber.org/fx"
+
+	"github.com/mapoio/hyperion"
+)
+
+// Module provides an in-memory Cache implementation.
+//
+// Usage:
+//
+//	fx.New(
+//	    hyperion.CoreModule,
+//	    viper.Module,   // Provides Config
+//	    otel.Module,    // Provides Meter for cache metrics
+//	    memory.Module,  // Provides Cache
+//	    myapp.Module,
+//	).Run()
+//
+// Configuration example (config.yaml):
+//
+//	cache:
+//	  policy: tinylfu         # lru or tinylfu
+//	  max_cost: 134217728     # bytes of cached values, 0 = unlimited
+//	  max_items: 100000       # 0 = unlimited
+//	  cleanup_interval: 1m    # expired entry sweep, 0 = disabled
+var Module = fx.Module("hyperion.adapter.memory",
+	fx.Provide(
+		fx.Annotate(
+			NewMemoryCacheProvider,
+			fx.As(new(hyperion.Cache)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			func() hyperion.ConfigValidator { return ValidateConfig },
+			fx.ResultTags(`group:"hyperion.config_validators"`),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			ConfigSections,
+			fx.ResultTags(`group:"hyperion.config_sections,flatten"`),
+		),
+	),
+	fx.Invoke(registerLifecycle),
+)
+
+// NewMemoryCacheProvider creates an in-memory cache.
+func NewMemoryCacheProvider(cfg hyperion.Config, meter hyperion.Meter) (hyperion.Cache, error) {
+	return NewMemoryCache(cfg, meter)
+}
+
+// registerLifecycle stops the expired entry sweep on shutdown.
+func registerLifecycle(lc fx.Lifecycle, cache hyperion.Cache) {
+	closer, ok := cache.(io.Closer)
+	if !ok {
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return closer.Close()
+		},
+	})
+}
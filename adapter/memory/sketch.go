@@ -0,0 +1,88 @@
+package memory
+
+import "hash/maphash"
+
+// sketchDepth is the number of counter rows in a frequencySketch.
+const sketchDepth = 4
+
+// frequencySketch is a count-min sketch of 4-bit counters that estimates
+// how often each key was accessed recently. It is the admission filter of
+// the TinyLFU policy.
+//
+// Counters are halved once the number of increments reaches the sample
+// size, so old popularity fades and the estimate follows the current
+// access pattern.
+type frequencySketch struct {
+	seeds     [sketchDepth]maphash.Seed
+	rows      [sketchDepth][]uint8
+	mask      uint64
+	additions int
+	sample    int
+}
+
+// maxSketchWidth bounds the sketch at 16 MiB of counters.
+const maxSketchWidth = 1 << 22
+
+// newFrequencySketch creates a sketch sized for about capacity keys.
+// Rows are four times wider than capacity, so the keys competing for
+// admission rarely share counters with the cached ones.
+func newFrequencySketch(capacity int) *frequencySketch {
+	width := 256
+	for width < 4*capacity && width < maxSketchWidth {
+		width <<= 1
+	}
+
+	s := &frequencySketch{
+		mask:   uint64(width - 1),
+		sample: 10 * width,
+	}
+	for i := range s.rows {
+		s.seeds[i] = maphash.MakeSeed()
+		s.rows[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// increment records an access to key.
+func (s *frequencySketch) increment(key string) {
+	for i := range s.rows {
+		idx := maphash.String(s.seeds[i], key) & s.mask
+		if s.rows[i][idx] < 15 {
+			s.rows[i][idx]++
+		}
+	}
+
+	s.additions++
+	if s.additions >= s.sample {
+		s.age()
+	}
+}
+
+// estimate returns the approximate access count of key.
+func (s *frequencySketch) estimate(key string) uint8 {
+	minimum := uint8(15)
+	for i := range s.rows {
+		if c := s.rows[i][maphash.String(s.seeds[i], key)&s.mask]; c < minimum {
+			minimum = c
+		}
+	}
+	return minimum
+}
+
+// age halves every counter.
+func (s *frequencySketch) age() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
+
+// reset zeroes every counter.
+func (s *frequencySketch) reset() {
+	for i := range s.rows {
+		clear(s.rows[i])
+	}
+	s.additions = 0
+}
@@ -0,0 +1,364 @@
+package memory
+
+import (
+	"container/list"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// entry is a cached value.
+type entry struct {
+	expiresAt time.Time // Zero means no expiration
+	key       string
+	value     []byte
+	tags      []string
+}
+
+// expired reports whether the entry's TTL has passed at now.
+func (e *entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// evictions counts entries removed by the store itself.
+type evictions struct {
+	size     int64 // Evicted to make room for a new entry
+	expired  int64 // Removed after their TTL passed
+	rejected int64 // New entries refused by the TinyLFU admission filter
+}
+
+// store is a bounded map of entries kept in recency order.
+//
+// When adding an entry would exceed maxCost or maxItems, least recently
+// used entries are evicted. With a frequency sketch (the TinyLFU policy),
+// a new entry is only admitted if it has been accessed more often than
+// every entry it would evict; otherwise the new entry is dropped.
+//
+// Expired entries are removed lazily on access, by sweep, and first
+// whenever room is needed.
+//
+// Entries may carry tags; the tag index maps each tag to the keys of the
+// entries carrying it, and is updated as entries are removed.
+type store struct {
+	now      func() time.Time
+	items    map[string]*list.Element
+	tags     map[string]map[string]struct{}
+	order    *list.List       // Front is most recently used
+	sketch   *frequencySketch // nil for the LRU policy
+	cost     int64            // Sum of value sizes
+	maxCost  int64            // 0 means unlimited
+	maxItems int              // 0 means unlimited
+	mu       sync.Mutex
+}
+
+// newStore creates an empty store. sketch may be nil.
+func newStore(maxCost int64, maxItems int, sketch *frequencySketch) *store {
+	return &store{
+		now:      time.Now,
+		items:    make(map[string]*list.Element),
+		tags:     make(map[string]map[string]struct{}),
+		order:    list.New(),
+		sketch:   sketch,
+		maxCost:  maxCost,
+		maxItems: maxItems,
+	}
+}
+
+// get returns a copy of the value stored under key.
+func (s *store) get(key string, ev *evictions) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.getLocked(key, s.now(), ev)
+}
+
+// getMany returns copies of the values stored under keys, omitting missing keys.
+func (s *store) getMany(keys []string, ev *evictions) map[string][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	result := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		if value, ok := s.getLocked(key, now, ev); ok {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func (s *store) getLocked(key string, now time.Time, ev *evictions) ([]byte, bool) {
+	if s.sketch != nil {
+		s.sketch.increment(key)
+	}
+
+	e, ok := s.lookupLocked(key, now, ev)
+	if !ok {
+		return nil, false
+	}
+	s.order.MoveToFront(s.items[key])
+	return append([]byte(nil), e.value...), true
+}
+
+// exists reports whether key holds an unexpired entry.
+// Unlike get, it does not count as an access.
+func (s *store) exists(key string, ev *evictions) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.lookupLocked(key, s.now(), ev)
+	return ok
+}
+
+// lookupLocked returns the unexpired entry for key, removing it if it has expired.
+func (s *store) lookupLocked(key string, now time.Time, ev *evictions) (*entry, bool) {
+	el, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if e.expired(now) {
+		s.removeLocked(el)
+		ev.expired++
+		return nil, false
+	}
+	return e, true
+}
+
+// set stores a copy of value under key. A ttl of 0 means no expiration.
+// The tags are added to those the entry already has.
+// It reports false if the TinyLFU admission filter rejected the entry.
+func (s *store) set(key string, value []byte, ttl time.Duration, tags []string, ev *evictions) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setLocked(key, value, ttl, tags, s.now(), ev)
+}
+
+// setMany stores copies of items, each with the same ttl.
+func (s *store) setMany(items map[string][]byte, ttl time.Duration, ev *evictions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, value := range items {
+		s.setLocked(key, value, ttl, nil, now, ev)
+	}
+}
+
+func (s *store) setLocked(key string, value []byte, ttl time.Duration, tags []string, now time.Time, ev *evictions) bool {
+	return s.putLocked(key, value, expiry(now, ttl), tags, true, now, ev)
+}
+
+// putLocked stores a copy of value under key until expiresAt. If filter
+// is false, a new key bypasses the TinyLFU admission filter, for writes
+// that callers rely on, such as atomic operations.
+func (s *store) putLocked(key string, value []byte, expiresAt time.Time, tags []string, filter bool, now time.Time, ev *evictions) bool {
+	if s.sketch != nil {
+		s.sketch.increment(key)
+	}
+	value = append([]byte(nil), value...)
+
+	// Updates are always admitted; the entry only grows or shrinks
+	if el, ok := s.items[key]; ok {
+		e := el.Value.(*entry)
+		s.cost += int64(len(value) - len(e.value))
+		e.value, e.expiresAt = value, expiresAt
+		s.tagLocked(e, tags)
+		s.order.MoveToFront(el)
+		for s.overLimit(0, 0) && s.order.Back() != el {
+			s.evictLocked(s.order.Back(), now, ev)
+		}
+		return true
+	}
+
+	cost := int64(len(value))
+	victims := s.victimsLocked(cost, now)
+	if filter && s.sketch != nil && !s.admitLocked(key, victims, now) {
+		// Expired victims are removed anyway; they are free to reclaim
+		for _, el := range victims {
+			if el.Value.(*entry).expired(now) {
+				s.evictLocked(el, now, ev)
+			}
+		}
+		ev.rejected++
+		return false
+	}
+
+	for _, el := range victims {
+		s.evictLocked(el, now, ev)
+	}
+	e := &entry{key: key, value: value, expiresAt: expiresAt}
+	s.items[key] = s.order.PushFront(e)
+	s.tagLocked(e, tags)
+	s.cost += cost
+	return true
+}
+
+// expiry returns the expiration time of an entry stored at now with ttl.
+// A ttl of 0 means no expiration, the zero time.
+func expiry(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
+
+// tagLocked adds tags to e and to the tag index.
+func (s *store) tagLocked(e *entry, tags []string) {
+	for _, tag := range tags {
+		if slices.Contains(e.tags, tag) {
+			continue
+		}
+		e.tags = append(e.tags, tag)
+		keys, ok := s.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			s.tags[tag] = keys
+		}
+		keys[e.key] = struct{}{}
+	}
+}
+
+// overLimit reports whether adding an entry of the given cost and count
+// would exceed maxCost or maxItems.
+func (s *store) overLimit(cost int64, count int) bool {
+	return (s.maxCost > 0 && s.cost+cost > s.maxCost) ||
+		(s.maxItems > 0 && len(s.items)+count > s.maxItems)
+}
+
+// victimsLocked returns the entries to evict to make room for a new entry
+// of the given cost: expired entries first, then the least recently used.
+func (s *store) victimsLocked(cost int64, now time.Time) []*list.Element {
+	if !s.overLimit(cost, 1) {
+		return nil
+	}
+
+	var victims []*list.Element
+	freedCost, freedItems := int64(0), 0
+	fits := func() bool {
+		return (s.maxCost == 0 || s.cost-freedCost+cost <= s.maxCost) &&
+			(s.maxItems == 0 || len(s.items)-freedItems+1 <= s.maxItems)
+	}
+	take := func(el *list.Element) {
+		victims = append(victims, el)
+		freedCost += int64(len(el.Value.(*entry).value))
+		freedItems++
+	}
+
+	for el := s.order.Back(); el != nil && !fits(); el = el.Prev() {
+		if el.Value.(*entry).expired(now) {
+			take(el)
+		}
+	}
+	for el := s.order.Back(); el != nil && !fits(); el = el.Prev() {
+		if !el.Value.(*entry).expired(now) {
+			take(el)
+		}
+	}
+	return victims
+}
+
+// admitLocked reports whether key is accessed more often than every
+// unexpired victim.
+func (s *store) admitLocked(key string, victims []*list.Element, now time.Time) bool {
+	freq := s.sketch.estimate(key)
+	for _, el := range victims {
+		e := el.Value.(*entry)
+		if !e.expired(now) && s.sketch.estimate(e.key) >= freq {
+			return false
+		}
+	}
+	return true
+}
+
+// evictLocked removes el and counts it as expired or evicted for size.
+func (s *store) evictLocked(el *list.Element, now time.Time, ev *evictions) {
+	if el.Value.(*entry).expired(now) {
+		ev.expired++
+	} else {
+		ev.size++
+	}
+	s.removeLocked(el)
+}
+
+// removeLocked removes el from the store and the tag index.
+func (s *store) removeLocked(el *list.Element) {
+	e := s.order.Remove(el).(*entry)
+	delete(s.items, e.key)
+	s.cost -= int64(len(e.value))
+	for _, tag := range e.tags {
+		delete(s.tags[tag], e.key)
+		if len(s.tags[tag]) == 0 {
+			delete(s.tags, tag)
+		}
+	}
+}
+
+// delete removes key from the store.
+func (s *store) delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.items[key]; ok {
+		s.removeLocked(el)
+	}
+}
+
+// clear removes every entry and forgets access frequencies.
+func (s *store) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items = make(map[string]*list.Element)
+	s.tags = make(map[string]map[string]struct{})
+	s.order.Init()
+	s.cost = 0
+	if s.sketch != nil {
+		s.sketch.reset()
+	}
+}
+
+// invalidateTags removes every entry carrying any of tags.
+func (s *store) invalidateTags(tags []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range s.tags[tag] {
+			s.removeLocked(s.items[key])
+		}
+	}
+}
+
+// deletePrefix removes every entry whose key starts with prefix.
+func (s *store) deletePrefix(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, el := range s.items {
+		if strings.HasPrefix(key, prefix) {
+			s.removeLocked(el)
+		}
+	}
+}
+
+// removeExpired removes every expired entry.
+func (s *store) removeExpired(ev *evictions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for el := s.order.Back(); el != nil; {
+		prev := el.Prev()
+		if el.Value.(*entry).expired(now) {
+			s.removeLocked(el)
+			ev.expired++
+		}
+		el = prev
+	}
+}
+
+// stats returns the number of entries and their total cost.
+func (s *store) stats() (items int, cost int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items), s.cost
+}
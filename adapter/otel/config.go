@@ -100,6 +100,49 @@ func LoadMetricsConfig(config hyperion.Config) (MetricsConfig, error) {
 	return cfg, nil
 }
 
+// ValidateConfig validates the tracing and metrics sections of cfg, if present.
+// It is registered as a hyperion.ConfigValidator by Module so that config
+// reloads with an invalid tracing or metrics section are rejected.
+func ValidateConfig(cfg hyperion.Config) error {
+	if cfg.IsSet("tracing") {
+		if _, err := LoadTracingConfig(cfg); err != nil {
+			return err
+		}
+	}
+	if cfg.IsSet("metrics") {
+		if _, err := LoadMetricsConfig(cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfigSections describes the "tracing" and "metrics" sections for JSON
+// Schema generation and linting. Module contributes them to the
+// "hyperion.config_sections" group.
+func ConfigSections() []hyperion.ConfigSection {
+	return []hyperion.ConfigSection{
+		{
+			Key:  "tracing",
+			Type: TracingConfig{},
+			Validate: func(cfg hyperion.Config) error {
+				_, err := LoadTracingConfig(cfg)
+				return err
+			},
+			Description: "OpenTelemetry tracing (adapter/otel)",
+		},
+		{
+			Key:  "metrics",
+			Type: MetricsConfig{},
+			Validate: func(cfg hyperion.Config) error {
+				_, err := LoadMetricsConfig(cfg)
+				return err
+			},
+			Description: "OpenTelemetry metrics (adapter/otel)",
+		},
+	}
+}
+
 // validateTracingConfig validates the tracing configuration.
 func validateTracingConfig(cfg TracingConfig) error {
 	if !cfg.Enabled {
@@ -383,3 +383,24 @@ func TestValidateMetricsConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	if err := ValidateConfig(&mockConfig{data: map[string]any{}}); err != nil {
+		t.Errorf("ValidateConfig() without tracing or metrics sections error = %v, want nil", err)
+	}
+
+	valid := &mockConfig{data: map[string]any{
+		"tracing": TracingConfig{Enabled: true, ServiceName: "svc", Exporter: "otlp", Endpoint: "localhost:4317", SampleRate: 0.5},
+		"metrics": MetricsConfig{Enabled: false},
+	}}
+	if err := ValidateConfig(valid); err != nil {
+		t.Errorf("ValidateConfig() error = %v, want nil", err)
+	}
+
+	invalid := &mockConfig{data: map[string]any{
+		"tracing": TracingConfig{Enabled: true, ServiceName: "svc", Exporter: "otlp", Endpoint: "localhost:4317", SampleRate: 2},
+	}}
+	if err := ValidateConfig(invalid); err == nil {
+		t.Error("ValidateConfig() should reject tracing.sample_rate above 1.0")
+	}
+}
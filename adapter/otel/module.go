@@ -164,4 +164,17 @@ var MeterModule = fx.Module("hyperion.adapter.otel.meter",
 var Module = fx.Options(
 	TracerModule,
 	MeterModule,
+	// Reject config reloads with invalid tracing or metrics sections
+	fx.Provide(
+		fx.Annotate(
+			func() hyperion.ConfigValidator { return ValidateConfig },
+			fx.ResultTags(`group:"hyperion.config_validators"`),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			ConfigSections,
+			fx.ResultTags(`group:"hyperion.config_sections,flatten"`),
+		),
+	),
 )
@@ -7,9 +7,14 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// exceptionChainKey holds the wrapped errors of a recorded error as
+// "type: message" entries, in depth-first order.
+const exceptionChainKey = attribute.Key("exception.chain")
+
 // otelSpan wraps an OpenTelemetry span to implement hyperion.Span.
 type otelSpan struct {
 	span trace.Span
@@ -27,11 +32,48 @@ func (s *otelSpan) AddEvent(name string, options ...hyperion.EventOption) {
 }
 
 // RecordError records an error on the span with optional event options.
+//
+// The "exception" event carries the error type, message, wrapped error chain
+// and stack trace. The stack comes from the error when it implements
+// hyperion.StackTracer, otherwise it is captured at the RecordError call site.
 func (s *otelSpan) RecordError(err error, options ...hyperion.EventOption) {
-	s.span.RecordError(err)
+	if err == nil {
+		return
+	}
+	s.span.AddEvent(semconv.ExceptionEventName, trace.WithAttributes(exceptionAttributes(err)...))
 	s.span.SetStatus(codes.Error, err.Error())
 }
 
+// exceptionAttributes builds the exception event attributes for err.
+// It must be called directly from RecordError, see the stack skip below.
+func exceptionAttributes(err error) []attribute.KeyValue {
+	detail := hyperion.DescribeError(err)
+
+	stack := detail.Stack
+	if stack == "" {
+		stack = hyperion.FormatStack(hyperion.CaptureStack(2)) // skip [exceptionAttributes, RecordError]
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.ExceptionTypeKey.String(detail.Type),
+		semconv.ExceptionMessageKey.String(detail.Message),
+		semconv.ExceptionStacktraceKey.String(stack),
+	}
+	if chain := flattenCauses(detail.Causes, nil); len(chain) > 0 {
+		attrs = append(attrs, exceptionChainKey.StringSlice(chain))
+	}
+	return attrs
+}
+
+// flattenCauses appends the causes tree to dst in depth-first order.
+func flattenCauses(causes []hyperion.ErrorDetail, dst []string) []string {
+	for _, cause := range causes {
+		dst = append(dst, cause.Type+": "+cause.Message)
+		dst = flattenCauses(cause.Causes, dst)
+	}
+	return dst
+}
+
 // SetAttributes sets attributes on the span.
 func (s *otelSpan) SetAttributes(attributes ...hyperion.Attribute) {
 	attrs := convertAttributes(attributes...)
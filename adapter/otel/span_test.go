@@ -1,9 +1,19 @@
 package otel
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strings"
 	"testing"
 
 	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/mapoio/hyperion"
 )
 
 func TestConvertAttributeValue(t *testing.T) {
@@ -99,3 +109,70 @@ func TestConvertAttributeValue(t *testing.T) {
 		})
 	}
 }
+
+func TestOtelSpan_RecordError_Detail(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := &OtelTracer{tracer: tp.Tracer("test"), provider: tp}
+
+	recordAndGet := func(t *testing.T, err error) map[attribute.Key]attribute.Value {
+		t.Helper()
+		exporter.Reset()
+
+		_, span := tracer.Start(wrapContext(context.Background()), "op")
+		span.RecordError(err)
+		span.End()
+
+		spans := exporter.GetSpans()
+		if len(spans) != 1 || len(spans[0].Events) != 1 {
+			t.Fatalf("expected one span with one event, got %v", spans)
+		}
+		attrs := make(map[attribute.Key]attribute.Value)
+		for _, kv := range spans[0].Events[0].Attributes {
+			attrs[kv.Key] = kv.Value
+		}
+		return attrs
+	}
+
+	t.Run("chain and call site stack", func(t *testing.T) {
+		pathErr := &fs.PathError{Op: "open", Path: "/data", Err: fs.ErrPermission}
+		attrs := recordAndGet(t, fmt.Errorf("read data: %w", pathErr))
+
+		if got := attrs["exception.type"].AsString(); got != "*fmt.wrapError" {
+			t.Errorf("exception.type = %q", got)
+		}
+		wantChain := []string{
+			"*fs.PathError: open /data: permission denied",
+			"*errors.errorString: permission denied",
+		}
+		if got := attrs["exception.chain"].AsStringSlice(); !reflect.DeepEqual(got, wantChain) {
+			t.Errorf("exception.chain = %q, want %q", got, wantChain)
+		}
+		stack := attrs["exception.stacktrace"].AsString()
+		if !strings.Contains(stack, "TestOtelSpan_RecordError_Detail") || strings.Contains(stack, "exceptionAttributes") {
+			t.Errorf("exception.stacktrace should start at the RecordError caller, got:\n%s", stack)
+		}
+	})
+
+	t.Run("stack from error", func(t *testing.T) {
+		attrs := recordAndGet(t, hyperion.WithStack(errors.New("boom")))
+
+		if got := attrs["exception.type"].AsString(); got != "*errors.errorString" {
+			t.Errorf("exception.type = %q, want the wrapped type", got)
+		}
+		if _, ok := attrs["exception.chain"]; ok {
+			t.Error("exception.chain should be omitted for errors without causes")
+		}
+	})
+
+	t.Run("nil error is ignored", func(t *testing.T) {
+		exporter.Reset()
+		_, span := tracer.Start(wrapContext(context.Background()), "op")
+		span.RecordError(nil)
+		span.End()
+
+		if events := exporter.GetSpans()[0].Events; len(events) != 0 {
+			t.Errorf("expected no events, got %d", len(events))
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/mapoio/hyperion"
+)
+
+// incrScript adds ARGV[1] to KEYS[1] and, if the key was created, sets its
+// expiry to ARGV[2] milliseconds (0 for none).
+var incrScript = goredis.NewScript(`
+local existed = redis.call('EXISTS', KEYS[1])
+local n = redis.call('INCRBY', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[2])
+if existed == 0 and ttl > 0 then
+  redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return n
+`)
+
+// casScript sets KEYS[1] to ARGV[2] with an expiry of ARGV[3] milliseconds
+// (0 for none) if it currently holds ARGV[1].
+var casScript = goredis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+  return 0
+end
+local ttl = tonumber(ARGV[3])
+if ttl > 0 then
+  redis.call('SET', KEYS[1], ARGV[2], 'PX', ttl)
+else
+  redis.call('SET', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// expireScript sets the expiry of an existing KEYS[1] to ARGV[1]
+// milliseconds, or removes it for 0. Returns whether the key exists.
+var expireScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return 0
+end
+local ttl = tonumber(ARGV[1])
+if ttl > 0 then
+  redis.call('PEXPIRE', KEYS[1], ttl)
+else
+  redis.call('PERSIST', KEYS[1])
+end
+return 1
+`)
+
+// Ensure redisCache implements hyperion.AtomicCache interface.
+var _ hyperion.AtomicCache = (*redisCache)(nil)
+
+// Incr increments the integer stored under key by 1.
+func (c *redisCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return c.IncrBy(ctx, key, 1, ttl)
+}
+
+// IncrBy runs INCRBY, and PEXPIRE when the key is created, in one script.
+// Non-integer values and overflows are reported as hyperion.ErrNotInteger.
+func (c *redisCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	n, err := incrScript.Run(ctx, c.client, []string{c.prefix + key}, delta, ttl.Milliseconds()).Int64()
+	if err != nil {
+		if msg := err.Error(); strings.Contains(msg, "not an integer") || strings.Contains(msg, "would overflow") {
+			return 0, fmt.Errorf("redis INCRBY %s: %w", key, hyperion.ErrNotInteger)
+		}
+		return 0, fmt.Errorf("redis INCRBY %s: %w", key, err)
+	}
+	return n, nil
+}
+
+// SetNX runs SET NX.
+func (c *redisCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	ok, err := c.client.SetNX(ctx, c.prefix+key, value, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis SET NX %s: %w", key, err)
+	}
+	return ok, nil
+}
+
+// CompareAndSwap compares and sets the value in one script.
+func (c *redisCache) CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error) {
+	n, err := casScript.Run(ctx, c.client, []string{c.prefix + key}, old, value, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return false, fmt.Errorf("redis compare-and-swap %s: %w", key, err)
+	}
+	return n == 1, nil
+}
+
+// Expire runs PEXPIRE, or PERSIST for a TTL of 0, if the key exists.
+func (c *redisCache) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	n, err := expireScript.Run(ctx, c.client, []string{c.prefix + key}, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return false, fmt.Errorf("redis PEXPIRE %s: %w", key, err)
+	}
+	return n == 1, nil
+}
+
+// TTL runs PTTL. Returns ErrNotFound if the key does not exist.
+func (c *redisCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := c.client.PTTL(ctx, c.prefix+key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("redis PTTL %s: %w", key, err)
+	}
+	// PTTL replies -2 for a missing key and -1 for one without expiry
+	switch {
+	case ttl == -2:
+		return 0, ErrNotFound
+	case ttl < 0:
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// GetSet runs SET with the GET option.
+func (c *redisCache) GetSet(ctx context.Context, key string, value []byte, ttl time.Duration) ([]byte, bool, error) {
+	old, err := c.client.SetArgs(ctx, c.prefix+key, value, goredis.SetArgs{TTL: ttl, Get: true}).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("redis SET GET %s: %w", key, err)
+	}
+	return old, true, nil
+}
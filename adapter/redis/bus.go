@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/mapoio/hyperion"
+)
+
+// invalidationBus implements hyperion.InvalidationBus on Redis pub/sub.
+type invalidationBus struct {
+	client  goredis.UniversalClient
+	channel string
+}
+
+// Ensure invalidationBus implements hyperion.InvalidationBus interface.
+var _ hyperion.InvalidationBus = (*invalidationBus)(nil)
+
+// NewInvalidationBus creates a hyperion.InvalidationBus that publishes
+// JSON-encoded messages on a Redis pub/sub channel, connecting the
+// hyperion.TieredCache of every instance sharing that channel.
+//
+// Pub/sub is fire-and-forget: instances that are disconnected when a message
+// is published miss it, and rely on the L1 TTL instead. The bus does not
+// close client.
+func NewInvalidationBus(client goredis.UniversalClient, channel string) hyperion.InvalidationBus {
+	return &invalidationBus{client: client, channel: channel}
+}
+
+// Publish sends msg to the channel.
+func (b *invalidationBus) Publish(ctx context.Context, msg hyperion.Invalidation) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode invalidation: %w", err)
+	}
+	return b.client.Publish(ctx, b.channel, payload).Err()
+}
+
+// Subscribe listens on the channel and calls handler for each message.
+// It returns once the subscription is confirmed by the server; go-redis
+// reconnects it if the connection drops.
+func (b *invalidationBus) Subscribe(handler func(hyperion.Invalidation)) (func(), error) {
+	ctx := context.Background()
+	pubsub := b.client.Subscribe(ctx, b.channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", b.channel, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for m := range pubsub.Channel() {
+			var msg hyperion.Invalidation
+			if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+				continue
+			}
+			handler(msg)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			_ = pubsub.Close()
+			<-done
+		})
+	}, nil
+}
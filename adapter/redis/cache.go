@@ -0,0 +1,256 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/mapoio/hyperion"
+)
+
+// clearBatchSize is the number of keys scanned and deleted per round trip by Clear.
+const clearBatchSize = 500
+
+var (
+	// ErrNotFound is returned by Get for keys that are missing or expired.
+	// It is hyperion.ErrCacheMiss.
+	ErrNotFound = hyperion.ErrCacheMiss
+
+	// ErrNoKeyPrefix is returned by Clear when no key_prefix is configured,
+	// since clearing would delete keys the cache does not own.
+	ErrNoKeyPrefix = errors.New("redis cache: Clear requires a key_prefix")
+)
+
+// HealthChecker is implemented by the Cache returned by NewRedisCache.
+//
+// Example:
+//
+//	if hc, ok := cache.(redis.HealthChecker); ok {
+//	    err := hc.Health(ctx)
+//	}
+type HealthChecker interface {
+	// Health pings the Redis server.
+	Health(ctx context.Context) error
+}
+
+// redisCache implements hyperion.Cache on top of a go-redis client.
+type redisCache struct {
+	client goredis.UniversalClient
+	prefix string
+}
+
+// Ensure redisCache implements hyperion.Cache interface.
+var _ hyperion.Cache = (*redisCache)(nil)
+
+// Ensure redisCache implements HealthChecker interface.
+var _ HealthChecker = (*redisCache)(nil)
+
+// NewRedisCache creates a Redis cache configured from the "cache.redis"
+// section of cfg. The connection is established lazily, on first use.
+func NewRedisCache(cfg hyperion.Config) (hyperion.Cache, error) {
+	redisCfg, err := LoadConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load redis config: %w", err)
+	}
+	return New(redisCfg)
+}
+
+// New creates a Redis cache from redisCfg.
+func New(redisCfg Config) (hyperion.Cache, error) {
+	client, err := NewClient(redisCfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromClient(client, redisCfg.KeyPrefix), nil
+}
+
+// NewClient creates a go-redis client from redisCfg, for use with
+// NewFromClient or NewInvalidationBus.
+func NewClient(redisCfg Config) (goredis.UniversalClient, error) {
+	if err := redisCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid redis config: %w", err)
+	}
+	client, err := redisCfg.newClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redis client: %w", err)
+	}
+	return client, nil
+}
+
+// NewFromClient creates a cache on an existing go-redis client, with every
+// key prefixed by prefix. Closing the cache closes the client.
+func NewFromClient(client goredis.UniversalClient, prefix string) hyperion.Cache {
+	return &redisCache{client: client, prefix: prefix}
+}
+
+// Get retrieves the value for the given key.
+// Returns ErrNotFound if the key is missing or expired.
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis GET %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// Set stores the value for the given key with the specified TTL.
+// A TTL of 0 means no expiration.
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.client.Set(ctx, c.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redis SET %s: %w", key, err)
+	}
+	return nil
+}
+
+// Delete removes the value for the given key.
+func (c *redisCache) Delete(ctx context.Context, key string) error {
+	if err := c.client.Del(ctx, c.prefix+key).Err(); err != nil {
+		return fmt.Errorf("redis DEL %s: %w", key, err)
+	}
+	return nil
+}
+
+// Exists checks if the key exists in the cache.
+func (c *redisCache) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := c.client.Exists(ctx, c.prefix+key).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis EXISTS %s: %w", key, err)
+	}
+	return n > 0, nil
+}
+
+// MGet retrieves multiple values in one pipelined round trip.
+// Keys are fetched with individual GETs rather than MGET, so they may
+// live in different cluster slots. Missing keys are omitted from the result.
+func (c *redisCache) MGet(ctx context.Context, keys ...string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	cmds := make([]*goredis.StringCmd, len(keys))
+	_, err := c.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, c.prefix+key)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		return nil, fmt.Errorf("redis pipelined GET: %w", err)
+	}
+
+	for i, cmd := range cmds {
+		value, err := cmd.Bytes()
+		if errors.Is(err, goredis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("redis GET %s: %w", keys[i], err)
+		}
+		result[keys[i]] = value
+	}
+	return result, nil
+}
+
+// MSet stores multiple key-value pairs in one pipelined round trip,
+// each with the same TTL.
+func (c *redisCache) MSet(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	_, err := c.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for key, value := range items {
+			pipe.Set(ctx, c.prefix+key, value, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis pipelined SET: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every key under the configured key prefix.
+// Keys are found with SCAN, on every master in cluster mode, so Clear
+// does not block the server. It returns ErrNoKeyPrefix without a prefix.
+func (c *redisCache) Clear(ctx context.Context) error {
+	if c.prefix == "" {
+		return ErrNoKeyPrefix
+	}
+	return c.deleteMatching(ctx, escapePattern(c.prefix)+"*")
+}
+
+// deleteMatching deletes every key matching pattern, on every master in
+// cluster mode.
+func (c *redisCache) deleteMatching(ctx context.Context, pattern string) error {
+	if cluster, ok := c.client.(*goredis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *goredis.Client) error {
+			return clearNode(ctx, node, pattern)
+		})
+	}
+	return clearNode(ctx, c.client, pattern)
+}
+
+// clearNode deletes every key matching pattern on a single node.
+// Keys are deleted one DEL per key in a pipeline, since a multi-key DEL
+// fails in a cluster when the keys hash to different slots.
+func clearNode(ctx context.Context, client goredis.Cmdable, pattern string) error {
+	var cursor uint64
+	for {
+		keys, next, err := client.Scan(ctx, cursor, pattern, clearBatchSize).Result()
+		if err != nil {
+			return fmt.Errorf("redis SCAN: %w", err)
+		}
+
+		if len(keys) > 0 {
+			_, err := client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+				for _, key := range keys {
+					pipe.Del(ctx, key)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("redis DEL: %w", err)
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// escapePattern escapes the glob metacharacters of a SCAN MATCH pattern.
+func escapePattern(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Health pings the Redis server.
+func (c *redisCache) Health(ctx context.Context) error {
+	if err := c.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("redis PING: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying client and its connection pool.
+func (c *redisCache) Close() error {
+	return c.client.Close()
+}
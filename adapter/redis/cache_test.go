@@ -0,0 +1,426 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/adapter/redis"
+	"github.com/mapoio/hyperion/hyperiontest"
+)
+
+func newCache(t *testing.T, mr *miniredis.Miniredis, mode, prefix string) hyperion.Cache {
+	t.Helper()
+	cfg := redis.DefaultConfig()
+	cfg.Mode = mode
+	cfg.Addrs = []string{mr.Addr()}
+	cfg.KeyPrefix = prefix
+
+	cache, err := redis.New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cache.(io.Closer).Close() })
+	return cache
+}
+
+func TestConformance(t *testing.T) {
+	for _, mode := range []string{redis.ModeStandalone, redis.ModeCluster} {
+		t.Run(mode, func(t *testing.T) {
+			var mr *miniredis.Miniredis
+			h := hyperiontest.CacheHarness{
+				New: func(t *testing.T) hyperion.Cache {
+					mr = miniredis.RunT(t)
+					return newCache(t, mr, mode, "test:")
+				},
+				Advance: func(d time.Duration) { mr.FastForward(d) },
+			}
+			hyperiontest.RunCacheSuite(t, h)
+			hyperiontest.RunTaggedCacheSuite(t, h)
+			hyperiontest.RunAtomicCacheSuite(t, h)
+		})
+	}
+}
+
+func TestKeyPrefix(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	cache := newCache(t, mr, redis.ModeStandalone, "app:")
+
+	if err := cache.Set(ctx, "user:1", []byte("alice"), 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, _ := mr.Get("app:user:1"); got != "alice" {
+		t.Errorf("app:user:1 = %q, want alice", got)
+	}
+
+	// Clear only deletes keys under the prefix
+	_ = mr.Set("other:key", "kept")
+	_ = mr.Set("app*", "kept") // Matches an unescaped "app*" pattern
+	if err := cache.Clear(ctx); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if mr.Exists("app:user:1") {
+		t.Error("Clear left app:user:1")
+	}
+	for _, key := range []string{"other:key", "app*"} {
+		if !mr.Exists(key) {
+			t.Errorf("Clear deleted %s outside the prefix", key)
+		}
+	}
+}
+
+func TestTagSetExpiry(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	cache := newCache(t, mr, redis.ModeStandalone, "app:").(hyperion.TaggedCache)
+
+	_ = cache.SetWithTags(ctx, "a", []byte("1"), time.Minute, "tag")
+	_ = cache.SetWithTags(ctx, "b", []byte("2"), time.Hour, "tag")
+	_ = cache.SetWithTags(ctx, "c", []byte("3"), time.Second, "tag")
+	if ttl := mr.TTL("app:\x00tag:tag"); ttl != time.Hour {
+		t.Errorf("tag set TTL = %s, want the longest key TTL of 1h", ttl)
+	}
+	if members, _ := mr.Members("app:\x00tag:tag"); len(members) != 3 || members[0] != "app:a" {
+		t.Errorf("tag set members = %v, want the prefixed keys", members)
+	}
+
+	_ = cache.SetWithTags(ctx, "d", []byte("4"), 0, "tag")
+	if ttl := mr.TTL("app:\x00tag:tag"); ttl != 0 {
+		t.Errorf("tag set TTL = %s, want none after a key without expiry", ttl)
+	}
+
+	// Clear removes tag sets along with the keys
+	_ = cache.Clear(ctx)
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Errorf("keys after Clear = %q, want none", keys)
+	}
+}
+
+func TestDeletePrefixWithoutPrefix(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cache := newCache(t, mr, redis.ModeStandalone, "").(hyperion.TaggedCache)
+	_ = mr.Set("other", "x")
+
+	if err := cache.DeletePrefix(context.Background(), ""); !errors.Is(err, redis.ErrNoKeyPrefix) {
+		t.Errorf("DeletePrefix(\"\") = %v, want ErrNoKeyPrefix", err)
+	}
+	if err := cache.DeletePrefix(context.Background(), "user:"); err != nil {
+		t.Errorf("DeletePrefix(user:) failed: %v", err)
+	}
+	if !mr.Exists("other") {
+		t.Error("DeletePrefix removed an unrelated key")
+	}
+}
+
+func TestClearManyKeys(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	cache := newCache(t, mr, redis.ModeStandalone, "app:")
+
+	items := make(map[string][]byte)
+	for i := 0; i < 1200; i++ {
+		items[time.Duration(i).String()] = []byte("v")
+	}
+	if err := cache.MSet(ctx, items, 0); err != nil {
+		t.Fatalf("MSet failed: %v", err)
+	}
+	if err := cache.Clear(ctx); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Errorf("%d keys left after Clear", len(keys))
+	}
+}
+
+func TestClearWithoutPrefix(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cache := newCache(t, mr, redis.ModeStandalone, "")
+	_ = mr.Set("key", "value")
+
+	if err := cache.Clear(context.Background()); !errors.Is(err, redis.ErrNoKeyPrefix) {
+		t.Errorf("Clear without prefix returned %v, want ErrNoKeyPrefix", err)
+	}
+	if !mr.Exists("key") {
+		t.Error("Clear without prefix deleted keys")
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cache := newCache(t, mr, redis.ModeStandalone, "")
+
+	if _, err := cache.Get(context.Background(), "missing"); !errors.Is(err, redis.ErrNotFound) {
+		t.Errorf("Get(missing) returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	cache := newCache(t, mr, redis.ModeStandalone, "")
+	_ = mr.Set("key", "value")
+
+	mr.SetError("LOADING")
+	if _, err := cache.Get(ctx, "key"); err == nil || errors.Is(err, redis.ErrNotFound) {
+		t.Errorf("Get with a server error returned %v, want the server error", err)
+	}
+	if _, err := cache.MGet(ctx, "key"); err == nil {
+		t.Error("MGet with a server error returned nil")
+	}
+	if err := cache.MSet(ctx, map[string][]byte{"key": nil}, 0); err == nil {
+		t.Error("MSet with a server error returned nil")
+	}
+}
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	cache := newCache(t, mr, redis.ModeStandalone, "")
+
+	hc, ok := cache.(redis.HealthChecker)
+	if !ok {
+		t.Fatal("cache does not implement HealthChecker")
+	}
+	if err := hc.Health(ctx); err != nil {
+		t.Errorf("Health failed: %v", err)
+	}
+
+	mr.Close()
+	if err := hc.Health(ctx); err == nil {
+		t.Error("Health succeeded with the server down")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"cache": map[string]any{"redis": map[string]any{
+			"mode":        "sentinel",
+			"addrs":       []any{"s1:26379", "s2:26379"},
+			"master_name": "mymaster",
+			"key_prefix":  "app:",
+			"pool_size":   20,
+			"tls":         map[string]any{"enabled": true, "server_name": "redis.internal"},
+		}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := redis.LoadConfig(cfg)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if got.Mode != redis.ModeSentinel || len(got.Addrs) != 2 || got.MasterName != "mymaster" ||
+		got.PoolSize != 20 || !got.TLS.Enabled || got.TLS.ServerName != "redis.internal" {
+		t.Errorf("LoadConfig = %+v", got)
+	}
+	if got.DialTimeout != 5*time.Second {
+		t.Errorf("DialTimeout = %v, want the 5s default", got.DialTimeout)
+	}
+	if _, err := redis.NewRedisCache(cfg); err != nil {
+		t.Errorf("NewRedisCache failed: %v", err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*redis.Config)
+	}{
+		{"unknown mode", func(c *redis.Config) { c.Mode = "proxy" }},
+		{"no address", func(c *redis.Config) { c.Addrs = nil }},
+		{"standalone with two addresses", func(c *redis.Config) { c.Addrs = []string{"a:1", "b:2"} }},
+		{"sentinel without master", func(c *redis.Config) { c.Mode = redis.ModeSentinel }},
+		{"cluster with db", func(c *redis.Config) { c.Mode = redis.ModeCluster; c.DB = 1 }},
+		{"cert without key", func(c *redis.Config) { c.TLS.CertFile = "cert.pem" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := redis.DefaultConfig()
+			tt.modify(&cfg)
+			if err := cfg.Validate(); err == nil {
+				t.Error("Validate accepted an invalid config")
+			}
+			if _, err := redis.New(cfg); err == nil {
+				t.Error("New accepted an invalid config")
+			}
+		})
+	}
+
+	cfg := redis.DefaultConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("DefaultConfig is invalid: %v", err)
+	}
+
+	cfg.TLS.Enabled = true
+	cfg.TLS.CAFile = "/nonexistent/ca.pem"
+	if _, err := redis.New(cfg); err == nil {
+		t.Error("New accepted a missing CA file")
+	}
+}
+
+func TestModule(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"cache": map[string]any{"redis": map[string]any{"addrs": []any{mr.Addr()}}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cache hyperion.Cache
+	app := fxtest.New(t,
+		fx.Provide(func() hyperion.Config { return cfg }),
+		redis.Module,
+		fx.Populate(&cache),
+	)
+	app.RequireStart()
+
+	ctx := context.Background()
+	if err := cache.Set(ctx, "key", []byte("value"), 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	app.RequireStop()
+	if _, err := cache.Get(ctx, "key"); err == nil {
+		t.Error("Get succeeded after the module stopped and closed the client")
+	}
+}
+
+func TestModule_Unreachable(t *testing.T) {
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	mr.Close()
+
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"cache": map[string]any{"redis": map[string]any{"addrs": []any{addr}, "dial_timeout": "100ms"}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fx.New(
+		fx.NopLogger,
+		fx.Provide(func() hyperion.Config { return cfg }),
+		redis.Module,
+		fx.Invoke(func(hyperion.Cache) {}),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Start(ctx); err == nil {
+		_ = app.Stop(ctx)
+		t.Error("app started with an unreachable Redis")
+	}
+}
+
+func TestInvalidationBus(t *testing.T) {
+	mr := miniredis.RunT(t)
+	bus := redis.NewInvalidationBus(newClient(t, mr), "test:invalidate")
+	received := make(chan hyperion.Invalidation, 1)
+	stop, err := bus.Subscribe(func(msg hyperion.Invalidation) { received <- msg })
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	want := hyperion.Invalidation{Source: "a", Keys: []string{"user:1", "user:2"}}
+	if err := bus.Publish(context.Background(), want); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	select {
+	case got := <-received:
+		if got.Source != "a" || len(got.Keys) != 2 || got.Keys[1] != "user:2" || got.All {
+			t.Errorf("received %+v, want %+v", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no invalidation received")
+	}
+
+	// Messages that are not invalidations are ignored
+	mr.Publish("test:invalidate", "not json")
+
+	stop()
+	stop()
+	if err := bus.Publish(context.Background(), want); err != nil {
+		t.Fatalf("Publish after stop failed: %v", err)
+	}
+	select {
+	case got := <-received:
+		t.Errorf("received %+v after stop", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestInvalidationBus_SubscribeError(t *testing.T) {
+	client, err := redis.NewClient(redis.Config{
+		Mode:        redis.ModeStandalone,
+		Addrs:       []string{"127.0.0.1:1"},
+		DialTimeout: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	bus := redis.NewInvalidationBus(client, "test:invalidate")
+	if _, err := bus.Subscribe(func(hyperion.Invalidation) {}); err == nil {
+		t.Error("Subscribe to an unreachable server succeeded")
+	}
+}
+
+// newClient connects to mr, closing the client when the test ends.
+func newClient(t *testing.T, mr *miniredis.Miniredis) goredis.UniversalClient {
+	t.Helper()
+	client, err := redis.NewClient(redis.Config{Mode: redis.ModeStandalone, Addrs: []string{mr.Addr()}})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestLockerConformance(t *testing.T) {
+	var mr *miniredis.Miniredis
+	hyperiontest.RunLockerSuite(t, hyperiontest.LockerHarness{
+		New: func(t *testing.T) hyperion.Locker {
+			mr = miniredis.RunT(t)
+			return redis.NewLocker(newClient(t, mr), "app:")
+		},
+		Advance: func(d time.Duration) { mr.FastForward(d) },
+	})
+}
+
+func TestLockerKeys(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	lock, err := redis.NewLocker(newClient(t, mr), "app:").Acquire(ctx, "job", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	// Both keys share the {job} hash tag; the token counter does not expire
+	if got := mr.TTL("app:lock:{job}"); got != time.Minute {
+		t.Errorf("lock TTL = %v, want 1m", got)
+	}
+	if token, _ := mr.Get("app:lock:{job}:token"); token != "1" {
+		t.Errorf("token counter = %q, want 1", token)
+	}
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if mr.Exists("app:lock:{job}") {
+		t.Error("lock key still exists after Release")
+	}
+	if !mr.Exists("app:lock:{job}:token") {
+		t.Error("token counter deleted by Release")
+	}
+}
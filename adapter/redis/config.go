@@ -0,0 +1,239 @@
+package redis
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/mapoio/hyperion"
+)
+
+const (
+	// ModeStandalone connects to a single Redis server.
+	ModeStandalone = "standalone"
+
+	// ModeSentinel connects to the master of a Sentinel-monitored group.
+	ModeSentinel = "sentinel"
+
+	// ModeCluster connects to a Redis Cluster.
+	ModeCluster = "cluster"
+)
+
+// Config holds configuration for the Redis cache, read from the
+// "cache.redis" section.
+type Config struct {
+	// Mode selects the deployment: standalone, sentinel or cluster.
+	Mode string `mapstructure:"mode" json:"mode" default:"standalone" validate:"oneof=standalone sentinel cluster"`
+
+	// Addrs lists the server address (standalone), the Sentinel addresses
+	// (sentinel) or the cluster seed nodes (cluster).
+	Addrs []string `mapstructure:"addrs" json:"addrs" default:"localhost:6379" validate:"min=1"`
+
+	// MasterName is the Sentinel master group name. Required in sentinel mode.
+	MasterName string `mapstructure:"master_name" json:"master_name"`
+
+	Username string `mapstructure:"username" json:"username"`
+	Password string `mapstructure:"password" json:"password"`
+
+	// SentinelUsername and SentinelPassword authenticate against the
+	// Sentinels, when they differ from the Redis credentials.
+	SentinelUsername string `mapstructure:"sentinel_username" json:"sentinel_username"`
+	SentinelPassword string `mapstructure:"sentinel_password" json:"sentinel_password"`
+
+	// KeyPrefix is prepended to every key. It namespaces the cache in a
+	// shared Redis and scopes Clear, which refuses to run without it.
+	KeyPrefix string `mapstructure:"key_prefix" json:"key_prefix"`
+
+	// DB selects the database. Not supported in cluster mode.
+	DB int `mapstructure:"db" json:"db" validate:"min=0"`
+
+	// Connection pool; zero values use the go-redis defaults.
+	PoolSize     int `mapstructure:"pool_size" json:"pool_size" validate:"min=0"`
+	MinIdleConns int `mapstructure:"min_idle_conns" json:"min_idle_conns" validate:"min=0"`
+
+	DialTimeout  time.Duration `mapstructure:"dial_timeout" json:"dial_timeout" default:"5s" validate:"min=0"`
+	ReadTimeout  time.Duration `mapstructure:"read_timeout" json:"read_timeout" default:"3s" validate:"min=0"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout" json:"write_timeout" default:"3s" validate:"min=0"`
+	PoolTimeout  time.Duration `mapstructure:"pool_timeout" json:"pool_timeout" validate:"min=0"`
+
+	TLS TLSConfig `mapstructure:"tls" json:"tls"`
+}
+
+// TLSConfig configures TLS for Redis connections.
+type TLSConfig struct {
+	// CAFile verifies the server certificate. Defaults to the system roots.
+	CAFile string `mapstructure:"ca_file" json:"ca_file"`
+
+	// CertFile and KeyFile hold a client certificate for mutual TLS.
+	CertFile string `mapstructure:"cert_file" json:"cert_file"`
+	KeyFile  string `mapstructure:"key_file" json:"key_file"`
+
+	// ServerName overrides the name used to verify the server certificate.
+	ServerName string `mapstructure:"server_name" json:"server_name"`
+
+	Enabled            bool `mapstructure:"enabled" json:"enabled"`
+	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify" json:"insecure_skip_verify"`
+}
+
+// DefaultConfig returns a configuration for a standalone server on localhost.
+func DefaultConfig() Config {
+	return Config{
+		Mode:         ModeStandalone,
+		Addrs:        []string{"localhost:6379"},
+		DialTimeout:  5 * time.Second,
+		ReadTimeout:  3 * time.Second,
+		WriteTimeout: 3 * time.Second,
+	}
+}
+
+// Validate checks the configuration.
+func (c *Config) Validate() error {
+	if len(c.Addrs) == 0 {
+		return errors.New("at least one address is required")
+	}
+
+	switch c.Mode {
+	case ModeStandalone:
+		if len(c.Addrs) > 1 {
+			return errors.New("standalone mode takes a single address")
+		}
+	case ModeSentinel:
+		if c.MasterName == "" {
+			return errors.New("master_name is required in sentinel mode")
+		}
+	case ModeCluster:
+		if c.DB != 0 {
+			return errors.New("db is not supported in cluster mode")
+		}
+	default:
+		return fmt.Errorf("unsupported mode %q", c.Mode)
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return errors.New("tls.cert_file and tls.key_file must be set together")
+	}
+	return nil
+}
+
+// LoadConfig reads the "cache.redis" section of cfg over the defaults.
+func LoadConfig(cfg hyperion.Config) (Config, error) {
+	if cfg == nil {
+		return DefaultConfig(), nil
+	}
+	return hyperion.Bind[Config](cfg, "cache.redis")
+}
+
+// ValidateConfig loads the Redis configuration from cfg and validates it.
+// It is registered as a hyperion.ConfigValidator by Module so that config
+// reloads with an invalid cache.redis section are rejected.
+func ValidateConfig(cfg hyperion.Config) error {
+	_, err := LoadConfig(cfg)
+	return err
+}
+
+// ConfigSections describes the "cache.redis" section for JSON Schema
+// generation and linting. Module contributes it to the
+// "hyperion.config_sections" group.
+func ConfigSections() []hyperion.ConfigSection {
+	return []hyperion.ConfigSection{{
+		Key:         "cache.redis",
+		Type:        Config{},
+		Validate:    ValidateConfig,
+		Description: "Redis cache connection (adapter/redis)",
+	}}
+}
+
+// newClient creates the go-redis client for the configured mode.
+func (c *Config) newClient() (goredis.UniversalClient, error) {
+	tlsConfig, err := c.TLS.load()
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Mode {
+	case ModeSentinel:
+		return goredis.NewFailoverClient(&goredis.FailoverOptions{
+			MasterName:       c.MasterName,
+			SentinelAddrs:    c.Addrs,
+			SentinelUsername: c.SentinelUsername,
+			SentinelPassword: c.SentinelPassword,
+			Username:         c.Username,
+			Password:         c.Password,
+			DB:               c.DB,
+			PoolSize:         c.PoolSize,
+			MinIdleConns:     c.MinIdleConns,
+			DialTimeout:      c.DialTimeout,
+			ReadTimeout:      c.ReadTimeout,
+			WriteTimeout:     c.WriteTimeout,
+			PoolTimeout:      c.PoolTimeout,
+			TLSConfig:        tlsConfig,
+		}), nil
+	case ModeCluster:
+		return goredis.NewClusterClient(&goredis.ClusterOptions{
+			Addrs:        c.Addrs,
+			Username:     c.Username,
+			Password:     c.Password,
+			PoolSize:     c.PoolSize,
+			MinIdleConns: c.MinIdleConns,
+			DialTimeout:  c.DialTimeout,
+			ReadTimeout:  c.ReadTimeout,
+			WriteTimeout: c.WriteTimeout,
+			PoolTimeout:  c.PoolTimeout,
+			TLSConfig:    tlsConfig,
+		}), nil
+	default:
+		return goredis.NewClient(&goredis.Options{
+			Addr:         c.Addrs[0],
+			Username:     c.Username,
+			Password:     c.Password,
+			DB:           c.DB,
+			PoolSize:     c.PoolSize,
+			MinIdleConns: c.MinIdleConns,
+			DialTimeout:  c.DialTimeout,
+			ReadTimeout:  c.ReadTimeout,
+			WriteTimeout: c.WriteTimeout,
+			PoolTimeout:  c.PoolTimeout,
+			TLSConfig:    tlsConfig,
+		}), nil
+	}
+}
+
+// load builds the tls.Config, or returns nil if TLS is disabled.
+func (t *TLSConfig) load() (*tls.Config, error) {
+	if !t.Enabled {
+		return nil, nil
+	}
+
+	//nolint:gosec // InsecureSkipVerify is an explicit opt-in for test environments
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls.ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in tls.ca_file %s", t.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
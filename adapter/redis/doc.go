@@ -0,0 +1,76 @@
+// Package redis provides a Redis-based implementation of the
+// hyperion.Cache interface on top of github.com/redis/go-redis/v9.
+//
+// # Features
+//
+//   - Standalone, Sentinel and Cluster deployments
+//   - Pipelined MGet and MSet, one round trip for many keys
+//   - Key prefix namespacing; Clear only deletes keys under the prefix
+//   - Connection pool, timeout and TLS settings
+//   - Tag and prefix invalidation through hyperion.TaggedCache
+//   - Atomic counters and conditional writes through hyperion.AtomicCache
+//   - Pub/sub invalidation bus for hyperion.TieredCache
+//   - Distributed locks with fencing tokens through hyperion.Locker
+//   - Health checks and lifecycle management through Module
+//
+// # Configuration
+//
+// The cache reads configuration from the provided hyperion.Config under
+// the "cache.redis" key:
+//
+//	cache:
+//	  redis:
+//	    mode: sentinel                 # standalone, sentinel or cluster
+//	    addrs:                         # server, sentinels or cluster seeds
+//	      - sentinel-1:26379
+//	      - sentinel-2:26379
+//	    master_name: mymaster          # sentinel mode only
+//	    password: ${REDIS_PASSWORD}
+//	    db: 0                          # not supported in cluster mode
+//	    key_prefix: "myapp:"
+//	    pool_size: 20
+//	    min_idle_conns: 2
+//	    dial_timeout: 5s
+//	    read_timeout: 3s
+//	    write_timeout: 3s
+//	    tls:
+//	      enabled: true
+//	      ca_file: /etc/redis/ca.pem
+//
+// # Clearing
+//
+// Clear deletes the keys under key_prefix using SCAN, on every master in
+// cluster mode. Without a key_prefix it returns ErrNoKeyPrefix rather than
+// flushing keys the cache does not own.
+//
+// # Tags
+//
+// The cache implements hyperion.TaggedCache. SetWithTags adds the key to a
+// Redis set per tag, which expires with its longest-lived key;
+// InvalidateTags deletes the members of the sets. DeletePrefix scans like
+// Clear.
+//
+// # Invalidation Bus
+//
+// NewInvalidationBus broadcasts hyperion.TieredCache invalidations over
+// Redis pub/sub, so each instance evicts stale entries from its L1:
+//
+//	bus := redis.NewInvalidationBus(client, "myapp:invalidate")
+//	cache, err := hyperion.NewTieredCache(local, shared, hyperion.WithInvalidationBus(bus))
+//
+// # Locks
+//
+// NewLocker implements hyperion.Locker with one key per lock and a token
+// counter beside it, for use directly or with hyperion.LeaderElector:
+//
+//	locker := redis.NewLocker(client, "myapp:")
+//	elector := hyperion.NewLeaderElector(locker, "scheduler", hyperion.OnElected(run))
+//
+// # Health Checks
+//
+//	if hc, ok := cache.(redis.HealthChecker); ok {
+//	    if err := hc.Health(ctx); err != nil {
+//	        log.Error("redis unhealthy", "error", err)
+//	    }
+//	}
+package redis
@@ -0,0 +1,22 @@
+module github.com/mapoio/hyperion/adapter/redis
+
+go 1.24
+
+require (
+	github.com/alicebob/miniredis/v2 v2.37.0
+	github.com/mapoio/hyperion v0.0.0-00010101000000-000000000000
+	github.com/redis/go-redis/v9 v9.17.2
+	go.uber.org/fx v1.24.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
+	go.uber.org/dig v1.19.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	go.uber.org/zap v1.27.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
+
+replace github.com/mapoio/hyperion => ../../hyperion
@@ -0,0 +1,140 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/mapoio/hyperion"
+)
+
+// acquireScript sets KEYS[1] to "ARGV[1]:token" with an expiry of ARGV[2]
+// milliseconds unless it exists, where token is the next value of the
+// counter KEYS[2]. Returns the token, or 0 if the lock is held.
+var acquireScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  return 0
+end
+local token = redis.call('INCR', KEYS[2])
+redis.call('SET', KEYS[1], ARGV[1] .. ':' .. token, 'PX', ARGV[2])
+return token
+`)
+
+// refreshScript sets the expiry of KEYS[1] to ARGV[2] milliseconds if it
+// holds ARGV[1]. Returns whether it did.
+var refreshScript = goredis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+  return 0
+end
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return 1
+`)
+
+// releaseScript deletes KEYS[1] if it holds ARGV[1]. Returns whether it did.
+var releaseScript = goredis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+  return 0
+end
+redis.call('DEL', KEYS[1])
+return 1
+`)
+
+// locker implements hyperion.Locker on Redis.
+type locker struct {
+	client goredis.UniversalClient
+	prefix string
+}
+
+// Ensure locker implements hyperion.Locker interface.
+var _ hyperion.Locker = (*locker)(nil)
+
+// NewLocker creates a hyperion.Locker on a single Redis deployment, with
+// keys prefixed by prefix. The locker does not close client.
+//
+// A lock is one key holding its owner and token, with the lock TTL; fencing
+// tokens come from a counter key next to it that never expires. Both keys
+// share a hash tag, so the scripts work in cluster mode.
+//
+// Locks are only as safe as the deployment: with asynchronous replication
+// a failover can lose a lock. Fencing tokens protect against that too.
+func NewLocker(client goredis.UniversalClient, prefix string) hyperion.Locker {
+	return &locker{client: client, prefix: prefix}
+}
+
+// lockKey returns the lock key and token counter key of key.
+func (l *locker) lockKey(key string) (string, string) {
+	lockKey := l.prefix + "lock:{" + key + "}"
+	return lockKey, lockKey + ":token"
+}
+
+// Acquire takes the lock in one script, or returns hyperion.ErrLockHeld.
+func (l *locker) Acquire(ctx context.Context, key string, ttl time.Duration) (hyperion.Lock, error) {
+	lockKey, tokenKey := l.lockKey(key)
+	owner, err := newOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := acquireScript.Run(ctx, l.client, []string{lockKey, tokenKey}, owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return nil, fmt.Errorf("redis acquire lock %s: %w", key, err)
+	}
+	if token == 0 {
+		return nil, hyperion.ErrLockHeld
+	}
+	return &redisLock{
+		client: l.client,
+		key:    key,
+		redis:  lockKey,
+		value:  owner + ":" + strconv.FormatInt(token, 10),
+		token:  token,
+	}, nil
+}
+
+// newOwner returns a random identifier for one acquisition of a lock.
+func newOwner() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate lock owner: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// redisLock is a lock acquired from a locker.
+type redisLock struct {
+	client goredis.UniversalClient
+	key    string
+	redis  string
+	value  string
+	token  int64
+}
+
+func (r *redisLock) Key() string  { return r.key }
+func (r *redisLock) Token() int64 { return r.token }
+
+// Refresh extends the lock if it still holds the key.
+func (r *redisLock) Refresh(ctx context.Context, ttl time.Duration) error {
+	return r.run(ctx, "refresh", refreshScript, ttl.Milliseconds())
+}
+
+// Release deletes the lock key if it still holds it.
+func (r *redisLock) Release(ctx context.Context) error {
+	return r.run(ctx, "release", releaseScript)
+}
+
+// run runs a script comparing the lock key with this lock's value.
+func (r *redisLock) run(ctx context.Context, op string, script *goredis.Script, args ...any) error {
+	ok, err := script.Run(ctx, r.client, []string{r.redis}, append([]any{r.value}, args...)...).Int()
+	if err != nil {
+		return fmt.Errorf("redis %s lock %s: %w", op, r.key, err)
+	}
+	if ok == 0 {
+		return hyperion.ErrLockLost
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.uber.org/fx"
+
+	"github.com/mapoio/hyperion"
+)
+
+// Module provides a Redis-based Cache implementation.
+//
+// Usage:
+//
+//	fx.New(
+//	    hyperion.CoreModule,
+//	    viper.Module,  // Provides Config
+//	    redis.Module,  // Provides Cache
+//	    myapp.Module,
+//	).Run()
+//
+// Configuration example (config.yaml):
+//
+//	cache:
+//	  redis:
+//	    mode: standalone        # standalone, sentinel or cluster
+//	    addrs: [localhost:6379]
+//	    key_prefix: "myapp:"
+//	    pool_size: 20
+//
+// The server is pinged on start, so an unreachable Redis fails startup,
+// and the client is closed on stop.
+var Module = fx.Module("hyperion.adapter.redis",
+	fx.Provide(
+		fx.Annotate(
+			NewRedisCacheProvider,
+			fx.As(new(hyperion.Cache)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			func() hyperion.ConfigValidator { return ValidateConfig },
+			fx.ResultTags(`group:"hyperion.config_validators"`),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			ConfigSections,
+			fx.ResultTags(`group:"hyperion.config_sections,flatten"`),
+		),
+	),
+	fx.Invoke(registerLifecycle),
+)
+
+// NewRedisCacheProvider creates a Redis cache.
+func NewRedisCacheProvider(cfg hyperion.Config) (hyperion.Cache, error) {
+	return NewRedisCache(cfg)
+}
+
+// registerLifecycle registers cache lifecycle hooks with fx.
+func registerLifecycle(lc fx.Lifecycle, cache hyperion.Cache) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if hc, ok := cache.(HealthChecker); ok {
+				if err := hc.Health(ctx); err != nil {
+					return fmt.Errorf("redis cache unavailable: %w", err)
+				}
+			}
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if closer, ok := cache.(io.Closer); ok {
+				return closer.Close()
+			}
+			return nil
+		},
+	})
+}
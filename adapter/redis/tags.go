@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/mapoio/hyperion"
+)
+
+// tagKeyPrefix follows the key prefix in the names of tag sets. The NUL
+// byte keeps tag sets apart from application keys.
+const tagKeyPrefix = "\x00tag:"
+
+// tagScript adds a member to a tag set and extends the set's expiry to
+// cover the member's TTL, so a set never expires before its keys.
+//
+// KEYS[1] is the tag set, ARGV[1] the member and ARGV[2] its TTL in
+// milliseconds, 0 for none.
+var tagScript = goredis.NewScript(`
+local existed = redis.call('EXISTS', KEYS[1])
+redis.call('SADD', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[2])
+if ttl == 0 then
+  redis.call('PERSIST', KEYS[1])
+  return 1
+end
+local current = redis.call('PTTL', KEYS[1])
+if existed == 0 or (current >= 0 and current < ttl) then
+  redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return 1
+`)
+
+// Ensure redisCache implements hyperion.TaggedCache interface.
+var _ hyperion.TaggedCache = (*redisCache)(nil)
+
+// tagKey returns the name of the set holding the keys tagged with tag.
+func (c *redisCache) tagKey(tag string) string {
+	return c.prefix + tagKeyPrefix + tag
+}
+
+// SetWithTags stores the value and adds its key to a Redis set per tag,
+// in one pipelined round trip. Tag sets expire with their longest-lived key.
+func (c *redisCache) SetWithTags(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	_, err := c.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for _, tag := range tags {
+			tagScript.Eval(ctx, pipe, []string{c.tagKey(tag)}, c.prefix+key, ttl.Milliseconds())
+		}
+		pipe.Set(ctx, c.prefix+key, value, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis SET %s with tags: %w", key, err)
+	}
+	return nil
+}
+
+// InvalidateTags deletes the keys in each tag's set, then the sets:
+// one pipelined SMEMBERS and one pipelined DEL round trip.
+func (c *redisCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	cmds := make([]*goredis.StringSliceCmd, len(tags))
+	_, err := c.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for i, tag := range tags {
+			cmds[i] = pipe.SMembers(ctx, c.tagKey(tag))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		return fmt.Errorf("redis SMEMBERS: %w", err)
+	}
+
+	_, err = c.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for i, tag := range tags {
+			for _, key := range cmds[i].Val() {
+				pipe.Del(ctx, key)
+			}
+			pipe.Del(ctx, c.tagKey(tag))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis DEL: %w", err)
+	}
+	return nil
+}
+
+// DeletePrefix removes every key starting with prefix, under the
+// configured key prefix, using SCAN like Clear. Without either prefix it
+// returns ErrNoKeyPrefix rather than deleting every key in the database.
+func (c *redisCache) DeletePrefix(ctx context.Context, prefix string) error {
+	if c.prefix+prefix == "" {
+		return ErrNoKeyPrefix
+	}
+	return c.deleteMatching(ctx, escapePattern(c.prefix+prefix)+"*")
+}
@@ -0,0 +1,33 @@
+package slog
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/hyperiontest"
+)
+
+func TestConformance(t *testing.T) {
+	hyperiontest.RunLoggerSuite(t, hyperiontest.LoggerHarness{
+		New: func(w io.Writer, level hyperion.LogLevel) hyperion.Logger {
+			logger := NewLogger(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			logger.SetLevel(level)
+			return logger
+		},
+		TraceContext: func() (context.Context, string, string) {
+			traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+			spanID := trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+			ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			}))
+			return ctx, traceID.String(), spanID.String()
+		},
+	})
+}
@@ -0,0 +1,127 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mapoio/hyperion"
+)
+
+// contextAwareLogger wraps slogLogger and automatically injects trace context.
+// The bound context is also passed to the handler, so handlers that read
+// values from the context keep working.
+type contextAwareLogger struct {
+	slogLogger *slogLogger
+	stdCtx     context.Context // The underlying context.Context for trace extraction
+}
+
+// newContextAwareLogger creates a context-aware logger that automatically
+// extracts trace context from the embedded context.Context.
+func newContextAwareLogger(ctx context.Context, slogLogger *slogLogger) hyperion.Logger {
+	return &contextAwareLogger{
+		slogLogger: slogLogger,
+		stdCtx:     ctx,
+	}
+}
+
+// Debug logs a debug message with trace context automatically injected.
+func (c *contextAwareLogger) Debug(msg string, fields ...any) {
+	c.slogLogger.log(c.stdCtx, slog.LevelDebug, msg, c.withTrace(fields))
+}
+
+// Info logs an info message with trace context automatically injected.
+func (c *contextAwareLogger) Info(msg string, fields ...any) {
+	c.slogLogger.log(c.stdCtx, slog.LevelInfo, msg, c.withTrace(fields))
+}
+
+// Warn logs a warning message with trace context automatically injected.
+func (c *contextAwareLogger) Warn(msg string, fields ...any) {
+	c.slogLogger.log(c.stdCtx, slog.LevelWarn, msg, c.withTrace(fields))
+}
+
+// Error logs an error message with trace context automatically injected.
+func (c *contextAwareLogger) Error(msg string, fields ...any) {
+	c.slogLogger.log(c.stdCtx, slog.LevelError, msg, c.withTrace(fields))
+}
+
+// Fatal logs a fatal message with trace context automatically injected and exits.
+func (c *contextAwareLogger) Fatal(msg string, fields ...any) {
+	c.slogLogger.log(c.stdCtx, LevelFatal, msg, c.withTrace(fields))
+	_ = c.slogLogger.Sync()
+	exit(1)
+}
+
+// LogFields logs a message with typed fields and trace context automatically injected.
+// This implements the hyperion.FieldLogger interface.
+func (c *contextAwareLogger) LogFields(level hyperion.LogLevel, msg string, fields ...hyperion.Field) {
+	c.slogLogger.logFields(c.stdCtx, toSlogLevel(level), msg, c.withTraceFields(fields))
+	if level == hyperion.FatalLevel {
+		_ = c.slogLogger.Sync()
+		exit(1)
+	}
+}
+
+// With creates a child logger with additional fields.
+func (c *contextAwareLogger) With(fields ...any) hyperion.Logger {
+	childLogger, ok := c.slogLogger.With(fields...).(*slogLogger)
+	if !ok {
+		// This should never happen since slogLogger.With() always returns *slogLogger
+		return c
+	}
+	return newContextAwareLogger(c.stdCtx, childLogger)
+}
+
+// WithError creates a child logger with an error field.
+func (c *contextAwareLogger) WithError(err error) hyperion.Logger {
+	return c.With("error", err)
+}
+
+// SetLevel changes the log level dynamically.
+func (c *contextAwareLogger) SetLevel(level hyperion.LogLevel) {
+	c.slogLogger.SetLevel(level)
+}
+
+// GetLevel returns the current log level.
+func (c *contextAwareLogger) GetLevel() hyperion.LogLevel {
+	return c.slogLogger.GetLevel()
+}
+
+// Sync flushes any buffered log entries.
+func (c *contextAwareLogger) Sync() error {
+	return c.slogLogger.Sync()
+}
+
+// withTrace prepends trace_id and span_id attributes to fields.
+func (c *contextAwareLogger) withTrace(fields []any) []any {
+	spanCtx := trace.SpanContextFromContext(c.stdCtx)
+
+	// Ended spans still carry a valid span context, so logs emitted after
+	// span.End() remain correlated with the trace.
+	if !spanCtx.IsValid() {
+		return fields
+	}
+
+	withTrace := make([]any, 0, len(fields)+2)
+	withTrace = append(withTrace,
+		slog.String("trace_id", spanCtx.TraceID().String()),
+		slog.String("span_id", spanCtx.SpanID().String()),
+	)
+	return append(withTrace, fields...)
+}
+
+// withTraceFields prepends trace_id and span_id fields to fields.
+func (c *contextAwareLogger) withTraceFields(fields []hyperion.Field) []hyperion.Field {
+	spanCtx := trace.SpanContextFromContext(c.stdCtx)
+	if !spanCtx.IsValid() {
+		return fields
+	}
+
+	withTrace := make([]hyperion.Field, 0, len(fields)+2)
+	withTrace = append(withTrace,
+		hyperion.F.String("trace_id", spanCtx.TraceID().String()),
+		hyperion.F.String("span_id", spanCtx.SpanID().String()),
+	)
+	return append(withTrace, fields...)
+}
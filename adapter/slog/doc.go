@@ -0,0 +1,55 @@
+// Package slog provides a log/slog-based implementation of the hyperion.Logger
+// interface, and a slog.Handler that writes into any hyperion.Logger.
+//
+// # Features
+//
+//   - hyperion.Logger and hyperion.ContextAwareLogger on top of any slog.Handler
+//   - Automatic trace_id and span_id injection via Context.Logger()
+//   - Dynamic log level adjustment at runtime
+//   - Reverse bridge: route third-party slog output into hyperion.Logger
+//
+// # Configuration
+//
+// The logger reads configuration from the provided hyperion.Config under the
+// "log" key, using the same schema as adapter/zap:
+//
+//	log:
+//	  level: info              # debug, info, warn, error, fatal
+//	  encoding: json           # json or text
+//	  output: stdout           # stdout, stderr, or file path
+//
+// # Usage
+//
+// Use slog as the application logger:
+//
+//	fx.New(
+//	    hyperion.CoreModule,
+//	    viper.Module,  // Provides Config
+//	    slog.Module,   // Provides Logger
+//	    fx.Invoke(run),
+//	).Run()
+//
+// Wrap an existing handler:
+//
+//	handler := slog.NewJSONHandler(os.Stdout, nil)
+//	logger := slogadapter.NewLogger(handler)
+//
+// # Bridging slog into hyperion.Logger
+//
+// NewHandler goes the other way: it returns a slog.Handler that forwards
+// records into any hyperion.Logger, so libraries that log through log/slog
+// share the zap pipeline, sampling and redaction:
+//
+//	fx.New(
+//	    zap.Module,         // Provides Logger
+//	    slog.BridgeModule,  // slog.Default() now writes to zap
+//	    fx.Invoke(run),
+//	).Run()
+//
+// Groups are flattened into dotted keys, so slog.Group("http", "method", "GET")
+// is logged as "http.method".
+//
+// # Thread Safety
+//
+// All Logger methods and the bridge handler are safe for concurrent use.
+package slog
@@ -0,0 +1,50 @@
+package slog
+
+import (
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+// toAttr converts a typed hyperion field to the equivalent slog.Attr
+// without boxing its value.
+func toAttr(f hyperion.Field) slog.Attr {
+	switch f.Type {
+	case hyperion.StringType:
+		return slog.String(f.Key, f.String)
+	case hyperion.Int64Type:
+		return slog.Int64(f.Key, f.Integer)
+	case hyperion.Float64Type:
+		return slog.Float64(f.Key, math.Float64frombits(uint64(f.Integer)))
+	case hyperion.BoolType:
+		return slog.Bool(f.Key, f.Integer == 1)
+	case hyperion.DurationType:
+		return slog.Duration(f.Key, time.Duration(f.Integer))
+	default:
+		return slog.Any(f.Key, f.Value())
+	}
+}
+
+// convertArgs replaces hyperion.Field values in a variadic key-value list
+// with slog.Attr, which slog treats as a complete pair.
+// slog itself reports malformed pairs under hyperion.BadKey ("!BADKEY").
+func convertArgs(args []any) []any {
+	var converted []any
+	for i, arg := range args {
+		f, ok := arg.(hyperion.Field)
+		if !ok {
+			continue
+		}
+		if converted == nil {
+			converted = make([]any, len(args))
+			copy(converted, args)
+		}
+		converted[i] = toAttr(f)
+	}
+	if converted == nil {
+		return args
+	}
+	return converted
+}
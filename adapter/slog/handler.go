@@ -0,0 +1,112 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mapoio/hyperion"
+)
+
+// handler is a slog.Handler that writes records into a hyperion.Logger.
+// It lets libraries that log through log/slog share the application's
+// logging pipeline (encoding, sampling, redaction, OTLP export).
+type handler struct {
+	logger hyperion.Logger
+	prefix string // Dotted group prefix applied to attribute keys
+}
+
+// Ensure handler implements slog.Handler interface.
+var _ slog.Handler = (*handler)(nil)
+
+// NewHandler returns a slog.Handler that forwards records to logger.
+//
+// Attribute groups are flattened into dotted keys ("http.method").
+// If logger implements hyperion.ContextAwareLogger, the record's context is
+// bound before logging so trace correlation keeps working. Records at or
+// above LevelFatal are logged at error level: slog callers do not expect
+// the process to exit.
+func NewHandler(logger hyperion.Logger) slog.Handler {
+	return &handler{logger: logger}
+}
+
+// NewSlog returns a *slog.Logger backed by logger.
+//
+// Example:
+//
+//	slog.SetDefault(slogadapter.NewSlog(logger))
+func NewSlog(logger hyperion.Logger) *slog.Logger {
+	return slog.New(NewHandler(logger))
+}
+
+// Enabled reports whether logger accepts records at level.
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return fromSlogLevel(level) >= h.logger.GetLevel()
+}
+
+// Handle converts the record's attributes to key-value fields and logs them.
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	logger := h.logger
+	if contextAware, ok := logger.(hyperion.ContextAwareLogger); ok && ctx != nil {
+		logger = contextAware.WithContext(ctx)
+	}
+
+	fields := make([]any, 0, r.NumAttrs()*2)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, h.prefix, a)
+		return true
+	})
+
+	switch fromSlogLevel(r.Level) {
+	case hyperion.DebugLevel:
+		logger.Debug(r.Message, fields...)
+	case hyperion.InfoLevel:
+		logger.Info(r.Message, fields...)
+	case hyperion.WarnLevel:
+		logger.Warn(r.Message, fields...)
+	default:
+		logger.Error(r.Message, fields...)
+	}
+	return nil
+}
+
+// WithAttrs returns a handler whose logger carries attrs as fields.
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	fields := make([]any, 0, len(attrs)*2)
+	for _, a := range attrs {
+		fields = appendAttr(fields, h.prefix, a)
+	}
+	return &handler{logger: h.logger.With(fields...), prefix: h.prefix}
+}
+
+// WithGroup returns a handler that prefixes subsequent attribute keys with name.
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &handler{logger: h.logger, prefix: h.prefix + name + "."}
+}
+
+// appendAttr appends a as key-value fields, flattening groups into dotted keys.
+func appendAttr(fields []any, prefix string, a slog.Attr) []any {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix = prefix + a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendAttr(fields, groupPrefix, ga)
+		}
+		return fields
+	}
+
+	return append(fields, prefix+a.Key, a.Value.Any())
+}
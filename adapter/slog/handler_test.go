@@ -0,0 +1,143 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/mapoio/hyperion"
+)
+
+// recordedEntry is a single call captured by recordingLogger.
+type recordedEntry struct {
+	ctx    context.Context
+	fields map[string]any
+	msg    string
+	level  hyperion.LogLevel
+}
+
+// recordingLogger is a hyperion.ContextAwareLogger that records every entry.
+type recordingLogger struct {
+	entries *[]recordedEntry
+	ctx     context.Context
+	fields  []any
+	level   hyperion.LogLevel
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{entries: &[]recordedEntry{}}
+}
+
+func (r *recordingLogger) record(level hyperion.LogLevel, msg string, fields []any) {
+	all := append(append([]any{}, r.fields...), fields...)
+	m := make(map[string]any, len(all)/2)
+	for i := 0; i+1 < len(all); i += 2 {
+		key, _ := all[i].(string)
+		m[key] = all[i+1]
+	}
+	*r.entries = append(*r.entries, recordedEntry{ctx: r.ctx, fields: m, msg: msg, level: level})
+}
+
+func (r *recordingLogger) Debug(msg string, fields ...any) {
+	r.record(hyperion.DebugLevel, msg, fields)
+}
+func (r *recordingLogger) Info(msg string, fields ...any) { r.record(hyperion.InfoLevel, msg, fields) }
+func (r *recordingLogger) Warn(msg string, fields ...any) { r.record(hyperion.WarnLevel, msg, fields) }
+func (r *recordingLogger) Error(msg string, fields ...any) {
+	r.record(hyperion.ErrorLevel, msg, fields)
+}
+func (r *recordingLogger) Fatal(msg string, fields ...any) {
+	r.record(hyperion.FatalLevel, msg, fields)
+}
+func (r *recordingLogger) WithError(err error) hyperion.Logger {
+	return r.With("error", err)
+}
+func (r *recordingLogger) SetLevel(level hyperion.LogLevel) { r.level = level }
+func (r *recordingLogger) GetLevel() hyperion.LogLevel      { return r.level }
+func (r *recordingLogger) Sync() error                      { return nil }
+
+func (r *recordingLogger) With(fields ...any) hyperion.Logger {
+	child := *r
+	child.fields = append(append([]any{}, r.fields...), fields...)
+	return &child
+}
+
+func (r *recordingLogger) WithContext(ctx context.Context) hyperion.Logger {
+	child := *r
+	child.ctx = ctx
+	return &child
+}
+
+type ctxKey struct{}
+
+func TestHandler_ForwardsRecords(t *testing.T) {
+	rec := newRecordingLogger()
+	logger := NewSlog(rec)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "bound")
+	logger.InfoContext(ctx, "hello", "user", "alice", slog.Int("count", 3))
+	logger.Warn("careful")
+	logger.Log(context.Background(), LevelFatal, "not fatal")
+
+	entries := *rec.entries
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	if entries[0].msg != "hello" || entries[0].level != hyperion.InfoLevel {
+		t.Errorf("entry 0 = %q/%v, want hello/info", entries[0].msg, entries[0].level)
+	}
+	if entries[0].fields["user"] != "alice" || entries[0].fields["count"] != int64(3) {
+		t.Errorf("entry 0 fields = %v", entries[0].fields)
+	}
+	if entries[0].ctx == nil || entries[0].ctx.Value(ctxKey{}) != "bound" {
+		t.Error("record context must be bound via ContextAwareLogger")
+	}
+	if entries[1].level != hyperion.WarnLevel {
+		t.Errorf("entry 1 level = %v, want warn", entries[1].level)
+	}
+	if entries[2].level != hyperion.ErrorLevel {
+		t.Errorf("fatal slog record must be logged at error level, got %v", entries[2].level)
+	}
+}
+
+func TestHandler_GroupsAndAttrs(t *testing.T) {
+	rec := newRecordingLogger()
+	logger := NewSlog(rec).With("service", "api").WithGroup("http")
+
+	logger.Info("request",
+		"method", "GET",
+		slog.Group("response", "status", 200),
+		slog.Group("", "inline", true),
+		slog.Attr{},
+	)
+
+	fields := (*rec.entries)[0].fields
+	want := map[string]any{
+		"service":              "api",
+		"http.method":          "GET",
+		"http.response.status": int64(200),
+		"http.inline":          true,
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("field %q = %v, want %v", k, fields[k], v)
+		}
+	}
+	if len(fields) != len(want) {
+		t.Errorf("got fields %v, want %v", fields, want)
+	}
+}
+
+func TestHandler_Enabled(t *testing.T) {
+	rec := newRecordingLogger()
+	rec.SetLevel(hyperion.WarnLevel)
+	h := NewHandler(rec)
+
+	if h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("info must be disabled when logger level is warn")
+	}
+	if !h.Enabled(context.Background(), slog.LevelError) {
+		t.Error("error must be enabled when logger level is warn")
+	}
+}
@@ -0,0 +1,324 @@
+package slog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+// LevelFatal is the slog level used for hyperion.FatalLevel.
+// slog has no built-in fatal level, so it is placed above slog.LevelError.
+const LevelFatal = slog.Level(12)
+
+// exit terminates the process after a Fatal log entry.
+// It is a variable so tests can intercept the exit.
+var exit = os.Exit
+
+// slogLogger implements hyperion.Logger interface on top of a slog.Handler.
+type slogLogger struct {
+	logger *slog.Logger
+	level  *slog.LevelVar
+	sync   func() error // Flushes the underlying output, nil if not supported
+}
+
+// Ensure slogLogger implements hyperion.Logger interface.
+var _ hyperion.Logger = (*slogLogger)(nil)
+
+// Ensure slogLogger implements hyperion.ContextAwareLogger interface.
+var _ hyperion.ContextAwareLogger = (*slogLogger)(nil)
+
+// Ensure slogLogger implements hyperion.FieldLogger interface.
+var _ hyperion.FieldLogger = (*slogLogger)(nil)
+
+// Config holds configuration for the slog logger.
+// It uses the same "log" section as adapter/zap.
+type Config struct {
+	Level    string `mapstructure:"level" default:"info" validate:"oneof=debug info warn error fatal"` // Log level: debug, info, warn, error, fatal
+	Encoding string `mapstructure:"encoding" default:"json" validate:"oneof=json text console"`        // Encoding format: json or text (console is accepted as text)
+	Output   string `mapstructure:"output" default:"stdout"`                                           // Output destination: stdout, stderr, or file path
+}
+
+// NewSlogLogger creates a new slog-based logger.
+// It reads configuration from the provided hyperion.Config under the "log" key.
+// If no configuration is found, sensible defaults are used.
+func NewSlogLogger(cfg hyperion.Config) (hyperion.Logger, error) {
+	logCfg := &Config{
+		Level:    "info",
+		Encoding: "json",
+		Output:   "stdout",
+	}
+
+	if cfg != nil {
+		if err := cfg.Unmarshal("log", logCfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal log config: %w", err)
+		}
+	}
+
+	level, err := parseLevel(logCfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		w    io.Writer
+		sync func() error
+	)
+	switch logCfg.Output {
+	case "stdout":
+		w = os.Stdout
+	case "stderr":
+		w = os.Stderr
+	default:
+		// Treat as file path
+		f, err := os.OpenFile(logCfg.Output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file %q: %w", logCfg.Output, err)
+		}
+		w = f
+		sync = f.Sync
+	}
+
+	levelVar := new(slog.LevelVar)
+	levelVar.Set(toSlogLevel(level))
+
+	// The handler itself accepts everything; filtering is done by the LevelVar
+	// so SetLevel takes effect immediately.
+	opts := &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       slog.Level(-8),
+		ReplaceAttr: replaceLevelName,
+	}
+
+	var handler slog.Handler
+	switch logCfg.Encoding {
+	case "json":
+		handler = slog.NewJSONHandler(w, opts)
+	case "text", "console":
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", logCfg.Encoding)
+	}
+
+	return newSlogLogger(handler, levelVar, sync), nil
+}
+
+// NewLogger creates a hyperion.Logger that writes to the given slog.Handler.
+//
+// The initial level is the lowest level the handler reports as enabled.
+// SetLevel can only raise the effective level above the handler's own
+// minimum, since records the handler rejects are never emitted.
+func NewLogger(handler slog.Handler) hyperion.Logger {
+	levelVar := new(slog.LevelVar)
+	levelVar.Set(minEnabledLevel(handler))
+	return newSlogLogger(handler, levelVar, nil)
+}
+
+// newSlogLogger wraps handler with a level gate controlled by levelVar.
+func newSlogLogger(handler slog.Handler, levelVar *slog.LevelVar, sync func() error) *slogLogger {
+	return &slogLogger{
+		logger: slog.New(&levelHandler{level: levelVar, handler: handler}),
+		level:  levelVar,
+		sync:   sync,
+	}
+}
+
+// Debug logs a debug message with optional fields.
+func (l *slogLogger) Debug(msg string, fields ...any) {
+	l.log(context.Background(), slog.LevelDebug, msg, fields)
+}
+
+// Info logs an info message with optional fields.
+func (l *slogLogger) Info(msg string, fields ...any) {
+	l.log(context.Background(), slog.LevelInfo, msg, fields)
+}
+
+// Warn logs a warning message with optional fields.
+func (l *slogLogger) Warn(msg string, fields ...any) {
+	l.log(context.Background(), slog.LevelWarn, msg, fields)
+}
+
+// Error logs an error message with optional fields.
+func (l *slogLogger) Error(msg string, fields ...any) {
+	l.log(context.Background(), slog.LevelError, msg, fields)
+}
+
+// Fatal logs a fatal message with optional fields and exits the process.
+func (l *slogLogger) Fatal(msg string, fields ...any) {
+	l.log(context.Background(), LevelFatal, msg, fields)
+	_ = l.Sync()
+	exit(1)
+}
+
+// LogFields logs a message with typed fields mapped directly to slog.Attr.
+// This implements the hyperion.FieldLogger interface.
+func (l *slogLogger) LogFields(level hyperion.LogLevel, msg string, fields ...hyperion.Field) {
+	l.logFields(context.Background(), toSlogLevel(level), msg, fields)
+	if level == hyperion.FatalLevel {
+		_ = l.Sync()
+		exit(1)
+	}
+}
+
+// With creates a child logger with additional fields.
+func (l *slogLogger) With(fields ...any) hyperion.Logger {
+	return &slogLogger{
+		logger: l.logger.With(convertArgs(fields)...),
+		level:  l.level,
+		sync:   l.sync,
+	}
+}
+
+// WithError creates a child logger with an error field.
+func (l *slogLogger) WithError(err error) hyperion.Logger {
+	return l.With("error", err)
+}
+
+// SetLevel changes the log level dynamically.
+func (l *slogLogger) SetLevel(level hyperion.LogLevel) {
+	l.level.Set(toSlogLevel(level))
+}
+
+// GetLevel returns the current log level.
+func (l *slogLogger) GetLevel() hyperion.LogLevel {
+	return fromSlogLevel(l.level.Level())
+}
+
+// Sync flushes any buffered log entries.
+func (l *slogLogger) Sync() error {
+	if l.sync == nil {
+		return nil
+	}
+	return l.sync()
+}
+
+// WithContext returns a context-aware logger that automatically injects
+// trace context (trace_id and span_id) into all log entries.
+// This implements the hyperion.ContextAwareLogger interface.
+func (l *slogLogger) WithContext(ctx context.Context) hyperion.Logger {
+	return newContextAwareLogger(ctx, l)
+}
+
+// log builds and emits a record, recording the caller of the exported
+// logging method as the record's source.
+func (l *slogLogger) log(ctx context.Context, level slog.Level, msg string, fields []any) {
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip [Callers, log, exported method]
+
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.Add(convertArgs(fields)...)
+	_ = l.logger.Handler().Handle(ctx, r)
+}
+
+// logFields is log for typed fields. It is always reached through
+// hyperion.LogFields, which adds one frame to skip.
+func (l *slogLogger) logFields(ctx context.Context, level slog.Level, msg string, fields []hyperion.Field) {
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(4, pcs[:]) // skip [Callers, logFields, LogFields, hyperion.LogFields]
+
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	for _, f := range fields {
+		r.AddAttrs(toAttr(f))
+	}
+	_ = l.logger.Handler().Handle(ctx, r)
+}
+
+// levelHandler gates an inner handler with a dynamic minimum level.
+type levelHandler struct {
+	level   slog.Leveler
+	handler slog.Handler
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.handler.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithAttrs(attrs)}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithGroup(name)}
+}
+
+// levelMapping defines mapping from hyperion to slog log levels.
+var levelMapping = map[hyperion.LogLevel]slog.Level{
+	hyperion.DebugLevel: slog.LevelDebug,
+	hyperion.InfoLevel:  slog.LevelInfo,
+	hyperion.WarnLevel:  slog.LevelWarn,
+	hyperion.ErrorLevel: slog.LevelError,
+	hyperion.FatalLevel: LevelFatal,
+}
+
+// toSlogLevel converts hyperion.LogLevel to slog.Level.
+func toSlogLevel(level hyperion.LogLevel) slog.Level {
+	if slogLevel, ok := levelMapping[level]; ok {
+		return slogLevel
+	}
+	return slog.LevelInfo // default
+}
+
+// fromSlogLevel converts slog.Level to hyperion.LogLevel.
+// slog levels are integers, so intermediate values map to the
+// nearest hyperion level at or below them.
+func fromSlogLevel(level slog.Level) hyperion.LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return hyperion.DebugLevel
+	case level < slog.LevelWarn:
+		return hyperion.InfoLevel
+	case level < slog.LevelError:
+		return hyperion.WarnLevel
+	case level < LevelFatal:
+		return hyperion.ErrorLevel
+	default:
+		return hyperion.FatalLevel
+	}
+}
+
+// parseLevel parses a configured level name.
+func parseLevel(s string) (hyperion.LogLevel, error) {
+	for level := hyperion.DebugLevel; level <= hyperion.FatalLevel; level++ {
+		if level.String() == s {
+			return level, nil
+		}
+	}
+	return hyperion.InfoLevel, fmt.Errorf("invalid log level %q", s)
+}
+
+// minEnabledLevel returns the lowest hyperion level enabled by handler.
+func minEnabledLevel(handler slog.Handler) slog.Level {
+	for level := hyperion.DebugLevel; level <= hyperion.FatalLevel; level++ {
+		if handler.Enabled(context.Background(), toSlogLevel(level)) {
+			return toSlogLevel(level)
+		}
+	}
+	return LevelFatal
+}
+
+// replaceLevelName renders LevelFatal as "FATAL" instead of "ERROR+4".
+func replaceLevelName(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.LevelKey {
+		if level, ok := a.Value.Any().(slog.Level); ok && level >= LevelFatal {
+			return slog.String(slog.LevelKey, "FATAL")
+		}
+	}
+	return a
+}
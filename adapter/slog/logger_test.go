@@ -0,0 +1,285 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/mapoio/hyperion"
+)
+
+// mockConfig implements hyperion.Config for testing.
+type mockConfig struct {
+	hyperion.Config
+	log map[string]string
+}
+
+func (m *mockConfig) Unmarshal(key string, rawVal any) error {
+	if logCfg, ok := rawVal.(*Config); ok && key == "log" {
+		if v, ok := m.log["level"]; ok {
+			logCfg.Level = v
+		}
+		if v, ok := m.log["encoding"]; ok {
+			logCfg.Encoding = v
+		}
+		if v, ok := m.log["output"]; ok {
+			logCfg.Output = v
+		}
+	}
+	return nil
+}
+
+// newBufferLogger creates a slogLogger writing JSON to a buffer.
+func newBufferLogger(level slog.Level) (*slogLogger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true, Level: level})
+	logger, _ := NewLogger(handler).(*slogLogger)
+	return logger, &buf
+}
+
+// decodeLines decodes each JSON log line in buf.
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid JSON log line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestNewSlogLogger_DefaultConfig(t *testing.T) {
+	logger, err := NewSlogLogger(nil)
+	if err != nil {
+		t.Fatalf("NewSlogLogger() error = %v", err)
+	}
+
+	if level := logger.GetLevel(); level != hyperion.InfoLevel {
+		t.Errorf("GetLevel() = %v, want %v", level, hyperion.InfoLevel)
+	}
+}
+
+func TestNewSlogLogger_WithConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       map[string]string
+		wantLevel hyperion.LogLevel
+		wantErr   bool
+	}{
+		{name: "debug json", log: map[string]string{"level": "debug", "encoding": "json"}, wantLevel: hyperion.DebugLevel},
+		{name: "warn text", log: map[string]string{"level": "warn", "encoding": "text"}, wantLevel: hyperion.WarnLevel},
+		{name: "console is text", log: map[string]string{"encoding": "console", "output": "stderr"}, wantLevel: hyperion.InfoLevel},
+		{name: "invalid level", log: map[string]string{"level": "verbose"}, wantErr: true},
+		{name: "invalid encoding", log: map[string]string{"encoding": "xml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, err := NewSlogLogger(&mockConfig{log: tt.log})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSlogLogger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if level := logger.GetLevel(); level != tt.wantLevel {
+				t.Errorf("GetLevel() = %v, want %v", level, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestNewSlogLogger_FileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := NewSlogLogger(&mockConfig{log: map[string]string{"output": path}})
+	if err != nil {
+		t.Fatalf("NewSlogLogger() error = %v", err)
+	}
+
+	logger.Info("to file")
+	if err := logger.Sync(); err != nil {
+		t.Errorf("Sync() error = %v", err)
+	}
+}
+
+func TestSlogLogger_LogMethods(t *testing.T) {
+	logger, buf := newBufferLogger(slog.LevelDebug)
+
+	logger.Debug("debug message", "key", "value")
+	logger.Info("info message", "count", 42)
+	logger.Warn("warn message")
+	logger.Error("error message")
+
+	entries := decodeLines(t, buf)
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4", len(entries))
+	}
+
+	wantLevels := []string{"DEBUG", "INFO", "WARN", "ERROR"}
+	for i, want := range wantLevels {
+		if got := entries[i]["level"]; got != want {
+			t.Errorf("entry %d level = %v, want %v", i, got, want)
+		}
+	}
+	if entries[0]["key"] != "value" {
+		t.Errorf("entry 0 key = %v, want value", entries[0]["key"])
+	}
+
+	// Source must point at the caller, not at the adapter
+	source, _ := entries[0]["source"].(map[string]any)
+	if file, _ := source["file"].(string); !strings.HasSuffix(file, "logger_test.go") {
+		t.Errorf("source file = %v, want logger_test.go", source["file"])
+	}
+}
+
+func TestSlogLogger_Fatal(t *testing.T) {
+	var code int
+	origExit := exit
+	exit = func(c int) { code = c }
+	defer func() { exit = origExit }()
+
+	logger, buf := newBufferLogger(slog.LevelDebug)
+	logger.Fatal("fatal message")
+
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	if !strings.Contains(buf.String(), "fatal message") {
+		t.Errorf("expected fatal message in output, got %s", buf.String())
+	}
+}
+
+func TestSlogLogger_WithAndWithError(t *testing.T) {
+	logger, buf := newBufferLogger(slog.LevelDebug)
+
+	logger.With("request_id", "abc123").WithError(context.Canceled).Info("child")
+	logger.Info("parent")
+
+	entries := decodeLines(t, buf)
+	if entries[0]["request_id"] != "abc123" {
+		t.Errorf("request_id = %v, want abc123", entries[0]["request_id"])
+	}
+	if entries[0]["error"] != context.Canceled.Error() {
+		t.Errorf("error = %v, want %v", entries[0]["error"], context.Canceled)
+	}
+	if _, ok := entries[1]["request_id"]; ok {
+		t.Error("parent logger must not inherit child fields")
+	}
+}
+
+func TestSlogLogger_SetLevel(t *testing.T) {
+	logger, buf := newBufferLogger(slog.LevelDebug)
+
+	if level := logger.GetLevel(); level != hyperion.DebugLevel {
+		t.Errorf("initial GetLevel() = %v, want %v", level, hyperion.DebugLevel)
+	}
+
+	child := logger.With("k", "v")
+	logger.SetLevel(hyperion.WarnLevel)
+
+	child.Info("suppressed")
+	child.Warn("emitted")
+
+	entries := decodeLines(t, buf)
+	if len(entries) != 1 || entries[0]["msg"] != "emitted" {
+		t.Errorf("expected only the warn entry, got %v", entries)
+	}
+	if level := child.GetLevel(); level != hyperion.WarnLevel {
+		t.Errorf("child GetLevel() = %v, want %v", level, hyperion.WarnLevel)
+	}
+}
+
+func TestNewLogger_HandlerMinimumLevel(t *testing.T) {
+	logger, _ := newBufferLogger(slog.LevelWarn)
+
+	if level := logger.GetLevel(); level != hyperion.WarnLevel {
+		t.Errorf("GetLevel() = %v, want %v", level, hyperion.WarnLevel)
+	}
+}
+
+func TestSlogLogger_WithContext(t *testing.T) {
+	logger, buf := newBufferLogger(slog.LevelDebug)
+
+	traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanID := trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	logger.WithContext(ctx).With("user", "alice").Info("traced")
+	logger.WithContext(context.Background()).Info("untraced")
+
+	entries := decodeLines(t, buf)
+	if entries[0]["trace_id"] != traceID.String() {
+		t.Errorf("trace_id = %v, want %v", entries[0]["trace_id"], traceID)
+	}
+	if entries[0]["span_id"] != spanID.String() {
+		t.Errorf("span_id = %v, want %v", entries[0]["span_id"], spanID)
+	}
+	if entries[0]["user"] != "alice" {
+		t.Errorf("user = %v, want alice", entries[0]["user"])
+	}
+	if _, ok := entries[1]["trace_id"]; ok {
+		t.Error("trace_id must not be set without an active span")
+	}
+}
+
+func TestLevelConversion(t *testing.T) {
+	for level := hyperion.DebugLevel; level <= hyperion.FatalLevel; level++ {
+		if got := fromSlogLevel(toSlogLevel(level)); got != level {
+			t.Errorf("fromSlogLevel(toSlogLevel(%v)) = %v", level, got)
+		}
+	}
+
+	if got := fromSlogLevel(slog.LevelInfo + 2); got != hyperion.InfoLevel {
+		t.Errorf("fromSlogLevel(INFO+2) = %v, want %v", got, hyperion.InfoLevel)
+	}
+	if got := toSlogLevel(hyperion.LogLevel(99)); got != slog.LevelInfo {
+		t.Errorf("toSlogLevel(99) = %v, want %v", got, slog.LevelInfo)
+	}
+}
+
+func TestConfigSections(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"log": map[string]any{"level": "debug", "encoding": "json", "output": "stderr"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues := hyperion.LintConfig(cfg, ConfigSections()); len(issues) != 0 {
+		t.Errorf("LintConfig = %v, want no issues", issues)
+	}
+
+	var sections []hyperion.ConfigSection
+	app := fxtest.New(t,
+		fx.Provide(func() hyperion.Config { return cfg }),
+		Module,
+		fx.Invoke(fx.Annotate(
+			func(s []hyperion.ConfigSection) { sections = s },
+			fx.ParamTags(`group:"hyperion.config_sections"`),
+		)),
+	)
+	app.RequireStart()
+	app.RequireStop()
+
+	if len(sections) != 1 || sections[0].Key != "log" {
+		t.Errorf("Module config sections = %+v, want the log section", sections)
+	}
+}
@@ -0,0 +1,68 @@
+package slog
+
+import (
+	"log/slog"
+
+	"go.uber.org/fx"
+
+	"github.com/mapoio/hyperion"
+)
+
+// Module provides slog-based Logger implementation.
+//
+// Usage:
+//
+//	fx.New(
+//	    hyperion.CoreModule,
+//	    viper.Module,   // Provides Config (optional for slog)
+//	    slog.Module,    // Provides Logger
+//	    myapp.Module,
+//	).Run()
+var Module = fx.Module("hyperion.adapter.slog",
+	fx.Provide(
+		fx.Annotate(
+			NewSlogProvider,
+			fx.As(new(hyperion.Logger)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			ConfigSections,
+			fx.ResultTags(`group:"hyperion.config_sections,flatten"`),
+		),
+	),
+)
+
+// ConfigSections describes the "log" section for JSON Schema generation
+// and linting. Module contributes it to the "hyperion.config_sections" group.
+func ConfigSections() []hyperion.ConfigSection {
+	return []hyperion.ConfigSection{{
+		Key:         "log",
+		Type:        Config{},
+		Description: "Logging (adapter/slog)",
+	}}
+}
+
+// BridgeModule routes the process-wide default slog logger into the
+// hyperion.Logger provided by any adapter (e.g. zap.Module).
+// Third-party libraries logging through log/slog then share the
+// application's logging pipeline.
+//
+// Usage:
+//
+//	fx.New(
+//	    hyperion.CoreModule,
+//	    zap.Module,          // Provides Logger
+//	    slog.BridgeModule,   // slog.Default() now writes to zap
+//	    myapp.Module,
+//	).Run()
+var BridgeModule = fx.Module("hyperion.adapter.slog.bridge",
+	fx.Invoke(func(logger hyperion.Logger) {
+		slog.SetDefault(NewSlog(logger))
+	}),
+)
+
+// NewSlogProvider creates a slog logger.
+func NewSlogProvider(cfg hyperion.Config) (hyperion.Logger, error) {
+	return NewSlogLogger(cfg)
+}
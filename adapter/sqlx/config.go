@@ -0,0 +1,248 @@
+package sqlx
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"dario.cat/mergo"
+	"github.com/go-playground/validator/v10"
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+
+	// Register the database/sql drivers for each supported Driver.
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/mapoio/hyperion"
+)
+
+const (
+	// DriverPostgres represents the PostgreSQL driver (pgx).
+	DriverPostgres = "postgres"
+	// DriverMySQL represents the MySQL driver (go-sql-driver/mysql).
+	DriverMySQL = "mysql"
+	// DriverSQLite represents the SQLite driver (mattn/go-sqlite3, requires cgo).
+	DriverSQLite = "sqlite"
+)
+
+// Config represents the database configuration.
+//
+// The schema is the same as adapter/gorm's, so an application can switch
+// between the two adapters without touching its "database" section. The
+// GORM-specific settings are accepted and ignored.
+// Fields are ordered for optimal memory alignment (larger types first).
+type Config struct {
+	// String fields (16 bytes on 64-bit: 8-byte pointer + 8-byte length)
+	Driver   string `mapstructure:"driver" json:"driver" yaml:"driver" validate:"required,oneof=postgres mysql sqlite"` // Driver specifies the database driver (postgres, mysql, sqlite)
+	DSN      string `mapstructure:"dsn" json:"dsn" yaml:"dsn"`                                                          // DSN allows providing a complete connection string
+	Host     string `mapstructure:"host" json:"host" yaml:"host" validate:"omitempty,hostname|ip"`                      // Connection host
+	Username string `mapstructure:"username" json:"username" yaml:"username"`                                           // Connection username
+	Password string `mapstructure:"password" json:"password" yaml:"password"`                                           // Connection password
+	Database string `mapstructure:"database" json:"database" yaml:"database"`                                           // Database name (required for non-SQLite unless DSN provided)
+	SSLMode  string `mapstructure:"sslmode" json:"sslmode" yaml:"sslmode" validate:"omitempty,oneof=disable require verify-ca verify-full"`
+	Charset  string `mapstructure:"charset" json:"charset" yaml:"charset"`       // MySQL charset
+	LogLevel string `mapstructure:"log_level" json:"log_level" yaml:"log_level"` // GORM only; ignored
+
+	// Duration fields (8 bytes each)
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime" json:"conn_max_lifetime" yaml:"conn_max_lifetime" validate:"omitempty,min=0"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time" json:"conn_max_idle_time" yaml:"conn_max_idle_time" validate:"omitempty,min=0"`
+	SlowThreshold   time.Duration `mapstructure:"slow_threshold" json:"slow_threshold" yaml:"slow_threshold" validate:"omitempty,min=0"` // GORM only; ignored
+
+	// Int fields (8 bytes on 64-bit)
+	MaxOpenConns int `mapstructure:"max_open_conns" json:"max_open_conns" yaml:"max_open_conns" validate:"omitempty,min=0"`
+	MaxIdleConns int `mapstructure:"max_idle_conns" json:"max_idle_conns" yaml:"max_idle_conns" validate:"omitempty,min=0"`
+	Port         int `mapstructure:"port" json:"port" yaml:"port" validate:"omitempty,min=1,max=65535"`
+
+	// Bool fields - GORM only; ignored
+	SkipDefaultTransaction *bool `mapstructure:"skip_default_transaction" json:"skip_default_transaction" yaml:"skip_default_transaction"`
+	PrepareStmt            *bool `mapstructure:"prepare_stmt" json:"prepare_stmt" yaml:"prepare_stmt"`
+	AutoMigrate            *bool `mapstructure:"auto_migrate" json:"auto_migrate" yaml:"auto_migrate"`
+}
+
+// DefaultConfig returns a configuration with sensible defaults.
+// The values match adapter/gorm's defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Driver:          DriverSQLite,
+		Database:        "hyperion.db",
+		Host:            "localhost",
+		Port:            5432,
+		SSLMode:         "disable",
+		Charset:         "utf8mb4",
+		MaxOpenConns:    25,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: 5 * time.Minute,
+		ConnMaxIdleTime: 10 * time.Minute,
+	}
+}
+
+// NewSqlxDatabase creates a new sqlx database instance from configuration.
+// It supports PostgreSQL, MySQL, and SQLite drivers.
+func NewSqlxDatabase(cfg hyperion.Config) (hyperion.Database, error) {
+	dbConfig := DefaultConfig()
+	if err := loadConfig(cfg, dbConfig); err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := dbConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	db, err := dbConfig.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	return &sqlxDatabase{db: db}, nil
+}
+
+// ValidateConfig loads the database configuration from cfg, with defaults,
+// and validates it. It is registered as a hyperion.ConfigValidator by Module
+// so that config reloads with an invalid database section are rejected.
+func ValidateConfig(cfg hyperion.Config) error {
+	dbConfig := DefaultConfig()
+	if err := loadConfig(cfg, dbConfig); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid database config: %w", err)
+	}
+	return nil
+}
+
+// ConfigSections describes the "database" section for JSON Schema
+// generation and linting. Module contributes it to the
+// "hyperion.config_sections" group.
+func ConfigSections() []hyperion.ConfigSection {
+	return []hyperion.ConfigSection{{
+		Key:         "database",
+		Type:        Config{},
+		Validate:    ValidateConfig,
+		Description: "Database connection and pool settings (adapter/sqlx)",
+	}}
+}
+
+// NewSqlxUnitOfWork creates a new UnitOfWork from a Database instance.
+func NewSqlxUnitOfWork(db hyperion.Database) hyperion.UnitOfWork {
+	sdb, ok := db.(*sqlxDatabase)
+	if !ok {
+		panic("db must be a *sqlxDatabase instance")
+	}
+	return &sqlxUnitOfWork{db: sdb.db}
+}
+
+// loadConfig loads configuration from hyperion.Config and merges with defaults.
+// Like adapter/gorm, it reads both the "database" prefix and the root level,
+// with root taking precedence.
+func loadConfig(src hyperion.Config, dst *Config) error {
+	var prefixed Config
+	if src.IsSet("database") {
+		if err := src.Unmarshal("database", &prefixed); err != nil {
+			return fmt.Errorf("failed to unmarshal database config: %w", err)
+		}
+	}
+
+	var root Config
+	hasRootConfig := src.IsSet("driver") || src.IsSet("host") || src.IsSet("port")
+	if hasRootConfig {
+		if err := src.Unmarshal("", &root); err != nil {
+			return fmt.Errorf("failed to unmarshal root config: %w", err)
+		}
+	}
+
+	if err := mergo.Merge(&prefixed, root, mergo.WithOverride); err != nil {
+		return fmt.Errorf("failed to merge root config: %w", err)
+	}
+	if err := mergo.Merge(dst, prefixed, mergo.WithOverride); err != nil {
+		return fmt.Errorf("failed to merge config: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks if the configuration is valid using validator.
+// Validation rules are defined in struct tags (validate:"...").
+func (c *Config) Validate() error {
+	validate := validator.New()
+
+	if err := validate.Struct(c); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			firstErr := validationErrors[0]
+			return fmt.Errorf("validation failed for field '%s': %s (value: '%v')",
+				firstErr.Field(),
+				firstErr.Tag(),
+				firstErr.Value())
+		}
+		return err
+	}
+
+	// Database is required for non-SQLite drivers unless DSN is provided
+	if c.DSN == "" && c.Driver != DriverSQLite && c.Database == "" {
+		return fmt.Errorf("database name is required for driver '%s'", c.Driver)
+	}
+
+	return nil
+}
+
+// Open opens a connection pool with the configured settings.
+// Untagged struct fields map to snake_case columns, as in GORM.
+func (c *Config) Open() (*sqlx.DB, error) {
+	driverName, err := c.driverName()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sqlx.Open(driverName, c.buildDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	db.Mapper = reflectx.NewMapperFunc("db", toSnakeCase)
+
+	db.SetMaxOpenConns(c.MaxOpenConns)
+	db.SetMaxIdleConns(c.MaxIdleConns)
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+
+	return db, nil
+}
+
+// driverName returns the database/sql driver registered for the Driver.
+func (c *Config) driverName() (string, error) {
+	switch c.Driver {
+	case DriverPostgres:
+		return "pgx", nil
+	case DriverMySQL:
+		return "mysql", nil
+	case DriverSQLite:
+		return "sqlite3", nil
+	default:
+		return "", fmt.Errorf("unsupported driver: %s", c.Driver)
+	}
+}
+
+// buildDSN builds a DSN string based on the driver and configuration.
+func (c *Config) buildDSN() string {
+	if c.DSN != "" {
+		return c.DSN
+	}
+
+	switch c.Driver {
+	case DriverPostgres:
+		return fmt.Sprintf(
+			"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+			c.Host, c.Port, c.Username, c.Password, c.Database, c.SSLMode,
+		)
+	case DriverMySQL:
+		return fmt.Sprintf(
+			"%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=True&loc=Local",
+			c.Username, c.Password, c.Host, c.Port, c.Database, c.Charset,
+		)
+	case DriverSQLite:
+		return c.Database
+	default:
+		return ""
+	}
+}
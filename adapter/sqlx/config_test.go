@@ -0,0 +1,117 @@
+package sqlx_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/mapoio/hyperion"
+	hsqlx "github.com/mapoio/hyperion/adapter/sqlx"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*hsqlx.Config)
+	}{
+		{"unknown driver", func(c *hsqlx.Config) { c.Driver = "oracle" }},
+		{"postgres without database", func(c *hsqlx.Config) { c.Driver = hsqlx.DriverPostgres; c.Database = "" }},
+		{"bad sslmode", func(c *hsqlx.Config) { c.SSLMode = "sometimes" }},
+		{"negative pool", func(c *hsqlx.Config) { c.MaxOpenConns = -1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := hsqlx.DefaultConfig()
+			tt.modify(cfg)
+			if err := cfg.Validate(); err == nil {
+				t.Error("Validate accepted an invalid config")
+			}
+		})
+	}
+
+	if err := hsqlx.DefaultConfig().Validate(); err != nil {
+		t.Errorf("DefaultConfig is invalid: %v", err)
+	}
+	cfg := hsqlx.DefaultConfig()
+	cfg.Driver, cfg.Database, cfg.DSN = hsqlx.DriverPostgres, "", "postgres://localhost/app"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate rejected a DSN without database name: %v", err)
+	}
+}
+
+// TestValidateConfig checks that a gorm configuration, including its
+// GORM-only settings, is accepted unchanged.
+func TestValidateConfig(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"database": map[string]any{
+			"driver":                   hsqlx.DriverMySQL,
+			"database":                 "app",
+			"log_level":                "info",
+			"slow_threshold":           "100ms",
+			"prepare_stmt":             false,
+			"skip_default_transaction": true,
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hsqlx.ValidateConfig(cfg); err != nil {
+		t.Errorf("ValidateConfig failed: %v", err)
+	}
+	if issues := hyperion.LintConfig(cfg, hsqlx.ConfigSections()); len(issues) != 0 {
+		t.Errorf("LintConfig = %v, want no issues", issues)
+	}
+
+	bad, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"database": map[string]any{"driver": "oracle"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hsqlx.ValidateConfig(bad); err == nil {
+		t.Error("ValidateConfig accepted an unknown driver")
+	}
+	if _, err := hsqlx.NewSqlxDatabase(bad); err == nil {
+		t.Error("NewSqlxDatabase accepted an unknown driver")
+	}
+}
+
+func TestModule(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"database": map[string]any{
+			"driver":   hsqlx.DriverSQLite,
+			"database": filepath.Join(t.TempDir(), "app.db"),
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		db  hyperion.Database
+		uow hyperion.UnitOfWork
+	)
+	app := fxtest.New(t,
+		fx.Provide(func() hyperion.Config { return cfg }),
+		hsqlx.Module,
+		fx.Populate(&db, &uow),
+	)
+	app.RequireStart()
+
+	ctx := context.Background()
+	if err := db.Health(ctx); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if uow == nil {
+		t.Fatal("Module did not provide a UnitOfWork")
+	}
+
+	app.RequireStop()
+	if err := db.Health(ctx); err == nil {
+		t.Error("Health succeeded after the module stopped and closed the pool")
+	}
+}
@@ -0,0 +1,43 @@
+package sqlx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mapoio/hyperion"
+)
+
+// sqlxDatabase implements hyperion.Database interface using sqlx.
+// It provides database connectivity, health checks, and graceful shutdown.
+type sqlxDatabase struct {
+	db *sqlx.DB
+}
+
+// Ensure interface compliance at compile time.
+var _ hyperion.Database = (*sqlxDatabase)(nil)
+
+// Executor returns the default database executor.
+// The returned executor can be used for non-transactional operations.
+func (d *sqlxDatabase) Executor() hyperion.Executor {
+	return &sqlxExecutor{db: d.db}
+}
+
+// Health checks the database connection by pinging the pool.
+// Returns an error if the connection is not healthy.
+func (d *sqlxDatabase) Health(ctx context.Context) error {
+	if err := d.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("database ping failed: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying database connection pool.
+// This should be called during application shutdown.
+func (d *sqlxDatabase) Close() error {
+	if err := d.db.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+	return nil
+}
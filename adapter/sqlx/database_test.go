@@ -0,0 +1,171 @@
+package sqlx_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mapoio/hyperion"
+	hsqlx "github.com/mapoio/hyperion/adapter/sqlx"
+	"github.com/mapoio/hyperion/hyperiontest"
+)
+
+// newDatabase opens a SQLite file database, closed when the test ends.
+// A file rather than :memory: so every pooled connection sees the same data.
+func newDatabase(t *testing.T) hyperion.Database {
+	t.Helper()
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"database": map[string]any{
+			"driver":   hsqlx.DriverSQLite,
+			"database": filepath.Join(t.TempDir(), "test.db"),
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := hsqlx.NewSqlxDatabase(cfg)
+	if err != nil {
+		t.Fatalf("NewSqlxDatabase failed: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestConformance(t *testing.T) {
+	hyperiontest.RunExecutorSuite(t, hyperiontest.ExecutorHarness{
+		New:        newDatabase,
+		UnitOfWork: hsqlx.NewSqlxUnitOfWork,
+	})
+}
+
+func TestQuery_ColumnMapping(t *testing.T) {
+	ctx := context.Background()
+	exec := newDatabase(t).Executor()
+	if _, err := exec.Exec(ctx, "CREATE TABLE accounts (user_id INTEGER, http_status INTEGER, nick TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := exec.Exec(ctx, "INSERT INTO accounts VALUES (?, ?, ?)", 7, 200, "ace"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Initialisms stay together; db tags take precedence
+	var got struct {
+		UserID     int64
+		HTTPStatus int
+		Name       string `db:"nick"`
+	}
+	if err := exec.Query(ctx, &got, "SELECT user_id, http_status, nick FROM accounts"); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if got.UserID != 7 || got.HTTPStatus != 200 || got.Name != "ace" {
+		t.Errorf("Query = %+v", got)
+	}
+
+	// A single-row dest is left unchanged when nothing matches
+	got.Name = "unchanged"
+	if err := exec.Query(ctx, &got, "SELECT user_id, http_status, nick FROM accounts WHERE user_id = ?", 0); err != nil {
+		t.Fatalf("Query without rows failed: %v", err)
+	}
+	if got.Name != "unchanged" {
+		t.Errorf("Query without rows overwrote dest: %+v", got)
+	}
+
+	var blob []byte
+	if err := exec.Query(ctx, &blob, "SELECT nick FROM accounts"); err != nil {
+		t.Fatalf("Query([]byte) failed: %v", err)
+	}
+	if string(blob) != "ace" {
+		t.Errorf("Query([]byte) = %q, want ace", blob)
+	}
+}
+
+func TestExec_LastInsertId(t *testing.T) {
+	ctx := context.Background()
+	exec := newDatabase(t).Executor()
+	if _, err := exec.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	for want := int64(1); want <= 2; want++ {
+		result, err := exec.Exec(ctx, "INSERT INTO users (name) VALUES (?)", "user")
+		if err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+		if id, err := result.LastInsertId(); err != nil || id != want {
+			t.Errorf("LastInsertId() = %d, %v; want %d", id, err, want)
+		}
+	}
+}
+
+func TestBegin_Nested(t *testing.T) {
+	ctx := context.Background()
+	tx, err := newDatabase(t).Executor().Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Begin(ctx); err == nil {
+		t.Error("Begin inside a transaction succeeded")
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	ctx := context.Background()
+	exec := newDatabase(t).Executor()
+	if _, ok := exec.Unwrap().(*sqlx.DB); !ok {
+		t.Errorf("Unwrap() = %T, want *sqlx.DB", exec.Unwrap())
+	}
+
+	tx, err := exec.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, ok := tx.Unwrap().(*sqlx.Tx); !ok {
+		t.Errorf("transaction Unwrap() = %T, want *sqlx.Tx", tx.Unwrap())
+	}
+}
+
+func TestWithTransactionOptions(t *testing.T) {
+	db := newDatabase(t)
+	uow := hsqlx.NewSqlxUnitOfWork(db)
+	ctx := hyperion.New(context.Background(), hyperion.NewNoOpLogger(), db.Executor(),
+		hyperion.NewNoOpTracer(), hyperion.NewNoOpMeter())
+
+	opts := &hyperion.TransactionOptions{Isolation: hyperion.IsolationLevelSerializable}
+	err := uow.WithTransactionOptions(ctx, opts, func(txCtx hyperion.Context) error {
+		_, err := txCtx.DB().Exec(txCtx, "CREATE TABLE t (id INTEGER)")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithTransactionOptions failed: %v", err)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	db := newDatabase(t)
+	if err := db.Health(context.Background()); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := db.Health(context.Background()); err == nil {
+		t.Error("Health succeeded after Close")
+	}
+}
+
+func TestDatabaseLocker(t *testing.T) {
+	hyperiontest.RunLockerSuite(t, hyperiontest.LockerHarness{
+		New: func(t *testing.T) hyperion.Locker {
+			locker := hyperion.NewDatabaseLocker(newDatabase(t).Executor())
+			if err := locker.CreateTable(context.Background()); err != nil {
+				t.Fatalf("CreateTable failed: %v", err)
+			}
+			return locker
+		},
+	})
+}
@@ -0,0 +1,87 @@
+// Package sqlx provides a database/sql adapter for Hyperion built on
+// github.com/jmoiron/sqlx, as a lightweight alternative to adapter/gorm.
+//
+// This adapter implements hyperion.Database, hyperion.Executor, and
+// hyperion.UnitOfWork. It reads the same "database" configuration section
+// as adapter/gorm, so switching between the two is a one-line change to the
+// fx application.
+//
+// # Supported Databases
+//
+//   - PostgreSQL (via github.com/jackc/pgx/v5/stdlib)
+//   - MySQL (via github.com/go-sql-driver/mysql)
+//   - SQLite (via github.com/mattn/go-sqlite3, requires cgo)
+//
+// # Installation
+//
+//	app := fx.New(
+//	    hyperion.CoreModule,
+//	    viper.Module, // Provides Config
+//	    sqlx.Module,  // Provides Database and UnitOfWork
+//	    myapp.Module,
+//	)
+//
+// # Configuration
+//
+//	database:
+//	  driver: postgres
+//	  host: localhost
+//	  port: 5432
+//	  username: dbuser
+//	  password: dbpass
+//	  database: mydb
+//	  max_open_conns: 25
+//	  max_idle_conns: 5
+//	  conn_max_lifetime: 5m
+//	  conn_max_idle_time: 10m
+//
+// GORM-only settings (log_level, slow_threshold, prepare_stmt,
+// skip_default_transaction, auto_migrate) are accepted and ignored.
+//
+// # Queries
+//
+// Statements use "?" placeholders on every driver; they are rebound to
+// $1, $2, ... for PostgreSQL. Query scans into a pointer to a slice for
+// many rows, or into a pointer to a struct or scalar for the first row:
+//
+//	type User struct {
+//	    ID        int64
+//	    Email     string
+//	    CreatedAt time.Time
+//	}
+//
+//	var users []User
+//	err := ctx.DB().Query(ctx, &users, "SELECT id, email, created_at FROM users WHERE active = ?", true)
+//
+// Struct fields map to columns by their `db` tag, or by their snake_case
+// name as in GORM (UserID -> user_id). Unlike GORM, every selected column
+// must have a field.
+//
+// # Transactions
+//
+// UnitOfWork injects the transaction executor into the Context passed to
+// fn, so repositories using ctx.DB() join the transaction:
+//
+//	err := uow.WithTransaction(ctx, func(txCtx hyperion.Context) error {
+//	    if _, err := txCtx.DB().Exec(txCtx, "INSERT INTO users (email) VALUES (?)", email); err != nil {
+//	        return err // rolled back
+//	    }
+//	    return nil // committed
+//	})
+//
+// A call whose context already carries a transaction joins it; see
+// hyperion.Propagation for savepoints (PropagationNested) and independent
+// transactions (PropagationRequiresNew). hyperion.OnCommit and
+// hyperion.OnRollback callbacks run after the outermost commit or rollback.
+//
+// # Accessing sqlx
+//
+// Unwrap returns the *sqlx.DB, or the *sqlx.Tx inside a transaction:
+//
+//	switch conn := ctx.DB().Unwrap().(type) {
+//	case *sqlx.Tx:
+//	    rows, err = conn.NamedQuery(query, arg)
+//	case *sqlx.DB:
+//	    rows, err = conn.NamedQuery(query, arg)
+//	}
+package sqlx
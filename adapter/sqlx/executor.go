@@ -0,0 +1,198 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mapoio/hyperion"
+)
+
+// sqlxExecutor implements hyperion.Executor interface using sqlx.
+// It runs statements on the pool, or on tx when it represents a transaction.
+type sqlxExecutor struct {
+	db    *sqlx.DB
+	tx    *sqlx.Tx // nil unless this executor is a transaction
+	depth int      // Number of enclosing savepoints (PropagationNested)
+}
+
+// Ensure interface compliance at compile time.
+var _ hyperion.Executor = (*sqlxExecutor)(nil)
+
+// conn returns the transaction, or the pool outside a transaction.
+func (e *sqlxExecutor) conn() sqlx.ExtContext {
+	if e.tx != nil {
+		return e.tx
+	}
+	return e.db
+}
+
+// Exec executes a SQL statement without returning rows.
+// "?" placeholders are rebound to the driver's syntax ($1 for PostgreSQL).
+func (e *sqlxExecutor) Exec(ctx context.Context, query string, args ...any) (hyperion.Result, error) {
+	result, err := e.conn().ExecContext(ctx, e.db.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("exec failed: %w", err)
+	}
+	return result, nil
+}
+
+// Query executes a SQL query and scans the results into dest.
+//
+// A pointer to a slice receives every row; any other pointer (a struct or
+// a scalar) receives the first row and is left unchanged when there are
+// none, as with the GORM adapter. Struct fields map to columns by their
+// `db` tag, or by their snake_case name; every column must have a field.
+func (e *sqlxExecutor) Query(ctx context.Context, dest any, query string, args ...any) error {
+	query = e.db.Rebind(query)
+
+	var err error
+	if isSlicePointer(dest) {
+		err = sqlx.SelectContext(ctx, e.conn(), dest, query, args...)
+	} else {
+		err = sqlx.GetContext(ctx, e.conn(), dest, query, args...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+	return nil
+}
+
+// QueryRow scans the first row of a query into dest.
+// It returns an error wrapping hyperion.ErrNotFound if there are no rows.
+func (e *sqlxExecutor) QueryRow(ctx context.Context, dest any, query string, args ...any) error {
+	err := sqlx.GetContext(ctx, e.conn(), dest, e.db.Rebind(query), args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("query row: %w", hyperion.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+	return nil
+}
+
+// QueryIter executes a SQL query and returns a cursor over its rows.
+// The cursor holds a connection until it is closed.
+func (e *sqlxExecutor) QueryIter(ctx context.Context, query string, args ...any) (hyperion.Rows, error) {
+	rows, err := e.conn().QueryxContext(ctx, e.db.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	return &sqlxRows{rows: rows}, nil
+}
+
+// isSlicePointer reports whether dest points to a slice of rows.
+// []byte is a single scalar value.
+func isSlicePointer(dest any) bool {
+	t := reflect.TypeOf(dest)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return false
+	}
+	t = t.Elem()
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+// Begin starts a new transaction and returns a transaction executor.
+func (e *sqlxExecutor) Begin(ctx context.Context) (hyperion.Executor, error) {
+	return e.BeginTx(ctx, nil)
+}
+
+// BeginTx starts a new transaction with custom options.
+// Returns an error if this executor is already a transaction.
+func (e *sqlxExecutor) BeginTx(ctx context.Context, opts *sql.TxOptions) (hyperion.Executor, error) {
+	if e.tx != nil {
+		return nil, fmt.Errorf("begin transaction failed: already in a transaction")
+	}
+
+	tx, err := e.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	return &sqlxExecutor{db: e.db, tx: tx}, nil
+}
+
+// Commit commits the current transaction.
+// Returns an error if this executor is not a transaction.
+func (e *sqlxExecutor) Commit() error {
+	if e.tx == nil {
+		return fmt.Errorf("not in transaction: cannot commit non-transaction executor")
+	}
+
+	if err := e.tx.Commit(); err != nil {
+		return fmt.Errorf("commit failed: %w", err)
+	}
+
+	return nil
+}
+
+// Rollback rolls back the current transaction.
+// Returns an error if this executor is not a transaction.
+func (e *sqlxExecutor) Rollback() error {
+	if e.tx == nil {
+		return fmt.Errorf("not in transaction: cannot rollback non-transaction executor")
+	}
+
+	if err := e.tx.Rollback(); err != nil {
+		return fmt.Errorf("rollback failed: %w", err)
+	}
+
+	return nil
+}
+
+// Unwrap returns the underlying *sqlx.Tx inside a transaction, and the
+// *sqlx.DB otherwise.
+func (e *sqlxExecutor) Unwrap() any {
+	if e.tx != nil {
+		return e.tx
+	}
+	return e.db
+}
+
+// sqlxRows implements hyperion.Rows over *sqlx.Rows.
+type sqlxRows struct {
+	rows *sqlx.Rows
+}
+
+// Ensure interface compliance at compile time.
+var _ hyperion.Rows = (*sqlxRows)(nil)
+
+func (r *sqlxRows) Next() bool   { return r.rows.Next() }
+func (r *sqlxRows) Err() error   { return r.rows.Err() }
+func (r *sqlxRows) Close() error { return r.rows.Close() }
+
+// Scan copies the current row into dest: a struct by column mapping, or a
+// scalar (including time.Time and sql.Scanner types) directly.
+func (r *sqlxRows) Scan(dest any) error {
+	var err error
+	if isStructPointer(dest) {
+		err = r.rows.StructScan(dest)
+	} else {
+		err = r.rows.Scan(dest)
+	}
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+	return nil
+}
+
+// isStructPointer reports whether dest points to a struct scanned field by
+// field rather than as a single value.
+func isStructPointer(dest any) bool {
+	if _, ok := dest.(sql.Scanner); ok {
+		return false
+	}
+	t := reflect.TypeOf(dest)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return false
+	}
+	return t.Elem().Kind() == reflect.Struct && t.Elem() != reflect.TypeOf(time.Time{})
+}
@@ -0,0 +1,78 @@
+package sqlx
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/mapoio/hyperion"
+)
+
+// Module provides sqlx-based Database and UnitOfWork implementations.
+// It is a drop-in alternative to adapter/gorm's Module and reads the same
+// "database" configuration section.
+//
+// Usage:
+//
+//	fx.New(
+//	    hyperion.CoreModule,
+//	    viper.Module,  // Provides Config
+//	    sqlx.Module,   // Provides Database and UnitOfWork
+//	    myapp.Module,
+//	).Run()
+//
+// Configuration example (config.yaml):
+//
+//	database:
+//	  driver: postgres
+//	  host: localhost
+//	  port: 5432
+//	  username: dbuser
+//	  password: dbpass
+//	  database: mydb
+var Module = fx.Module("hyperion.adapter.sqlx",
+	fx.Provide(
+		fx.Annotate(
+			NewSqlxProvider,
+			fx.As(new(hyperion.Database)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			NewSqlxUnitOfWorkProvider,
+			fx.As(new(hyperion.UnitOfWork)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			func() hyperion.ConfigValidator { return ValidateConfig },
+			fx.ResultTags(`group:"hyperion.config_validators"`),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			ConfigSections,
+			fx.ResultTags(`group:"hyperion.config_sections,flatten"`),
+		),
+	),
+	fx.Invoke(registerLifecycle),
+)
+
+// NewSqlxProvider creates a sqlx database.
+func NewSqlxProvider(cfg hyperion.Config) (hyperion.Database, error) {
+	return NewSqlxDatabase(cfg)
+}
+
+// NewSqlxUnitOfWorkProvider creates a sqlx UnitOfWork.
+func NewSqlxUnitOfWorkProvider(db hyperion.Database) hyperion.UnitOfWork {
+	return NewSqlxUnitOfWork(db)
+}
+
+// registerLifecycle registers database lifecycle hooks with fx.
+func registerLifecycle(lc fx.Lifecycle, db hyperion.Database) {
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return db.Close()
+		},
+	})
+}
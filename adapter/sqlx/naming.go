@@ -0,0 +1,28 @@
+package sqlx
+
+import (
+	"strings"
+	"unicode"
+)
+
+// toSnakeCase converts a Go field name to a column name the way GORM's
+// default naming strategy does, keeping initialisms together:
+// "EmailAddress" -> "email_address", "UserID" -> "user_id",
+// "HTTPStatus" -> "http_status".
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
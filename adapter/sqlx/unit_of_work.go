@@ -0,0 +1,190 @@
+package sqlx
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mapoio/hyperion"
+)
+
+// sqlxUnitOfWork implements hyperion.UnitOfWork interface using sqlx.
+// It provides declarative transaction management with automatic commit/rollback.
+type sqlxUnitOfWork struct {
+	db *sqlx.DB
+}
+
+// Ensure interface compliance at compile time.
+var _ hyperion.UnitOfWork = (*sqlxUnitOfWork)(nil)
+
+// WithTransaction executes fn within a database transaction.
+// If fn returns an error or panics, the transaction is rolled back.
+// Otherwise, the transaction is committed.
+//
+// The Context passed to fn will have its DB() method return
+// the transaction executor instead of the default executor.
+//
+// Example:
+//
+//	err := uow.WithTransaction(ctx, func(txCtx hyperion.Context) error {
+//	    // All operations using txCtx.DB() will be part of the transaction
+//	    return userRepo.Create(txCtx, user)
+//	})
+func (u *sqlxUnitOfWork) WithTransaction(ctx hyperion.Context, fn func(txCtx hyperion.Context) error) error {
+	return u.WithTransactionOptions(ctx, nil, fn)
+}
+
+// WithTransactionOptions executes fn within a database transaction with custom options.
+// It supports setting isolation level, read-only mode and propagation.
+// A transaction already in ctx is recognized by its executor.
+func (u *sqlxUnitOfWork) WithTransactionOptions(
+	ctx hyperion.Context,
+	opts *hyperion.TransactionOptions,
+	fn func(txCtx hyperion.Context) error,
+) error {
+	var propagation hyperion.Propagation
+	if opts != nil {
+		propagation = opts.Propagation
+	}
+	current, inTx := ctx.DB().(*sqlxExecutor)
+	inTx = inTx && current.tx != nil
+
+	switch propagation {
+	case hyperion.PropagationRequired:
+		if inTx {
+			return fn(ctx)
+		}
+	case hyperion.PropagationRequiresNew:
+	case hyperion.PropagationNested:
+		if inTx {
+			return u.withSavepoint(ctx, current, fn)
+		}
+	case hyperion.PropagationMandatory:
+		if !inTx {
+			return hyperion.ErrNoTransaction
+		}
+		return fn(ctx)
+	case hyperion.PropagationNever:
+		if inTx {
+			return hyperion.ErrTransactionExists
+		}
+		return fn(ctx)
+	default:
+		return fmt.Errorf("unknown transaction propagation: %d", propagation)
+	}
+
+	return u.begin(ctx, opts, fn)
+}
+
+// begin executes fn in a new transaction.
+func (u *sqlxUnitOfWork) begin(
+	ctx hyperion.Context,
+	opts *hyperion.TransactionOptions,
+	fn func(txCtx hyperion.Context) error,
+) error {
+	var txOpts *sql.TxOptions
+	if opts != nil {
+		txOpts = &sql.TxOptions{
+			Isolation: toSQLIsolation(opts.Isolation),
+			ReadOnly:  opts.ReadOnly,
+		}
+	}
+
+	tx, err := u.db.BeginTxx(ctx, txOpts)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	// Collect OnCommit and OnRollback hooks, run once the outcome is known
+	hooksCtx, hooks := hyperion.WithTransactionHooks(ctx)
+
+	// Roll back and re-panic, like GORM's Transaction helper
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			hooks.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	// Inject transaction executor into context
+	txCtx := hyperion.WithDB(hooksCtx, &sqlxExecutor{db: u.db, tx: tx})
+
+	if err := fn(txCtx); err != nil {
+		rbErr := tx.Rollback()
+		hooks.Rollback(ctx)
+		if rbErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback failed: %w", rbErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		hooks.Rollback(ctx)
+		return fmt.Errorf("commit failed: %w", err)
+	}
+	hooks.Commit(ctx)
+	return nil
+}
+
+// withSavepoint executes fn under a savepoint of the transaction in current.
+// If fn returns an error or panics, only its changes are rolled back.
+func (u *sqlxUnitOfWork) withSavepoint(
+	ctx hyperion.Context,
+	current *sqlxExecutor,
+	fn func(txCtx hyperion.Context) error,
+) error {
+	// Savepoints are named by depth: siblings may reuse a name, but a
+	// savepoint inside another must not shadow it.
+	name := fmt.Sprintf("hyperion_sp_%d", current.depth+1)
+	if _, err := current.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("savepoint failed: %w", err)
+	}
+
+	// Roll back to the savepoint and re-panic
+	spCtx, hooks := hyperion.WithSavepointHooks(ctx)
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = current.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			hooks.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	nested := &sqlxExecutor{db: current.db, tx: current.tx, depth: current.depth + 1}
+	if err := fn(hyperion.WithDB(spCtx, nested)); err != nil {
+		_, rbErr := current.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+		hooks.Rollback(ctx)
+		if rbErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback to savepoint failed: %w", rbErr))
+		}
+		return err
+	}
+
+	// The savepoint's work now belongs to the enclosing transaction
+	hooks.Commit(ctx)
+	if _, err := current.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("release savepoint failed: %w", err)
+	}
+	return nil
+}
+
+// toSQLIsolation converts hyperion.IsolationLevel to sql.IsolationLevel.
+func toSQLIsolation(level hyperion.IsolationLevel) sql.IsolationLevel {
+	switch level {
+	case hyperion.IsolationLevelDefault:
+		return sql.LevelDefault
+	case hyperion.IsolationLevelReadUncommitted:
+		return sql.LevelReadUncommitted
+	case hyperion.IsolationLevelReadCommitted:
+		return sql.LevelReadCommitted
+	case hyperion.IsolationLevelRepeatableRead:
+		return sql.LevelRepeatableRead
+	case hyperion.IsolationLevelSerializable:
+		return sql.LevelSerializable
+	default:
+		return sql.LevelDefault
+	}
+}
@@ -0,0 +1,80 @@
+package viper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+	viperadapter "github.com/mapoio/hyperion/adapter/viper"
+)
+
+type serverConfig struct {
+	Host    string        `mapstructure:"host" default:"0.0.0.0"`
+	Port    int           `mapstructure:"port" default:"8080" validate:"min=1,max=65535"`
+	Timeout time.Duration `mapstructure:"timeout" default:"30s"`
+}
+
+func TestProviderTypedAccessors(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "server:\n  timeout: 1m\n  labels:\n    team: core\n")
+	provider, err := viperadapter.NewProvider(path)
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+
+	if got := hyperion.GetDuration(provider, "server.timeout"); got != time.Minute {
+		t.Errorf("GetDuration = %v, want 1m", got)
+	}
+	if got := hyperion.GetStringMap(provider, "server.labels"); got["team"] != "core" {
+		t.Errorf("GetStringMap = %v, want team=core", got)
+	}
+}
+
+func TestBindWithProvider(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "server:\n  port: 9090\n  timeout: 5s\n")
+	provider, err := viperadapter.NewProvider(path)
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+
+	got, err := hyperion.Bind[serverConfig](provider, "server")
+	if err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	want := serverConfig{Host: "0.0.0.0", Port: 9090, Timeout: 5 * time.Second}
+	if got != want {
+		t.Errorf("Bind = %+v, want %+v", got, want)
+	}
+}
+
+func TestWatchWithProvider(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "server:\n  port: 9090\n")
+	provider, err := viperadapter.NewProvider(path)
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+
+	live, err := hyperion.Watch[serverConfig](provider, "server")
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer live.Stop()
+
+	changed := make(chan serverConfig, 1)
+	live.OnChange(func(_, new serverConfig) {
+		changed <- new
+	})
+
+	replaceFile(t, path, "server:\n  port: 9191\n")
+
+	select {
+	case got := <-changed:
+		if got.Port != 9191 {
+			t.Errorf("changed Port = %d, want 9191", got.Port)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for OnChange")
+	}
+	if got := live.Load().Port; got != 9191 {
+		t.Errorf("Load().Port = %d, want 9191", got)
+	}
+}
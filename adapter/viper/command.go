@@ -0,0 +1,146 @@
+package viper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/fx"
+
+	"github.com/mapoio/hyperion"
+)
+
+// configUsage is printed for invalid "config" command lines.
+const configUsage = `usage:
+  config lint <file>...   check config files against the registered sections
+  config schema           print the JSON Schema of the registered sections
+`
+
+// RunConfigCommand runs the "config" command of a service binary against
+// the given sections and returns the process exit code. args are the
+// arguments after "config":
+//
+//	lint <file>...  reports unknown keys, type mismatches and validation
+//	                failures in each file; exits 1 if any error is found
+//	schema          prints the JSON Schema of the config file
+//
+// Files are linted as written: environment overrides are not applied and
+// ${...} placeholders are not resolved.
+//
+// Example, listing the sections by hand (see ConfigCommand to collect the
+// ones contributed by the fx modules instead):
+//
+//	if len(os.Args) > 1 && os.Args[1] == "config" {
+//	    sections := append(gorm.ConfigSections(), otel.ConfigSections()...)
+//	    os.Exit(viper.RunConfigCommand(os.Args[2:], sections, os.Stdout, os.Stderr))
+//	}
+func RunConfigCommand(args []string, sections []hyperion.ConfigSection, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, configUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "lint":
+		if len(args) < 2 {
+			fmt.Fprint(stderr, configUsage)
+			return 2
+		}
+		return lintFiles(args[1:], sections, stdout, stderr)
+	case "schema":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(hyperion.GenerateConfigSchema(sections)); err != nil {
+			fmt.Fprintf(stderr, "failed to write schema: %v\n", err)
+			return 1
+		}
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown config command %q\n%s", args[0], configUsage)
+		return 2
+	}
+}
+
+// ConfigCommand is the "config" command of a service binary, run against
+// the sections contributed to the "hyperion.config_sections" fx group.
+//
+// Module provides it. Building the app runs the constructors its invokes
+// need, but no lifecycle hooks, so the command can run in CI:
+//
+//	if len(os.Args) > 1 && os.Args[1] == "config" {
+//	    var cmd *viper.ConfigCommand
+//	    app := fx.New(fx.NopLogger, viper.Module, gorm.Module, myapp.Module, fx.Populate(&cmd))
+//	    if err := app.Err(); err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    os.Exit(cmd.Run(os.Args[2:], os.Stdout, os.Stderr))
+//	}
+type ConfigCommand struct {
+	sections []hyperion.ConfigSection
+}
+
+// NewConfigCommand creates a ConfigCommand for sections. It returns an
+// error if two sections share a key, e.g. when two logger adapters are
+// installed, since the file can only follow one of them.
+func NewConfigCommand(sections []hyperion.ConfigSection) (*ConfigCommand, error) {
+	seen := make(map[string]hyperion.ConfigSection, len(sections))
+	for _, section := range sections {
+		key := strings.ToLower(section.Key)
+		if prev, ok := seen[key]; ok {
+			return nil, fmt.Errorf("config section %q registered twice: %T and %T", key, prev.Type, section.Type)
+		}
+		seen[key] = section
+	}
+	return &ConfigCommand{sections: append([]hyperion.ConfigSection(nil), sections...)}, nil
+}
+
+// Sections returns the sections the command checks against.
+func (c *ConfigCommand) Sections() []hyperion.ConfigSection {
+	return append([]hyperion.ConfigSection(nil), c.sections...)
+}
+
+// Schema returns the JSON Schema of the sections, see
+// hyperion.GenerateConfigSchema.
+func (c *ConfigCommand) Schema() map[string]any {
+	return hyperion.GenerateConfigSchema(c.sections)
+}
+
+// Run is RunConfigCommand with the command's sections.
+func (c *ConfigCommand) Run(args []string, stdout, stderr io.Writer) int {
+	return RunConfigCommand(args, c.sections, stdout, stderr)
+}
+
+// configCommandParams holds the sections contributed to the
+// "hyperion.config_sections" group.
+type configCommandParams struct {
+	fx.In
+
+	Sections []hyperion.ConfigSection `group:"hyperion.config_sections"`
+}
+
+// newModuleConfigCommand adapts NewConfigCommand to fx.
+func newModuleConfigCommand(params configCommandParams) (*ConfigCommand, error) {
+	return NewConfigCommand(params.Sections)
+}
+
+// lintFiles lints each file and prints one line per issue.
+func lintFiles(files []string, sections []hyperion.ConfigSection, stdout, stderr io.Writer) int {
+	code := 0
+	for _, file := range files {
+		cfg, err := hyperion.NewLayeredConfig(FileSource(file))
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", file, err)
+			code = 1
+			continue
+		}
+
+		for _, issue := range hyperion.LintConfig(cfg, sections) {
+			fmt.Fprintf(stdout, "%s: %s\n", file, issue)
+			if issue.Severity == hyperion.LintError {
+				code = 1
+			}
+		}
+	}
+	return code
+}
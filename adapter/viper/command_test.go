@@ -0,0 +1,141 @@
+package viper_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.uber.org/fx"
+
+	"github.com/mapoio/hyperion"
+	viperadapter "github.com/mapoio/hyperion/adapter/viper"
+)
+
+type lintServerConfig struct {
+	Host string `mapstructure:"host" validate:"required"`
+	Port int    `mapstructure:"port" validate:"min=1,max=65535"`
+}
+
+var commandSections = []hyperion.ConfigSection{
+	{Key: "server", Type: lintServerConfig{}, Description: "HTTP server"},
+}
+
+func TestRunConfigCommand_Lint(t *testing.T) {
+	dir := t.TempDir()
+	good := writeFile(t, dir, "good.yaml", "server:\n  host: localhost\n  port: 8080\n")
+	bad := writeFile(t, dir, "bad.yaml", "server:\n  host: localhost\n  prot: 8080\n  port: 0\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := viperadapter.RunConfigCommand([]string{"lint", good}, commandSections, &stdout, &stderr); code != 0 {
+		t.Fatalf("lint good.yaml exit code = %d, want 0 (stdout %q, stderr %q)", code, stdout.String(), stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("lint good.yaml printed %q, want nothing", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := viperadapter.RunConfigCommand([]string{"lint", good, bad}, commandSections, &stdout, &stderr); code != 1 {
+		t.Fatalf("lint bad.yaml exit code = %d, want 1", code)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		bad + ": error: server.prot: unknown key, did you mean port?",
+		bad + ": error: server.port: value must be at least 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("lint output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, good) {
+		t.Errorf("lint output reports good.yaml:\n%s", out)
+	}
+}
+
+func TestRunConfigCommand_Schema(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := viperadapter.RunConfigCommand([]string{"schema"}, commandSections, &stdout, &stderr); code != 0 {
+		t.Fatalf("schema exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &schema); err != nil {
+		t.Fatalf("schema output is not JSON: %v", err)
+	}
+	props, _ := schema["properties"].(map[string]any)
+	if _, ok := props["server"]; !ok {
+		t.Errorf("schema properties = %v, want server", props)
+	}
+}
+
+func TestRunConfigCommand_Usage(t *testing.T) {
+	for _, args := range [][]string{nil, {"lint"}, {"dump"}} {
+		var stdout, stderr bytes.Buffer
+		if code := viperadapter.RunConfigCommand(args, commandSections, &stdout, &stderr); code != 2 {
+			t.Errorf("RunConfigCommand(%v) exit code = %d, want 2", args, code)
+		}
+		if !strings.Contains(stderr.String(), "usage:") {
+			t.Errorf("RunConfigCommand(%v) stderr = %q, want usage", args, stderr.String())
+		}
+	}
+}
+
+type lintLogConfig struct {
+	Level string `mapstructure:"level" validate:"oneof=debug info"`
+}
+
+// provideSections contributes sections to the "hyperion.config_sections" group.
+func provideSections(sections ...hyperion.ConfigSection) fx.Option {
+	return fx.Provide(
+		fx.Annotate(
+			func() []hyperion.ConfigSection { return sections },
+			fx.ResultTags(`group:"hyperion.config_sections,flatten"`),
+		),
+	)
+}
+
+func TestConfigCommand_Module(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.yaml", "server:\n  host: localhost\n  port: 8080\nlog:\n  level: verbose\n")
+
+	var cmd *viperadapter.ConfigCommand
+	app := fx.New(
+		fx.Supply(viperadapter.Options{ConfigFile: path}),
+		viperadapter.Module,
+		provideSections(commandSections...),
+		provideSections(hyperion.ConfigSection{Key: "log", Type: lintLogConfig{}}),
+		fx.Populate(&cmd),
+		fx.NopLogger,
+	)
+	if err := app.Err(); err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+	if got := len(cmd.Sections()); got != 2 {
+		t.Fatalf("collected %d sections, want 2", got)
+	}
+
+	props, _ := cmd.Schema()["properties"].(map[string]any)
+	for _, key := range []string{"server", "log"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema properties = %v, want %s", props, key)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := cmd.Run([]string{"lint", path}, &stdout, &stderr); code != 1 {
+		t.Fatalf("lint exit code = %d, want 1 (stdout %q, stderr %q)", code, stdout.String(), stderr.String())
+	}
+	if want := path + ": error: log.level: must be one of [debug info], got verbose"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("lint output missing %q:\n%s", want, stdout.String())
+	}
+}
+
+func TestNewConfigCommand_DuplicateKey(t *testing.T) {
+	sections := []hyperion.ConfigSection{
+		{Key: "log", Type: lintLogConfig{}},
+		{Key: "LOG", Type: lintServerConfig{}},
+	}
+	if _, err := viperadapter.NewConfigCommand(sections); err == nil || !strings.Contains(err.Error(), `"log" registered twice`) {
+		t.Errorf("NewConfigCommand() error = %v, want duplicate log section", err)
+	}
+}
@@ -1,6 +1,8 @@
 package viper
 
 import (
+	"os"
+
 	"go.uber.org/fx"
 
 	"github.com/mapoio/hyperion"
@@ -8,29 +10,89 @@ import (
 
 // Module provides Viper-based Config implementation.
 //
+// Options can be supplied through fx; without them DefaultOptions are used.
+// The --config flag and the HYPERION_CONFIG and HYPERION_PROFILE env vars
+// override the supplied options.
+//
+// Validators contributed to the "hyperion.config_validators" fx group guard
+// every reload, and if a hyperion.Meter is available the reload outcomes are
+// counted in hyperion.config.reloads.
+//
+// Resolvers contributed to the "hyperion.secret_resolvers" fx group add
+// placeholder schemes next to the built-in ${env:...} and ${file:...}.
+//
+// Sections contributed to the "hyperion.config_sections" fx group are
+// collected into a *ConfigCommand for linting and schema generation.
+//
 // Usage:
 //
 //	fx.New(
 //	    hyperion.CoreModule,
+//	    fx.Supply(viper.Options{EnvPrefix: "ORDERS"}), // Optional
 //	    viper.Module,  // Provides Config
 //	    myapp.Module,
 //	).Run()
 var Module = fx.Module("hyperion.adapter.viper",
 	fx.Provide(
 		fx.Annotate(
-			NewViperProvider,
+			newModuleProvider,
 			fx.As(new(hyperion.Config)),
 			fx.As(new(hyperion.ConfigWatcher)),
 		),
 	),
+	fx.Provide(newModuleConfigCommand),
+	fx.Invoke(registerReloadHooks),
 )
 
-// NewViperProvider creates a Viper config provider.
-func NewViperProvider() (hyperion.ConfigWatcher, error) {
-	configPath := "configs/config.yaml"
-	provider, err := NewProvider(configPath)
-	if err != nil {
-		return nil, err
+// moduleParams holds the optional fx inputs of Module.
+type moduleParams struct {
+	fx.In
+
+	Options   Options                   `optional:"true"`
+	Resolvers []hyperion.SecretResolver `group:"hyperion.secret_resolvers"`
+}
+
+// reloadParams holds the fx inputs used to guard config reloads.
+type reloadParams struct {
+	fx.In
+
+	Watcher    hyperion.ConfigWatcher
+	Meter      hyperion.Meter             `optional:"true"`
+	Validators []hyperion.ConfigValidator `group:"hyperion.config_validators"`
+}
+
+// registerReloadHooks registers the validators contributed to the
+// "hyperion.config_validators" group and the reload metric.
+func registerReloadHooks(params reloadParams) {
+	provider, ok := params.Watcher.(*Provider)
+	if !ok {
+		return
+	}
+	for _, validator := range params.Validators {
+		provider.AddValidator(validator)
+	}
+	if params.Meter != nil {
+		provider.SetMeter(params.Meter)
 	}
-	return provider, nil
+}
+
+// newModuleProvider adapts newViperProvider to fx.
+func newModuleProvider(params moduleParams) (hyperion.ConfigWatcher, error) {
+	opts := params.Options
+	opts.Resolvers = append(append([]hyperion.SecretResolver(nil), opts.Resolvers...), params.Resolvers...)
+	return newViperProvider(opts)
+}
+
+// NewViperProvider creates a Viper config provider with DefaultOptions.
+// It loads configs/config.yaml (or ./config.yaml) unless the --config flag
+// or the HYPERION_CONFIG env var names another file, and applies the
+// HYPERION_PROFILE overlay if set.
+func NewViperProvider() (hyperion.ConfigWatcher, error) {
+	return newViperProvider(Options{})
+}
+
+// newViperProvider creates a provider from opts after applying the
+// --config flag and HYPERION_* env var overrides.
+func newViperProvider(opts Options) (hyperion.ConfigWatcher, error) {
+	return NewProviderWithOptions(opts.withOverrides(os.Args[1:], os.LookupEnv))
 }
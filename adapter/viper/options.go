@@ -0,0 +1,195 @@
+package viper
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mapoio/hyperion"
+)
+
+// Environment variables that override Options when the provider is created
+// through Module or NewViperProvider.
+const (
+	// EnvConfigFile overrides Options.ConfigFile.
+	EnvConfigFile = "HYPERION_CONFIG"
+
+	// EnvProfile overrides Options.Profile.
+	EnvProfile = "HYPERION_PROFILE"
+)
+
+// configFlag is the command-line flag that overrides Options.ConfigFile.
+// It takes precedence over EnvConfigFile.
+const configFlag = "config"
+
+// supportedExts are the extensions tried, in order, when searching for ConfigName.
+var supportedExts = []string{"yaml", "yml", "json", "toml"}
+
+// Options controls how the provider locates, layers and overrides configuration.
+//
+// Layers are merged in order, later layers overriding earlier ones:
+//
+//  1. The base file: ConfigFile, else Files[0], else the first
+//     ConfigName.{yaml,yml,json,toml} found in SearchPaths.
+//  2. The profile file next to the base file, e.g. config.production.yaml
+//     for Profile "production", if it exists.
+//  3. Files[1:], each only if it exists (e.g. a git-ignored config.local.yaml).
+//
+// Environment variables with the EnvPrefix override all layers.
+// Placeholders such as ${file:/run/secrets/db} are then resolved; see
+// hyperion.Interpolator for the syntax.
+//
+// Example with fx:
+//
+//	fx.New(
+//	    fx.Supply(viper.Options{
+//	        EnvPrefix: "ORDERS",
+//	        Files:     []string{"configs/config.yaml", "configs/config.local.yaml"},
+//	    }),
+//	    viper.Module,
+//	)
+type Options struct {
+	// ConfigFile is an explicit base file. It replaces Files[0] and the search.
+	// Overridden by the --config flag and the HYPERION_CONFIG env var.
+	ConfigFile string
+
+	// ConfigName is the base file name, without extension, looked up in
+	// SearchPaths (default: "config").
+	ConfigName string
+
+	// EnvPrefix is the prefix for environment variable overrides (default: "APP").
+	// With prefix "APP", APP_DATABASE_HOST overrides "database.host".
+	EnvPrefix string
+
+	// Profile selects an overlay file named <base>.<profile>.<ext>.
+	// Overridden by the HYPERION_PROFILE env var.
+	Profile string
+
+	// SearchPaths are the directories searched for ConfigName, in order
+	// (default: "configs", ".").
+	SearchPaths []string
+
+	// Files lists layered config files merged in order. The first file is
+	// the base file and must exist; missing later files are skipped.
+	Files []string
+
+	// Resolvers add placeholder schemes to the built-in env and file
+	// resolvers, e.g. a Vault resolver for ${vault:secret/db#password}.
+	Resolvers []hyperion.SecretResolver
+}
+
+// DefaultOptions returns the options used when none are provided.
+// They locate configs/config.yaml (or ./config.yaml) with the "APP" env prefix.
+func DefaultOptions() Options {
+	return Options{
+		ConfigName:  "config",
+		EnvPrefix:   "APP",
+		SearchPaths: []string{"configs", "."},
+	}
+}
+
+// withDefaults fills unset fields from DefaultOptions.
+func (o Options) withDefaults() Options {
+	defaults := DefaultOptions()
+	if o.ConfigName == "" {
+		o.ConfigName = defaults.ConfigName
+	}
+	if o.EnvPrefix == "" {
+		o.EnvPrefix = defaults.EnvPrefix
+	}
+	if len(o.SearchPaths) == 0 {
+		o.SearchPaths = defaults.SearchPaths
+	}
+	return o
+}
+
+// withOverrides applies the --config flag found in args and the
+// HYPERION_CONFIG and HYPERION_PROFILE environment variables.
+func (o Options) withOverrides(args []string, lookupEnv func(string) (string, bool)) Options {
+	if profile, ok := lookupEnv(EnvProfile); ok && profile != "" {
+		o.Profile = profile
+	}
+	if path, ok := lookupEnv(EnvConfigFile); ok && path != "" {
+		o.ConfigFile = path
+	}
+	if path, ok := flagValue(args, configFlag); ok {
+		o.ConfigFile = path
+	}
+	return o
+}
+
+// layers resolves the config files to load, in merge order.
+func (o Options) layers() ([]string, error) {
+	base, err := o.baseFile()
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{base}
+	if o.Profile != "" {
+		ext := filepath.Ext(base)
+		if profileFile := strings.TrimSuffix(base, ext) + "." + o.Profile + ext; fileExists(profileFile) {
+			files = append(files, profileFile)
+		}
+	}
+	if len(o.Files) > 1 {
+		for _, f := range o.Files[1:] {
+			if fileExists(f) {
+				files = append(files, f)
+			}
+		}
+	}
+	return files, nil
+}
+
+// baseFile returns the first layer. Its existence is checked when it is read.
+func (o Options) baseFile() (string, error) {
+	if o.ConfigFile != "" {
+		return o.ConfigFile, nil
+	}
+	if len(o.Files) > 0 {
+		return o.Files[0], nil
+	}
+
+	for _, dir := range o.SearchPaths {
+		for _, ext := range supportedExts {
+			path := filepath.Join(dir, o.ConfigName+"."+ext)
+			if fileExists(path) {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("config file %q not found in %v", o.ConfigName, o.SearchPaths)
+}
+
+// flagValue returns the value of --name or -name in args, in either the
+// "--name value" or "--name=value" form. Parsing stops at "--".
+// Other flags are ignored, so the application keeps ownership of its flag set.
+func flagValue(args []string, name string) (string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		trimmed := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if trimmed == arg {
+			continue // not a flag
+		}
+		if value, ok := strings.CutPrefix(trimmed, name+"="); ok {
+			return value, true
+		}
+		if trimmed == name && i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	return "", false
+}
+
+// fileExists reports whether path exists and is not a directory.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return !info.IsDir()
+}
@@ -0,0 +1,197 @@
+package viper_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/mapoio/hyperion"
+	viperadapter "github.com/mapoio/hyperion/adapter/viper"
+)
+
+// writeFile writes content to dir/name and returns the path.
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// TestNewProviderWithOptions_Layers tests base, profile and local layers merged in order
+func TestNewProviderWithOptions_Layers(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFile(t, dir, "config.yaml", "app:\n  name: base\n  port: 80\n  debug: false\n")
+	writeFile(t, dir, "config.production.yaml", "app:\n  port: 443\n")
+	local := writeFile(t, dir, "config.local.yaml", "app:\n  debug: true\n")
+
+	provider, err := viperadapter.NewProviderWithOptions(viperadapter.Options{
+		Profile: "production",
+		Files:   []string{base, local, filepath.Join(dir, "missing.yaml")},
+	})
+	if err != nil {
+		t.Fatalf("NewProviderWithOptions failed: %v", err)
+	}
+
+	if got := provider.GetString("app.name"); got != "base" {
+		t.Errorf("app.name = %q, want base", got)
+	}
+	if got := provider.GetInt("app.port"); got != 443 {
+		t.Errorf("app.port = %d, want 443 from profile layer", got)
+	}
+	if !provider.GetBool("app.debug") {
+		t.Error("app.debug should be true from local layer")
+	}
+}
+
+// TestNewProviderWithOptions_MissingBase tests that the base file is required
+func TestNewProviderWithOptions_MissingBase(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := viperadapter.NewProviderWithOptions(viperadapter.Options{
+		Files: []string{filepath.Join(dir, "config.yaml")},
+	}); err == nil {
+		t.Error("Expected error for missing base file")
+	}
+	if _, err := viperadapter.NewProviderWithOptions(viperadapter.Options{
+		SearchPaths: []string{dir},
+	}); err == nil {
+		t.Error("Expected error when no config file is found in search paths")
+	}
+}
+
+// TestNewProviderWithOptions_SearchPaths tests config lookup by name and extension
+func TestNewProviderWithOptions_SearchPaths(t *testing.T) {
+	first := t.TempDir()
+	second := t.TempDir()
+	writeFile(t, second, "service.json", `{"app": {"name": "from-json"}}`)
+
+	provider, err := viperadapter.NewProviderWithOptions(viperadapter.Options{
+		ConfigName:  "service",
+		SearchPaths: []string{first, second},
+	})
+	if err != nil {
+		t.Fatalf("NewProviderWithOptions failed: %v", err)
+	}
+	if got := provider.GetString("app.name"); got != "from-json" {
+		t.Errorf("app.name = %q, want from-json", got)
+	}
+}
+
+// TestNewProviderWithOptions_EnvPrefix tests a custom environment variable prefix
+func TestNewProviderWithOptions_EnvPrefix(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "database:\n  host: localhost\n")
+	t.Setenv("ORDERS_DATABASE_HOST", "db.internal")
+
+	provider, err := viperadapter.NewProviderWithOptions(viperadapter.Options{
+		ConfigFile: path,
+		EnvPrefix:  "ORDERS",
+	})
+	if err != nil {
+		t.Fatalf("NewProviderWithOptions failed: %v", err)
+	}
+	if got := provider.GetString("database.host"); got != "db.internal" {
+		t.Errorf("database.host = %q, want db.internal", got)
+	}
+}
+
+// TestNewViperProvider_Overrides tests HYPERION_CONFIG, HYPERION_PROFILE and --config
+func TestNewViperProvider_Overrides(t *testing.T) {
+	dir := t.TempDir()
+	envPath := writeFile(t, dir, "env.yaml", "source: env\n")
+	writeFile(t, dir, "env.staging.yaml", "profile: staging\n")
+	flagPath := writeFile(t, dir, "flag.yaml", "source: flag\n")
+
+	t.Setenv(viperadapter.EnvConfigFile, envPath)
+	t.Setenv(viperadapter.EnvProfile, "staging")
+
+	provider, err := viperadapter.NewViperProvider()
+	if err != nil {
+		t.Fatalf("NewViperProvider failed: %v", err)
+	}
+	if got := provider.GetString("source"); got != "env" {
+		t.Errorf("source = %q, want env", got)
+	}
+	if got := provider.GetString("profile"); got != "staging" {
+		t.Errorf("profile = %q, want staging", got)
+	}
+
+	origArgs := os.Args
+	defer func() { os.Args = origArgs }()
+
+	for _, args := range [][]string{
+		{"--config", flagPath},
+		{"-v", "--config=" + flagPath},
+	} {
+		os.Args = append([]string{"app"}, args...)
+
+		provider, err := viperadapter.NewViperProvider()
+		if err != nil {
+			t.Fatalf("NewViperProvider(%v) failed: %v", args, err)
+		}
+		if got := provider.GetString("source"); got != "flag" {
+			t.Errorf("args %v: source = %q, want flag", args, got)
+		}
+	}
+}
+
+// TestModule_Options tests options supplied through fx
+func TestModule_Options(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "app.yaml", "app:\n  name: supplied\n")
+
+	var cfg hyperion.Config
+	app := fx.New(
+		fx.Supply(viperadapter.Options{ConfigFile: path}),
+		viperadapter.Module,
+		fx.Populate(&cfg),
+		fx.NopLogger,
+	)
+	if err := app.Err(); err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+	if got := cfg.GetString("app.name"); got != "supplied" {
+		t.Errorf("app.name = %q, want supplied", got)
+	}
+}
+
+// TestProviderWatch_Layers tests that a change to any layer reloads the merged config
+func TestProviderWatch_Layers(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFile(t, dir, "config.yaml", "a: 1\nb: 1\n")
+	overlay := writeFile(t, dir, "config.local.yaml", "b: 2\n")
+
+	provider, err := viperadapter.NewProviderWithOptions(viperadapter.Options{Files: []string{base, overlay}})
+	if err != nil {
+		t.Fatalf("NewProviderWithOptions failed: %v", err)
+	}
+
+	called := make(chan struct{}, 1)
+	stop, err := provider.Watch(func(hyperion.ChangeEvent) {
+		select {
+		case called <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer stop()
+
+	time.Sleep(100 * time.Millisecond)
+	writeFile(t, dir, "config.local.yaml", "b: 3\n")
+
+	select {
+	case <-called:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch callback was not invoked for overlay change")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if a, b := provider.GetInt("a"), provider.GetInt("b"); a != 1 || b != 3 {
+		t.Errorf("after reload a=%d b=%d, want a=1 b=3", a, b)
+	}
+}
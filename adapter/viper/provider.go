@@ -1,11 +1,15 @@
 package viper
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"path/filepath"
+	"os"
+	"reflect"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
@@ -17,15 +21,30 @@ import (
 // It supports multiple configuration formats (YAML, JSON, TOML) and
 // automatic environment variable override with hot reload capabilities.
 type Provider struct {
-	v          *viper.Viper                          // Viper instance
+	v          *viper.Viper                          // Viper instance, replaced on each accepted reload
 	watcher    *fsnotify.Watcher                     // File system watcher
+	reloads    hyperion.Counter                      // Reload outcomes, nil until SetMeter
 	callbacks  map[uint64]func(hyperion.ChangeEvent) // Registered callbacks
 	watchDone  chan struct{}                         // Signal to stop watching
-	configPath string                                // Original config file path
+	envPrefix  string                                // Environment variable prefix
+	files      []string                              // Layered config files, in merge order
+	validators []hyperion.ConfigValidator            // Run against each reload candidate
+	interp     *hyperion.Interpolator                // Resolves placeholders on load and reload
+	secrets    map[string]bool                       // Keys whose values were resolved from secrets
+	origins    map[string]string                     // Layer file that provided each key
 	mu         sync.RWMutex                          // Protects callbacks and viper access
 	nextCallID uint64                                // Atomic counter for callback IDs
 }
 
+// Ensure Provider implements hyperion.TypedConfig interface.
+var _ hyperion.TypedConfig = (*Provider)(nil)
+
+// Ensure Provider implements hyperion.SecretConfig interface.
+var _ hyperion.SecretConfig = (*Provider)(nil)
+
+// Ensure Provider implements hyperion.OriginConfig interface.
+var _ hyperion.OriginConfig = (*Provider)(nil)
+
 // NewProvider creates a new viper-based config provider from the given configuration file path.
 // It automatically detects the file format based on the file extension.
 //
@@ -36,29 +55,114 @@ type Provider struct {
 //
 // Returns an error if the configuration file cannot be read or parsed.
 func NewProvider(configPath string) (hyperion.ConfigWatcher, error) {
-	v := viper.New()
+	return NewProviderWithOptions(Options{ConfigFile: configPath})
+}
 
-	// Set config file path
-	v.SetConfigFile(configPath)
+// NewProviderWithOptions creates a new viper-based config provider that
+// loads the layered files described by opts. Unset fields take their
+// values from DefaultOptions.
+//
+// The --config flag and HYPERION_* env vars are not consulted here;
+// NewViperProvider applies them.
+//
+// Returns an error if no base file is found or a layer cannot be read or parsed.
+func NewProviderWithOptions(opts Options) (hyperion.ConfigWatcher, error) {
+	opts = opts.withDefaults()
 
-	// Enable automatic environment variable override
-	v.SetEnvPrefix("APP")
-	v.AutomaticEnv()
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	files, err := opts.layers()
+	if err != nil {
+		return nil, err
+	}
 
-	// Read config file
-	if err := v.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+	interp := hyperion.NewInterpolator(opts.Resolvers...)
+	loaded, err := loadViper(opts.EnvPrefix, files, interp)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Provider{
-		v:          v,
+		v:          loaded.v,
 		callbacks:  make(map[uint64]func(hyperion.ChangeEvent)),
 		nextCallID: 0,
-		configPath: configPath,
+		envPrefix:  opts.EnvPrefix,
+		files:      files,
+		interp:     interp,
+		secrets:    loaded.secrets,
+		origins:    loaded.origins,
 	}, nil
 }
 
+// loadedConfig is a viper instance with the metadata collected while loading it.
+type loadedConfig struct {
+	v       *viper.Viper
+	secrets map[string]bool   // Keys whose values were resolved from secrets
+	origins map[string]string // Layer file that provided each key
+}
+
+// loadViper creates a viper instance with environment overrides, reads
+// files into it and resolves placeholders.
+func loadViper(envPrefix string, files []string, interp *hyperion.Interpolator) (*loadedConfig, error) {
+	v := viper.New()
+
+	// Enable automatic environment variable override
+	v.SetEnvPrefix(envPrefix)
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	// Read config files
+	origins, err := readLayers(v, files)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := resolvePlaceholders(v, interp)
+	if err != nil {
+		return nil, err
+	}
+	return &loadedConfig{v: v, secrets: secrets, origins: origins}, nil
+}
+
+// resolvePlaceholders expands placeholders in every value of v, including
+// values overridden by environment variables.
+func resolvePlaceholders(v *viper.Viper, interp *hyperion.Interpolator) (map[string]bool, error) {
+	secrets := make(map[string]bool)
+	for _, key := range v.AllKeys() {
+		value := v.Get(key)
+		expanded, secret, err := interp.ExpandValue(context.Background(), value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", key, err)
+		}
+		if secret {
+			secrets[key] = true
+		}
+		if !reflect.DeepEqual(expanded, value) {
+			v.Set(key, expanded)
+		}
+	}
+	return secrets, nil
+}
+
+// readLayers reads files into v, merging each file over the previous ones.
+// It returns the file that provided each key, as "file:<path>".
+func readLayers(v *viper.Viper, files []string) (map[string]string, error) {
+	origins := make(map[string]string)
+	for _, file := range files {
+		// Each layer is parsed on its own so its keys can be attributed
+		layer := viper.New()
+		layer.SetConfigFile(file)
+		if err := layer.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", file, err)
+		}
+		for _, key := range layer.AllKeys() {
+			origins[key] = "file:" + file
+		}
+		if err := v.MergeConfigMap(layer.AllSettings()); err != nil {
+			return nil, fmt.Errorf("failed to merge config file %s: %w", file, err)
+		}
+	}
+	return origins, nil
+}
+
 // Unmarshal unmarshals the configuration at the given key into the provided struct.
 func (p *Provider) Unmarshal(key string, rawVal any) error {
 	p.mu.RLock()
@@ -122,6 +226,22 @@ func (p *Provider) GetStringSlice(key string) []string {
 	return p.v.GetStringSlice(key)
 }
 
+// GetDuration returns the value for the given key as a time.Duration.
+// This implements the hyperion.TypedConfig interface.
+func (p *Provider) GetDuration(key string) time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.v.GetDuration(key)
+}
+
+// GetStringMap returns the value for the given key as a map.
+// This implements the hyperion.TypedConfig interface.
+func (p *Provider) GetStringMap(key string) map[string]any {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.v.GetStringMap(key)
+}
+
 // IsSet checks if the key is set in the configuration.
 func (p *Provider) IsSet(key string) bool {
 	p.mu.RLock()
@@ -136,12 +256,57 @@ func (p *Provider) AllKeys() []string {
 	return p.v.AllKeys()
 }
 
+// Origin returns where the value at key came from: "env" for an
+// environment variable override, "file:<path>" for the layer file that
+// provided it, "default" for other values, or "" if the key is not set.
+// This implements the hyperion.OriginConfig interface.
+func (p *Provider) Origin(key string) string {
+	key = strings.ToLower(key)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if _, ok := os.LookupEnv(envVarName(p.envPrefix, key)); ok {
+		return "env"
+	}
+	if origin, ok := p.origins[key]; ok {
+		return origin
+	}
+	if p.v.IsSet(key) {
+		return "default"
+	}
+	return ""
+}
+
+// envVarName returns the environment variable that overrides key.
+func envVarName(envPrefix, key string) string {
+	name := strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	if envPrefix == "" {
+		return name
+	}
+	return strings.ToUpper(envPrefix) + "_" + name
+}
+
+// IsSecret reports whether the value at key was resolved from a secret
+// placeholder such as ${file:/run/secrets/db}.
+// This implements the hyperion.SecretConfig interface.
+func (p *Provider) IsSecret(key string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.secrets[strings.ToLower(key)]
+}
+
 // Watch starts watching for configuration file changes and triggers callbacks.
-// It uses fsnotify to watch the configuration file directly.
+// It uses fsnotify to watch every layered configuration file directly.
+//
+// After each reload the settings are compared with the previous ones, and the
+// callback is invoked once per added, modified or removed key, with the old
+// and the new value. A reload that changes nothing invokes no callback.
 //
-// The callback is invoked whenever the configuration file is modified.
-// Note: The ChangeEvent.Key will contain the filename and Value will be nil for
-// file-based watches. Use the Provider methods to read the updated configuration values.
+// A reload whose files cannot be parsed, or that fails a validator registered
+// with AddValidator, is rejected: the previous configuration stays in effect
+// and the callback receives a single event of type hyperion.ChangeRejected
+// with the reason in Err.
 //
 // Multiple callbacks can be registered by calling Watch multiple times.
 // Each callback is assigned a unique ID to ensure safe removal even in
@@ -167,14 +332,16 @@ func (p *Provider) Watch(callback func(event hyperion.ChangeEvent)) (stop func()
 			return nil, fmt.Errorf("failed to create watcher: %w", err)
 		}
 
-		// Add the config file to the watcher
-		if err := watcher.Add(p.configPath); err != nil {
-			if closeErr := watcher.Close(); closeErr != nil {
-				// Log close error but prioritize returning the original error
-				fmt.Printf("failed to close watcher: %v\n", closeErr)
+		// Add every config layer to the watcher
+		for _, file := range p.files {
+			if err := watcher.Add(file); err != nil {
+				if closeErr := watcher.Close(); closeErr != nil {
+					// Log close error but prioritize returning the original error
+					fmt.Printf("failed to close watcher: %v\n", closeErr)
+				}
+				delete(p.callbacks, callbackID)
+				return nil, fmt.Errorf("failed to watch config file: %w", err)
 			}
-			delete(p.callbacks, callbackID)
-			return nil, fmt.Errorf("failed to watch config file: %w", err)
 		}
 
 		p.watcher = watcher
@@ -204,6 +371,13 @@ func (p *Provider) Watch(callback func(event hyperion.ChangeEvent)) (stop func()
 	}, nil
 }
 
+// WatchKey is like Watch, but only invokes callback for changes to prefix
+// and the keys nested below it, e.g. "log" for "log.level" and "log.output".
+// ChangeRejected events are delivered regardless of prefix.
+func (p *Provider) WatchKey(prefix string, callback func(event hyperion.ChangeEvent)) (stop func(), err error) {
+	return hyperion.WatchKey(p, prefix, callback)
+}
+
 // watchLoop handles file system events and reloads configuration.
 // It runs in a separate goroutine and terminates when watchDone is closed.
 func (p *Provider) watchLoop() {
@@ -256,12 +430,12 @@ func (p *Provider) handleFileEvent(event fsnotify.Event) {
 		p.mu.Lock()
 		if p.watcher != nil {
 			// Remove the old watch (if it exists) - ignore error as path may not exist
-			if err := p.watcher.Remove(p.configPath); err != nil {
+			if err := p.watcher.Remove(event.Name); err != nil {
 				// Log but continue - the path may have already been removed
 				fmt.Printf("note: failed to remove old watch (expected after rename): %v\n", err)
 			}
 			// Re-add watch to the config path (which now points to the new file)
-			if err := p.watcher.Add(p.configPath); err != nil {
+			if err := p.watcher.Add(event.Name); err != nil {
 				fmt.Printf("failed to re-add watch after rename: %v\n", err)
 				p.mu.Unlock()
 				return
@@ -270,18 +444,39 @@ func (p *Provider) handleFileEvent(event fsnotify.Event) {
 		p.mu.Unlock()
 	}
 
-	// Reload configuration with write lock
-	p.mu.Lock()
-	if err := p.v.ReadInConfig(); err != nil {
-		fmt.Printf("failed to reload config: %v\n", err)
-		p.mu.Unlock()
-		return
+	p.reload()
+}
+
+// reload loads the layered files into a candidate, validates it and, if it
+// is accepted, swaps it in and notifies callbacks of each changed key.
+// A rejected candidate leaves the running configuration untouched.
+func (p *Provider) reload() {
+	p.mu.RLock()
+	envPrefix, files, interp := p.envPrefix, p.files, p.interp
+	validators := append([]hyperion.ConfigValidator(nil), p.validators...)
+	p.mu.RUnlock()
+
+	// Load and validate outside the lock; readers keep the current config
+	candidate, err := loadViper(envPrefix, files, interp)
+	if err != nil {
+		err = fmt.Errorf("failed to load candidate config: %w", err)
+	} else {
+		err = validateCandidate(&Provider{v: candidate.v, secrets: candidate.secrets}, validators)
 	}
 
-	// Create change event
-	changeEvent := hyperion.ChangeEvent{
-		Key:   filepath.Base(event.Name),
-		Value: nil,
+	p.mu.Lock()
+	var changes []hyperion.ChangeEvent
+	if err != nil {
+		fmt.Printf("rejected config reload: %v\n", err)
+		changes = []hyperion.ChangeEvent{{Type: hyperion.ChangeRejected, Err: err}}
+		p.recordReload("rejected")
+	} else {
+		// One change event per added, modified or removed key
+		changes = hyperion.DiffSettings(snapshot(p.v), snapshot(candidate.v))
+		p.v = candidate.v
+		p.secrets = candidate.secrets
+		p.origins = candidate.origins
+		p.recordReload("applied")
 	}
 
 	// Snapshot callbacks while holding lock
@@ -292,7 +487,60 @@ func (p *Provider) handleFileEvent(event fsnotify.Event) {
 	p.mu.Unlock()
 
 	// Execute callbacks outside lock
-	for _, cb := range callbacks {
-		cb(changeEvent)
+	for _, changeEvent := range changes {
+		for _, cb := range callbacks {
+			cb(changeEvent)
+		}
+	}
+}
+
+// validateCandidate runs every validator against candidate and joins their errors.
+func validateCandidate(candidate hyperion.Config, validators []hyperion.ConfigValidator) error {
+	var errs []error
+	for _, validate := range validators {
+		if err := validate(candidate); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("candidate config failed validation: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// AddValidator registers a validator that every reload candidate must pass.
+// Validators are not run against the configuration loaded at startup;
+// adapters validate it when they are constructed.
+func (p *Provider) AddValidator(validator hyperion.ConfigValidator) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.validators = append(p.validators, validator)
+}
+
+// SetMeter enables the hyperion.config.reloads counter, which records each
+// reload with a "result" attribute of "applied" or "rejected".
+func (p *Provider) SetMeter(meter hyperion.Meter) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reloads = meter.Counter("hyperion.config.reloads",
+		hyperion.WithMetricDescription("Configuration reloads by result"),
+		hyperion.WithMetricUnit("1"),
+	)
+}
+
+// recordReload counts a reload outcome. The caller must hold p.mu.
+func (p *Provider) recordReload(result string) {
+	if p.reloads != nil {
+		p.reloads.Add(context.Background(), 1, hyperion.String("result", result))
+	}
+}
+
+// snapshot returns the current value of every key in v.
+func snapshot(v *viper.Viper) map[string]any {
+	keys := v.AllKeys()
+	settings := make(map[string]any, len(keys))
+	for _, key := range keys {
+		settings[key] = v.Get(key)
 	}
+	return settings
 }
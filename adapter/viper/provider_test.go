@@ -230,9 +230,9 @@ app:
 	// Wait for callback
 	select {
 	case event := <-eventChan:
-		// Callback was invoked
-		if event.Key != "config.yaml" {
-			t.Errorf("Event key = %v, want config.yaml", event.Key)
+		// Callback was invoked with the changed key
+		if event.Key != "app.name" {
+			t.Errorf("Event key = %v, want app.name", event.Key)
 		}
 	case <-time.After(2 * time.Second):
 		t.Error("Watch callback was not invoked")
@@ -0,0 +1,170 @@
+package viper_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/mapoio/hyperion"
+	viperadapter "github.com/mapoio/hyperion/adapter/viper"
+)
+
+// recordingMeter counts additions to hyperion.config.reloads by result.
+type recordingMeter struct {
+	hyperion.Meter
+	mu      sync.Mutex
+	results map[string]int64
+}
+
+func newRecordingMeter() *recordingMeter {
+	return &recordingMeter{Meter: hyperion.NewNoOpMeter(), results: make(map[string]int64)}
+}
+
+func (m *recordingMeter) Counter(name string, opts ...hyperion.MetricOption) hyperion.Counter {
+	return m
+}
+
+func (m *recordingMeter) Add(_ context.Context, value int64, attrs ...hyperion.Attribute) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, attr := range attrs {
+		if attr.Key == "result" {
+			m.results[attr.Value.(string)] += value
+		}
+	}
+}
+
+func (m *recordingMeter) count(result string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.results[result]
+}
+
+// requirePort rejects candidates without a positive server.port.
+func requirePort(cfg hyperion.Config) error {
+	if cfg.GetInt("server.port") <= 0 {
+		return errors.New("server.port must be positive")
+	}
+	return nil
+}
+
+// watchEvents registers a callback that forwards events to a channel.
+func watchEvents(t *testing.T, provider hyperion.ConfigWatcher) <-chan hyperion.ChangeEvent {
+	t.Helper()
+	events := make(chan hyperion.ChangeEvent, 16)
+	stop, err := provider.Watch(func(event hyperion.ChangeEvent) {
+		events <- event
+	})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	t.Cleanup(stop)
+	time.Sleep(100 * time.Millisecond)
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan hyperion.ChangeEvent) hyperion.ChangeEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change event received")
+		return hyperion.ChangeEvent{}
+	}
+}
+
+// TestProviderReload_Validation tests that invalid candidates are rejected and valid ones applied
+func TestProviderReload_Validation(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("server:\n  port: 8080\n"), 0o644); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	watcher, err := viperadapter.NewProvider(configPath)
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+	provider := watcher.(*viperadapter.Provider)
+	provider.AddValidator(requirePort)
+	meter := newRecordingMeter()
+	provider.SetMeter(meter)
+
+	events := watchEvents(t, provider)
+
+	// Invalid value: rejected, previous value kept
+	replaceFile(t, configPath, "server:\n  port: -1\n")
+	event := nextEvent(t, events)
+	if event.Type != hyperion.ChangeRejected || event.Err == nil {
+		t.Fatalf("event = %+v, want rejected with error", event)
+	}
+	if got := provider.GetInt("server.port"); got != 8080 {
+		t.Errorf("server.port = %d after rejected reload, want 8080", got)
+	}
+
+	// Broken YAML: rejected, previous value kept
+	replaceFile(t, configPath, "server:\n  port: [8081\n")
+	if event := nextEvent(t, events); event.Type != hyperion.ChangeRejected {
+		t.Fatalf("event = %+v, want rejected for unparsable file", event)
+	}
+	if got := provider.GetInt("server.port"); got != 8080 {
+		t.Errorf("server.port = %d after broken file, want 8080", got)
+	}
+
+	// Valid value: applied
+	replaceFile(t, configPath, "server:\n  port: 9090\n")
+	event = nextEvent(t, events)
+	if event.Type != hyperion.ChangeModified || event.OldValue != 8080 || event.Value != 9090 {
+		t.Errorf("event = %+v, want server.port modified 8080 -> 9090", event)
+	}
+
+	if rejected, applied := meter.count("rejected"), meter.count("applied"); rejected != 2 || applied != 1 {
+		t.Errorf("reload metric rejected=%d applied=%d, want 2 and 1", rejected, applied)
+	}
+}
+
+// TestModule_Validators tests validators and meter registration through fx
+func TestModule_Validators(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("server:\n  port: 8080\n"), 0o644); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	meter := newRecordingMeter()
+	var watcher hyperion.ConfigWatcher
+	app := fx.New(
+		fx.Supply(viperadapter.Options{ConfigFile: configPath}),
+		viperadapter.Module,
+		fx.Provide(func() hyperion.Meter { return meter }),
+		fx.Provide(
+			fx.Annotate(
+				func() hyperion.ConfigValidator { return requirePort },
+				fx.ResultTags(`group:"hyperion.config_validators"`),
+			),
+		),
+		fx.Populate(&watcher),
+		fx.NopLogger,
+	)
+	if err := app.Err(); err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+
+	events := watchEvents(t, watcher)
+	replaceFile(t, configPath, "server:\n  port: 0\n")
+
+	if event := nextEvent(t, events); event.Type != hyperion.ChangeRejected {
+		t.Fatalf("event = %+v, want rejected", event)
+	}
+	if got := watcher.GetInt("server.port"); got != 8080 {
+		t.Errorf("server.port = %d, want 8080", got)
+	}
+	if got := meter.count("rejected"); got != 1 {
+		t.Errorf("rejected reloads = %d, want 1", got)
+	}
+}
@@ -0,0 +1,132 @@
+package viper_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mapoio/hyperion"
+	viperadapter "github.com/mapoio/hyperion/adapter/viper"
+)
+
+func TestProviderPlaceholders(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := writeFile(t, dir, "db_password", "file-secret\n")
+	t.Setenv("HYPERION_TEST_DB_HOST", "db.internal")
+
+	path := writeFile(t, dir, "config.yaml", `
+database:
+  host: ${HYPERION_TEST_DB_HOST}
+  port: ${HYPERION_TEST_DB_PORT:-5432}
+  password: ${file:`+filepath.ToSlash(secretFile)+`}
+  token: ${mem:token}
+  hosts:
+    - ${HYPERION_TEST_DB_HOST}
+    - replica
+`)
+
+	cfg, err := viperadapter.NewProviderWithOptions(viperadapter.Options{
+		ConfigFile: path,
+		Resolvers:  []hyperion.SecretResolver{hyperion.NewMemoryResolver("mem", map[string]string{"token": "t0k3n"})},
+	})
+	if err != nil {
+		t.Fatalf("NewProviderWithOptions failed: %v", err)
+	}
+
+	for key, want := range map[string]string{
+		"database.host":     "db.internal",
+		"database.port":     "5432",
+		"database.password": "file-secret",
+		"database.token":    "t0k3n",
+	} {
+		if got := cfg.GetString(key); got != want {
+			t.Errorf("GetString(%q) = %q, want %q", key, got, want)
+		}
+	}
+	if got := cfg.GetStringSlice("database.hosts"); len(got) != 2 || got[0] != "db.internal" {
+		t.Errorf("GetStringSlice(hosts) = %v", got)
+	}
+
+	var db struct {
+		Password string `mapstructure:"password"`
+	}
+	if err := cfg.Unmarshal("database", &db); err != nil || db.Password != "file-secret" {
+		t.Errorf("Unmarshal password = %q, %v", db.Password, err)
+	}
+
+	for key, want := range map[string]bool{
+		"database.host":     false,
+		"database.password": true,
+		"database.token":    true,
+		"Database.Token":    true,
+	} {
+		if got := hyperion.IsSecret(cfg, key); got != want {
+			t.Errorf("IsSecret(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestProviderPlaceholders_Unresolved(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "database:\n  password: ${file:/nonexistent/secret}\n")
+
+	_, err := viperadapter.NewProvider(path)
+	if err == nil || !strings.Contains(err.Error(), "database.password") {
+		t.Errorf("NewProvider error = %v, want unresolved database.password", err)
+	}
+}
+
+func TestProviderPlaceholders_Reload(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.yaml", "database:\n  password: ${mem:db}\n")
+	secrets := hyperion.NewMemoryResolver("mem", map[string]string{"db": "v1"})
+
+	cfg, err := viperadapter.NewProviderWithOptions(viperadapter.Options{
+		ConfigFile: path,
+		Resolvers:  []hyperion.SecretResolver{secrets},
+	})
+	if err != nil {
+		t.Fatalf("NewProviderWithOptions failed: %v", err)
+	}
+	events := watchEvents(t, cfg.(*viperadapter.Provider))
+
+	// Secrets are resolved again on reload
+	secrets.Set("db", "v2")
+	replaceFile(t, path, "database:\n  password: ${mem:db}\n  pool: 10\n")
+
+	for {
+		event := nextEvent(t, events)
+		if event.Key == "database.password" {
+			break
+		}
+	}
+	if got := cfg.GetString("database.password"); got != "v2" {
+		t.Errorf("password after reload = %q, want v2", got)
+	}
+}
+
+func TestProviderOrigin(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFile(t, dir, "config.yaml", "database:\n  host: localhost\n  port: 5432\n  password: ${mem:db}\n")
+	local := writeFile(t, dir, "config.local.yaml", "database:\n  port: 6432\n")
+	t.Setenv("ORIGIN_DATABASE_HOST", "db.internal")
+
+	cfg, err := viperadapter.NewProviderWithOptions(viperadapter.Options{
+		Files:     []string{base, local},
+		EnvPrefix: "ORIGIN",
+		Resolvers: []hyperion.SecretResolver{hyperion.NewMemoryResolver("mem", map[string]string{"db": "pw"})},
+	})
+	if err != nil {
+		t.Fatalf("NewProviderWithOptions failed: %v", err)
+	}
+
+	want := []hyperion.ConfigEntry{
+		{Key: "database.host", Value: "db.internal", Source: "env"},
+		{Key: "database.password", Value: hyperion.SecretMask, Source: "file:" + base, Secret: true},
+		{Key: "database.port", Value: 6432, Source: "file:" + local},
+	}
+	got := hyperion.NewConfigInspector(cfg).Entries()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+package viper
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/mapoio/hyperion"
+)
+
+// FileSource returns a hyperion.WatchableSource that reads a YAML, JSON or
+// TOML file, for use with hyperion.NewLayeredConfig. The format is detected
+// from the file extension.
+//
+// The file's directory is watched, so atomic replacements (write to a temp
+// file, then rename) are reported like in-place writes.
+func FileSource(path string) hyperion.WatchableSource {
+	return &fileSource{path: filepath.Clean(path)}
+}
+
+type fileSource struct {
+	path string
+}
+
+func (s *fileSource) Name() string { return "file:" + s.path }
+
+func (s *fileSource) Load(context.Context) (map[string]any, error) {
+	v := viper.New()
+	v.SetConfigFile(s.path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", s.path, err)
+	}
+	return v.AllSettings(), nil
+}
+
+func (s *fileSource) Watch(onChange func()) (stop func(), err error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(s.path)); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", s.path, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != s.path {
+					continue
+				}
+				// A removal is the first half of an atomic replace; the
+				// following create reports the new content.
+				if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					onChange()
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		_ = watcher.Close()
+		<-done
+	}, nil
+}
+
+// FlagSource returns a hyperion.ConfigSource serving the command-line flags
+// in fs that were set explicitly, for use with hyperion.NewLayeredConfig.
+// Flag defaults are not provided, so they never override lower sources.
+//
+// The flag name is the config key: --server.port=9090 sets "server.port".
+// Slice flags provide their elements as a list.
+func FlagSource(fs *pflag.FlagSet) hyperion.ConfigSource {
+	return &flagSource{fs: fs}
+}
+
+type flagSource struct {
+	fs *pflag.FlagSet
+}
+
+func (s *flagSource) Name() string { return "flags" }
+
+func (s *flagSource) Load(context.Context) (map[string]any, error) {
+	settings := make(map[string]any)
+	s.fs.Visit(func(f *pflag.Flag) {
+		key := strings.ToLower(f.Name)
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			settings[key] = sv.GetSlice()
+			return
+		}
+		settings[key] = f.Value.String()
+	})
+	return settings, nil
+}
@@ -0,0 +1,78 @@
+package viper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/mapoio/hyperion"
+	viperadapter "github.com/mapoio/hyperion/adapter/viper"
+)
+
+func TestLayeredConfig_FileAndFlags(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "server:\n  port: 8080\n  hosts: [a]\nlog:\n  level: info\n")
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("log.level", "error", "")
+	fs.Int("server.port", 1, "")
+	fs.StringSlice("server.hosts", nil, "")
+	if err := fs.Parse([]string{"--server.port=9090", "--server.hosts=x,y"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := hyperion.NewLayeredConfig(viperadapter.FileSource(path), viperadapter.FlagSource(fs))
+	if err != nil {
+		t.Fatalf("NewLayeredConfig failed: %v", err)
+	}
+
+	if got := cfg.GetInt("server.port"); got != 9090 {
+		t.Errorf("server.port = %d, want 9090", got)
+	}
+	if got := cfg.Origin("server.port"); got != "flags" {
+		t.Errorf("Origin(server.port) = %q, want flags", got)
+	}
+	// Unset flags keep the file value rather than the flag default
+	if got := cfg.GetString("log.level"); got != "info" {
+		t.Errorf("log.level = %q, want info", got)
+	}
+	if got := cfg.Origin("log.level"); got != "file:"+path {
+		t.Errorf("Origin(log.level) = %q, want file:%s", got, path)
+	}
+	if got := cfg.GetStringSlice("server.hosts"); len(got) != 2 || got[1] != "y" {
+		t.Errorf("server.hosts = %v, want [x y]", got)
+	}
+}
+
+func TestFileSource_Watch(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "app:\n  name: v1\n")
+
+	cfg, err := hyperion.NewLayeredConfig(viperadapter.FileSource(path))
+	if err != nil {
+		t.Fatalf("NewLayeredConfig failed: %v", err)
+	}
+
+	events := make(chan hyperion.ChangeEvent, 16)
+	stop, err := cfg.Watch(func(event hyperion.ChangeEvent) { events <- event })
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer stop()
+
+	replaceFile(t, path, "app:\n  name: v2\n")
+
+	select {
+	case event := <-events:
+		if event.Key != "app.name" || event.Value != "v2" {
+			t.Errorf("event = %+v, want app.name=v2", event)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for change event")
+	}
+}
+
+func TestFileSource_Missing(t *testing.T) {
+	if _, err := hyperion.NewLayeredConfig(viperadapter.FileSource("/nonexistent/config.yaml")); err == nil {
+		t.Error("NewLayeredConfig with a missing file should fail")
+	}
+}
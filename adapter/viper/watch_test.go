@@ -0,0 +1,133 @@
+package viper_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+	viperadapter "github.com/mapoio/hyperion/adapter/viper"
+)
+
+// replaceFile atomically replaces path with content, so the watcher never
+// observes a truncated file.
+func replaceFile(t *testing.T, path, content string) {
+	t.Helper()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatalf("Failed to rename: %v", err)
+	}
+}
+
+// eventRecorder collects change events delivered to a callback.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events map[string]hyperion.ChangeEvent
+}
+
+func (r *eventRecorder) record(event hyperion.ChangeEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[event.Key] = event
+}
+
+// waitFor waits until an event for key has been recorded.
+func (r *eventRecorder) waitFor(t *testing.T, key string) hyperion.ChangeEvent {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		event, ok := r.events[key]
+		r.mu.Unlock()
+		if ok {
+			return event
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no change event for %q", key)
+	return hyperion.ChangeEvent{}
+}
+
+func (r *eventRecorder) keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make([]string, 0, len(r.events))
+	for key := range r.events {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// TestProviderWatch_KeyDiffs tests one event per changed key with old and new values
+func TestProviderWatch_KeyDiffs(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("log:\n  level: info\n  output: stdout\ndatabase:\n  host: a\n"), 0o644); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	provider, err := viperadapter.NewProvider(configPath)
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+
+	all := &eventRecorder{events: make(map[string]hyperion.ChangeEvent)}
+	stop, err := provider.Watch(all.record)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer stop()
+
+	time.Sleep(100 * time.Millisecond)
+	replaceFile(t, configPath, "log:\n  level: debug\n  output: stdout\ndatabase:\n  port: 5432\n")
+
+	if e := all.waitFor(t, "log.level"); e.Type != hyperion.ChangeModified || e.OldValue != "info" || e.Value != "debug" {
+		t.Errorf("log.level event = %+v, want modified info -> debug", e)
+	}
+	if e := all.waitFor(t, "database.port"); e.Type != hyperion.ChangeAdded || e.OldValue != nil {
+		t.Errorf("database.port event = %+v, want added", e)
+	}
+	if e := all.waitFor(t, "database.host"); e.Type != hyperion.ChangeRemoved || e.OldValue != "a" || e.Value != nil {
+		t.Errorf("database.host event = %+v, want removed", e)
+	}
+	for _, key := range all.keys() {
+		if key == "log.output" {
+			t.Error("unchanged key log.output should not produce an event")
+		}
+	}
+}
+
+// TestProviderWatchKey tests subscribing to a subtree
+func TestProviderWatchKey(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("log:\n  level: info\nlogger:\n  name: a\ndatabase:\n  host: a\n"), 0o644); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	provider, err := viperadapter.NewProvider(configPath)
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+
+	logEvents := &eventRecorder{events: make(map[string]hyperion.ChangeEvent)}
+	stop, err := provider.(*viperadapter.Provider).WatchKey("log", logEvents.record)
+	if err != nil {
+		t.Fatalf("WatchKey failed: %v", err)
+	}
+	defer stop()
+
+	time.Sleep(100 * time.Millisecond)
+	replaceFile(t, configPath, "log:\n  level: warn\nlogger:\n  name: b\ndatabase:\n  host: b\n")
+
+	logEvents.waitFor(t, "log.level")
+	time.Sleep(100 * time.Millisecond)
+	for _, key := range logEvents.keys() {
+		if key != "log.level" {
+			t.Errorf("WatchKey(\"log\") received event for %q", key)
+		}
+	}
+}
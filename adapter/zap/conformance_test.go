@@ -0,0 +1,36 @@
+package zap
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/hyperiontest"
+)
+
+func TestConformance(t *testing.T) {
+	hyperiontest.RunLoggerSuite(t, hyperiontest.LoggerHarness{
+		New: func(w io.Writer, level hyperion.LogLevel) hyperion.Logger {
+			atom := zap.NewAtomicLevelAt(toZapLevel(level))
+			encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+				TimeKey:     "ts",
+				LevelKey:    "level",
+				MessageKey:  "msg",
+				EncodeLevel: zapcore.LowercaseLevelEncoder,
+				EncodeTime:  zapcore.ISO8601TimeEncoder,
+			})
+			return newZapLogger(zapcore.NewCore(encoder, zapcore.AddSync(w), atom), atom)
+		},
+		TraceContext: func() (context.Context, string, string) {
+			traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+			spanID := trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+			ctx := trace.ContextWithSpan(context.Background(), &mockSpan{traceID: traceID, spanID: spanID})
+			return ctx, traceID.String(), spanID.String()
+		},
+	})
+}
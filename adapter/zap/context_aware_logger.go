@@ -42,16 +42,26 @@ func (c *contextAwareLogger) Warn(msg string, fields ...any) {
 
 // Error logs an error message with trace context automatically injected.
 func (c *contextAwareLogger) Error(msg string, fields ...any) {
-	zapFields := convertToZapFields(fields...)
+	zapFields := withErrorStacks(convertToZapFields(fields...), c.zapLogger.errorKeys, 1)
 	c.zapLogger.contextLogger.ErrorContext(c.stdCtx, msg, zapFields...)
 }
 
 // Fatal logs a fatal message with trace context automatically injected and exits.
 func (c *contextAwareLogger) Fatal(msg string, fields ...any) {
-	zapFields := convertToZapFields(fields...)
+	zapFields := withErrorStacks(convertToZapFields(fields...), c.zapLogger.errorKeys, 1)
 	c.zapLogger.contextLogger.FatalContext(c.stdCtx, msg, zapFields...)
 }
 
+// LogFields logs a message with typed fields and trace context automatically injected.
+// This implements the hyperion.FieldLogger interface.
+func (c *contextAwareLogger) LogFields(level hyperion.LogLevel, msg string, fields ...hyperion.Field) {
+	zapFields := toZapFields(fields)
+	if level >= hyperion.ErrorLevel {
+		zapFields = withErrorStacks(zapFields, c.zapLogger.errorKeys, 2) // skip [LogFields, hyperion.LogFields]
+	}
+	c.zapLogger.contextLogger.LogContext(c.stdCtx, toZapLevel(level), msg, zapFields...)
+}
+
 // With creates a child logger with additional fields.
 func (c *contextAwareLogger) With(fields ...any) hyperion.Logger {
 	childLogger, ok := c.zapLogger.With(fields...).(*zapLogger)
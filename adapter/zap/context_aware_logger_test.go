@@ -59,7 +59,6 @@ func TestContextAwareLogger_AutomaticTraceInjection(t *testing.T) {
 	zapCore := zap.New(otelCore)
 
 	logger := &zapLogger{
-		sugar:         zapCore.Sugar(),
 		atom:          zap.NewAtomicLevelAt(zapcore.InfoLevel),
 		core:          zapCore,
 		contextLogger: newContextLogger(zapCore),
@@ -135,7 +134,6 @@ func TestContextAwareLogger_NoTraceContext(t *testing.T) {
 	zapCore := zap.New(otelCore)
 
 	logger := &zapLogger{
-		sugar:         zapCore.Sugar(),
 		atom:          zap.NewAtomicLevelAt(zapcore.InfoLevel),
 		core:          zapCore,
 		contextLogger: newContextLogger(zapCore),
@@ -195,7 +193,6 @@ func TestContextAwareLogger_WithMethod(t *testing.T) {
 	zapCore := zap.New(otelCore)
 
 	logger := &zapLogger{
-		sugar:         zapCore.Sugar(),
 		atom:          zap.NewAtomicLevelAt(zapcore.InfoLevel),
 		core:          zapCore,
 		contextLogger: newContextLogger(zapCore),
@@ -296,7 +293,6 @@ func TestContextAwareLogger_AllLogLevels(t *testing.T) {
 	zapCore := zap.New(otelCore)
 
 	logger := &zapLogger{
-		sugar:         zapCore.Sugar(),
 		atom:          zap.NewAtomicLevelAt(zapcore.DebugLevel),
 		core:          zapCore,
 		contextLogger: newContextLogger(zapCore),
@@ -414,7 +410,6 @@ func TestOtelCore_DisabledLevel(t *testing.T) {
 	zapCore := zap.New(otelCore)
 
 	logger := &zapLogger{
-		sugar:         zapCore.Sugar(),
 		atom:          zap.NewAtomicLevelAt(zapcore.WarnLevel),
 		core:          zapCore,
 		contextLogger: newContextLogger(zapCore),
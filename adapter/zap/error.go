@@ -0,0 +1,113 @@
+package zap
+
+import (
+	"slices"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/mapoio/hyperion"
+)
+
+// errorFields returns the structured fields logged for err under key:
+//
+//	key        the error message
+//	key.type   the Go type of err
+//	key.chain  the wrapped errors (errors.Unwrap and errors.Join trees), if any
+//	key.stack  the stack trace carried by err, if any
+//
+// Errors without a stack get one captured at the log site for Error level
+// and above, including errors added with With or WithError, see
+// withErrorStacks.
+func errorFields(key string, err error) []zap.Field {
+	if err == nil {
+		return []zap.Field{zap.NamedError(key, nil)}
+	}
+
+	detail := hyperion.DescribeError(err)
+
+	fields := make([]zap.Field, 0, 4)
+	fields = append(fields,
+		zap.NamedError(key, err),
+		zap.String(key+".type", detail.Type),
+	)
+	if len(detail.Causes) > 0 {
+		fields = append(fields, zap.Array(key+".chain", errorChain(detail.Causes)))
+	}
+	if detail.Stack != "" {
+		fields = append(fields, zap.String(key+".stack", detail.Stack))
+	}
+	return fields
+}
+
+// withErrorStacks adds a key.stack field, captured at the log site, for every
+// error field whose error does not carry a stack of its own, and for each of
+// inherited, the keys of such error fields added to the logger with With.
+// skip is the number of frames above the caller of withErrorStacks to omit.
+func withErrorStacks(fields []zap.Field, inherited []string, skip int) []zap.Field {
+	var stack string
+	addStack := func(key string) {
+		if stack == "" {
+			stack = hyperion.FormatStack(hyperion.CaptureStack(skip + 2)) // skip [addStack, withErrorStacks]
+		}
+		fields = append(fields, zap.String(key+".stack", stack))
+	}
+
+	n := len(fields)
+	for _, f := range fields[:n] {
+		if f.Type == zapcore.ErrorType && stackless(f) {
+			addStack(f.Key)
+		}
+	}
+	for _, key := range inherited {
+		// A field of the call replaces the inherited one
+		if !slices.ContainsFunc(fields[:n], func(f zap.Field) bool { return f.Key == key }) {
+			addStack(key)
+		}
+	}
+	return fields
+}
+
+// stacklessErrorKeys returns the keys of the error fields whose error does
+// not carry a stack, for a child logger to add one when it logs an error.
+func stacklessErrorKeys(fields []zap.Field) []string {
+	var keys []string
+	for _, f := range fields {
+		if f.Type == zapcore.ErrorType && stackless(f) {
+			keys = append(keys, f.Key)
+		}
+	}
+	return keys
+}
+
+// stackless reports whether the error field f holds an error without a stack.
+func stackless(f zap.Field) bool {
+	err, ok := f.Interface.(error)
+	return ok && hyperion.ErrorStack(err) == nil
+}
+
+// errorChain marshals wrapped errors as a JSON array.
+type errorChain []hyperion.ErrorDetail
+
+// MarshalLogArray implements zapcore.ArrayMarshaler.
+func (c errorChain) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for i := range c {
+		if err := enc.AppendObject(errorObject(c[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errorObject marshals a single wrapped error and its own causes.
+type errorObject hyperion.ErrorDetail
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (o errorObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", o.Message)
+	enc.AddString("type", o.Type)
+	if len(o.Causes) > 0 {
+		return enc.AddArray("causes", errorChain(o.Causes))
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package zap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/mapoio/hyperion"
+)
+
+// newBufferLogger creates a JSON zapLogger writing to buf.
+func newBufferLogger(buf *bytes.Buffer) *zapLogger {
+	atom := zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	return newZapLogger(zapcore.NewCore(encoder, zapcore.AddSync(buf), atom), atom)
+}
+
+func decodeEntry(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to decode %q: %v", buf.String(), err)
+	}
+	buf.Reset()
+	return entry
+}
+
+func TestErrorFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferLogger(&buf)
+
+	pathErr := &fs.PathError{Op: "open", Path: "/etc/app.yaml", Err: fs.ErrNotExist}
+	err := errors.Join(fmt.Errorf("load config: %w", pathErr), errors.New("fallback failed"))
+
+	logger.Error("startup failed", "error", err)
+	entry := decodeEntry(t, &buf)
+
+	if entry["error"] != err.Error() {
+		t.Errorf("error = %v, want message %q", entry["error"], err.Error())
+	}
+	if entry["error.type"] != "*errors.joinError" {
+		t.Errorf("error.type = %v", entry["error.type"])
+	}
+
+	chain, ok := entry["error.chain"].([]any)
+	if !ok || len(chain) != 2 {
+		t.Fatalf("error.chain = %v, want two branches", entry["error.chain"])
+	}
+	first := chain[0].(map[string]any)
+	causes := first["causes"].([]any)
+	if got := causes[0].(map[string]any)["type"]; got != "*fs.PathError" {
+		t.Errorf("nested cause type = %v, want *fs.PathError", got)
+	}
+
+	stack, _ := entry["error.stack"].(string)
+	if !strings.Contains(stack, "TestErrorFields") {
+		t.Errorf("error.stack should be captured at the log site, got:\n%s", stack)
+	}
+	if strings.Contains(stack, "withErrorStacks") || strings.HasPrefix(stack, "github.com/mapoio/hyperion/adapter/zap.(*zapLogger)") {
+		t.Errorf("error.stack should start at the caller, got:\n%s", stack)
+	}
+}
+
+func TestErrorFields_StackFromError(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferLogger(&buf)
+
+	err := newStackError()
+	hyperion.LogFields(logger.WithContext(t.Context()), hyperion.ErrorLevel, "query failed", hyperion.F.Err(err))
+	entry := decodeEntry(t, &buf)
+
+	stack, _ := entry["error.stack"].(string)
+	if !strings.HasPrefix(stack, "github.com/mapoio/hyperion/adapter/zap.newStackError") {
+		t.Errorf("error.stack should come from the error, got:\n%s", stack)
+	}
+	if entry["error.type"] != "*errors.errorString" {
+		t.Errorf("error.type = %v, want the wrapped type", entry["error.type"])
+	}
+}
+
+func TestErrorFields_BelowErrorLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferLogger(&buf)
+
+	logger.WithError(errors.New("retrying")).Warn("transient failure")
+	entry := decodeEntry(t, &buf)
+
+	if entry["error"] != "retrying" || entry["error.type"] != "*errors.errorString" {
+		t.Errorf("unexpected error fields: %v", entry)
+	}
+	if _, ok := entry["error.stack"]; ok {
+		t.Error("error.stack should not be captured below error level")
+	}
+	if _, ok := entry["error.chain"]; ok {
+		t.Error("error.chain should be omitted for errors without causes")
+	}
+}
+
+func TestErrorFields_WithError(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferLogger(&buf)
+
+	loggers := map[string]hyperion.Logger{
+		"WithError":            logger.WithError(errors.New("boom")),
+		"With":                 logger.With("cause", errors.New("boom")).With("user", 1),
+		"WithContext/With":     logger.WithContext(t.Context()).With("cause", errors.New("boom")),
+		"WithError/WithFields": logger.WithError(errors.New("boom")).With("user", 1),
+	}
+	for name, child := range loggers {
+		child.Error("request failed")
+		entry := decodeEntry(t, &buf)
+
+		key := "error"
+		if _, ok := entry["cause"]; ok {
+			key = "cause"
+		}
+		stack, _ := entry[key+".stack"].(string)
+		if !strings.HasPrefix(stack, "github.com/mapoio/hyperion/adapter/zap.TestErrorFields_WithError") {
+			t.Errorf("%s: %s.stack should be captured at the log site, got:\n%s", name, key, stack)
+		}
+	}
+
+	// An error carrying its own stack keeps it
+	logger.WithError(newStackError()).Error("request failed")
+	entry := decodeEntry(t, &buf)
+	if stack, _ := entry["error.stack"].(string); !strings.HasPrefix(stack, "github.com/mapoio/hyperion/adapter/zap.newStackError") {
+		t.Errorf("error.stack should come from the error, got:\n%s", stack)
+	}
+}
+
+func newStackError() error {
+	return hyperion.WithStack(errors.New("boom"))
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"slices"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -15,10 +18,11 @@ import (
 
 // zapLogger implements hyperion.Logger interface using Zap.
 type zapLogger struct {
-	sugar         *zap.SugaredLogger
 	atom          zap.AtomicLevel
 	core          *zap.Logger
+	fieldCore     *zap.Logger    // core with one extra caller frame for hyperion.LogFields
 	contextLogger *contextLogger // Context-aware logger for trace correlation
+	errorKeys     []string       // With error fields without a stack, see withErrorStacks
 }
 
 // Ensure zapLogger implements hyperion.Logger interface.
@@ -27,14 +31,17 @@ var _ hyperion.Logger = (*zapLogger)(nil)
 // Ensure zapLogger implements hyperion.ContextAwareLogger interface.
 var _ hyperion.ContextAwareLogger = (*zapLogger)(nil)
 
+// Ensure zapLogger implements hyperion.FieldLogger interface.
+var _ hyperion.FieldLogger = (*zapLogger)(nil)
+
 // Config holds configuration for Zap logger.
 // Fields are ordered for optimal memory alignment.
 type Config struct {
-	OtlpConfig *OtlpLogConfig `mapstructure:"otlp"`     // OTLP logs export configuration (8 bytes pointer)
-	FileConfig *FileConfig    `mapstructure:"file"`     // File rotation configuration (8 bytes pointer)
-	Level      string         `mapstructure:"level"`    // Log level: debug, info, warn, error, fatal (16 bytes)
-	Encoding   string         `mapstructure:"encoding"` // Encoding format: json or console (16 bytes)
-	Output     string         `mapstructure:"output"`   // Output destination: stdout, stderr, or file path (16 bytes)
+	OtlpConfig *OtlpLogConfig `mapstructure:"otlp"`                                                                           // OTLP logs export configuration (8 bytes pointer)
+	FileConfig *FileConfig    `mapstructure:"file"`                                                                           // File rotation configuration (8 bytes pointer)
+	Level      string         `mapstructure:"level" default:"info" validate:"oneof=debug info warn error dpanic panic fatal"` // Log level: debug, info, warn, error, fatal (16 bytes)
+	Encoding   string         `mapstructure:"encoding" default:"json" validate:"oneof=json console"`                          // Encoding format: json or console (16 bytes)
+	Output     string         `mapstructure:"output" default:"stdout"`                                                        // Output destination: stdout, stderr, or file path (16 bytes)
 }
 
 // OtlpLogConfig holds OTLP logs export configuration.
@@ -169,54 +176,83 @@ func NewZapLogger(cfg hyperion.Config) (hyperion.Logger, error) {
 		atom,
 	)
 
+	return newZapLogger(core, atom), nil
+}
+
+// newZapLogger builds a zapLogger around core, whose level must be controlled by atom.
+func newZapLogger(core zapcore.Core, atom zap.AtomicLevel) *zapLogger {
 	// Wrap core with OTel bridge for automatic trace context injection
 	otelCore := newOtelCore(core)
 
 	// Create logger with OTel-wrapped core
 	zapCore := zap.New(otelCore, zap.AddCaller(), zap.AddCallerSkip(1))
 
+	return newZapLoggerFromCore(zapCore, atom)
+}
+
+// newZapLoggerFromCore wraps an already configured *zap.Logger.
+func newZapLoggerFromCore(core *zap.Logger, atom zap.AtomicLevel) *zapLogger {
 	return &zapLogger{
-		sugar:         zapCore.Sugar(),
 		atom:          atom,
-		core:          zapCore,
-		contextLogger: newContextLogger(zapCore),
-	}, nil
+		core:          core,
+		fieldCore:     core.WithOptions(zap.AddCallerSkip(1)),
+		contextLogger: newContextLogger(core),
+	}
 }
 
 // Debug logs a debug message with optional fields.
 func (l *zapLogger) Debug(msg string, fields ...any) {
-	l.sugar.Debugw(msg, fields...)
+	if ce := l.core.Check(zapcore.DebugLevel, msg); ce != nil {
+		ce.Write(convertToZapFields(fields...)...)
+	}
 }
 
 // Info logs an info message with optional fields.
 func (l *zapLogger) Info(msg string, fields ...any) {
-	l.sugar.Infow(msg, fields...)
+	if ce := l.core.Check(zapcore.InfoLevel, msg); ce != nil {
+		ce.Write(convertToZapFields(fields...)...)
+	}
 }
 
 // Warn logs a warning message with optional fields.
 func (l *zapLogger) Warn(msg string, fields ...any) {
-	l.sugar.Warnw(msg, fields...)
+	if ce := l.core.Check(zapcore.WarnLevel, msg); ce != nil {
+		ce.Write(convertToZapFields(fields...)...)
+	}
 }
 
 // Error logs an error message with optional fields.
 func (l *zapLogger) Error(msg string, fields ...any) {
-	l.sugar.Errorw(msg, fields...)
+	if ce := l.core.Check(zapcore.ErrorLevel, msg); ce != nil {
+		ce.Write(withErrorStacks(convertToZapFields(fields...), l.errorKeys, 1)...)
+	}
 }
 
 // Fatal logs a fatal message with optional fields and exits the process.
 func (l *zapLogger) Fatal(msg string, fields ...any) {
-	l.sugar.Fatalw(msg, fields...)
+	if ce := l.core.Check(zapcore.FatalLevel, msg); ce != nil {
+		ce.Write(withErrorStacks(convertToZapFields(fields...), l.errorKeys, 1)...)
+	}
+}
+
+// LogFields logs a message with typed fields mapped directly to zap.Field.
+// This implements the hyperion.FieldLogger interface.
+func (l *zapLogger) LogFields(level hyperion.LogLevel, msg string, fields ...hyperion.Field) {
+	if ce := l.fieldCore.Check(toZapLevel(level), msg); ce != nil {
+		zapFields := toZapFields(fields)
+		if level >= hyperion.ErrorLevel {
+			zapFields = withErrorStacks(zapFields, l.errorKeys, 2) // skip [LogFields, hyperion.LogFields]
+		}
+		ce.Write(zapFields...)
+	}
 }
 
 // With creates a child logger with additional fields.
 func (l *zapLogger) With(fields ...any) hyperion.Logger {
-	childCore := l.core.With(convertToZapFields(fields)...)
-	return &zapLogger{
-		sugar:         l.sugar.With(fields...),
-		atom:          l.atom,
-		core:          childCore,
-		contextLogger: newContextLogger(childCore),
-	}
+	zapFields := convertToZapFields(fields...)
+	child := newZapLoggerFromCore(l.core.With(zapFields...), l.atom)
+	child.errorKeys = append(slices.Clip(l.errorKeys), stacklessErrorKeys(zapFields)...)
+	return child
 }
 
 // WithError creates a child logger with an error field.
@@ -281,19 +317,89 @@ func fromZapLevel(level zapcore.Level) hyperion.LogLevel {
 }
 
 // convertToZapFields converts variadic fields to zap.Field slice.
-// This helper handles the conversion from sugared fields to structured fields.
+//
+// Strings are taken as keys for the following value. hyperion.Field and
+// zap.Field values stand on their own. Any other value, and a trailing key
+// without a value, is logged under hyperion.BadKey instead of being dropped.
+// Errors are expanded into structured fields, see errorFields.
 func convertToZapFields(fields ...any) []zap.Field {
 	if len(fields) == 0 {
 		return nil
 	}
 
-	zapFields := make([]zap.Field, 0, len(fields)/2)
-	for i := 0; i < len(fields)-1; i += 2 {
-		key, ok := fields[i].(string)
-		if !ok {
-			continue
+	zapFields := make([]zap.Field, 0, len(fields)/2+1)
+	for i := 0; i < len(fields); {
+		switch x := fields[i].(type) {
+		case hyperion.Field:
+			zapFields = appendZapField(zapFields, x)
+			i++
+		case zap.Field:
+			zapFields = append(zapFields, x)
+			i++
+		case string:
+			if i+1 >= len(fields) {
+				zapFields = append(zapFields, zap.String(hyperion.BadKey, x))
+				i++
+				continue
+			}
+			if err, ok := fields[i+1].(error); ok {
+				zapFields = append(zapFields, errorFields(x, err)...)
+			} else {
+				zapFields = append(zapFields, zap.Any(x, fields[i+1]))
+			}
+			i += 2
+		default:
+			zapFields = append(zapFields, zap.Any(hyperion.BadKey, x))
+			i++
 		}
-		zapFields = append(zapFields, zap.Any(key, fields[i+1]))
 	}
 	return zapFields
 }
+
+// toZapFields converts typed hyperion fields to zap fields.
+func toZapFields(fields []hyperion.Field) []zap.Field {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	zapFields := make([]zap.Field, 0, len(fields))
+	for _, f := range fields {
+		zapFields = appendZapField(zapFields, f)
+	}
+	return zapFields
+}
+
+// appendZapField appends the zap fields for f to dst.
+// Error fields expand to several fields, see errorFields.
+func appendZapField(dst []zap.Field, f hyperion.Field) []zap.Field {
+	if f.Type == hyperion.ErrorType {
+		err, _ := f.Interface.(error)
+		return append(dst, errorFields(f.Key, err)...)
+	}
+	return append(dst, toZapField(f))
+}
+
+// toZapField converts a typed hyperion field to the equivalent zap.Field
+// without boxing its value.
+func toZapField(f hyperion.Field) zap.Field {
+	switch f.Type {
+	case hyperion.StringType:
+		return zap.String(f.Key, f.String)
+	case hyperion.Int64Type:
+		return zap.Int64(f.Key, f.Integer)
+	case hyperion.Float64Type:
+		return zap.Float64(f.Key, math.Float64frombits(uint64(f.Integer)))
+	case hyperion.BoolType:
+		return zap.Bool(f.Key, f.Integer == 1)
+	case hyperion.DurationType:
+		return zap.Duration(f.Key, time.Duration(f.Integer))
+	case hyperion.TimeType:
+		// Same layout as zap.Time: Unix nanoseconds plus *time.Location
+		return zap.Field{Key: f.Key, Type: zapcore.TimeType, Integer: f.Integer, Interface: f.Interface}
+	case hyperion.ErrorType:
+		err, _ := f.Interface.(error)
+		return zap.NamedError(f.Key, err)
+	default:
+		return zap.Any(f.Key, f.Interface)
+	}
+}
@@ -266,6 +266,21 @@ func TestZapLogger_With(t *testing.T) {
 	}
 }
 
+func TestZapLogger_WithFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferLogger(&buf)
+
+	logger.With("k", "v").With("n", 1).Info("hello")
+	entry := decodeEntry(t, &buf)
+
+	if entry["k"] != "v" {
+		t.Errorf("k = %v, want v (entry %v)", entry["k"], entry)
+	}
+	if entry["n"] != float64(1) {
+		t.Errorf("n = %v, want 1 (entry %v)", entry["n"], entry)
+	}
+}
+
 func TestZapLogger_WithError(t *testing.T) {
 	logger, err := NewZapLogger(nil)
 	if err != nil {
@@ -23,8 +23,24 @@ var Module = fx.Module("hyperion.adapter.zap",
 			fx.As(new(hyperion.Logger)),
 		),
 	),
+	fx.Provide(
+		fx.Annotate(
+			ConfigSections,
+			fx.ResultTags(`group:"hyperion.config_sections,flatten"`),
+		),
+	),
 )
 
+// ConfigSections describes the "log" section for JSON Schema generation
+// and linting. Module contributes it to the "hyperion.config_sections" group.
+func ConfigSections() []hyperion.ConfigSection {
+	return []hyperion.ConfigSection{{
+		Key:         "log",
+		Type:        Config{},
+		Description: "Logging (adapter/zap)",
+	}}
+}
+
 // NewZapProvider creates a Zap logger.
 func NewZapProvider(cfg hyperion.Config) (hyperion.Logger, error) {
 	return NewZapLogger(cfg)
@@ -117,3 +117,12 @@ func (c *contextLogger) FatalContext(ctx context.Context, msg string, fields ...
 	}
 	c.logger.Fatal(msg, fields...)
 }
+
+// LogContext logs a message at level with trace context from ctx.
+func (c *contextLogger) LogContext(ctx context.Context, level zapcore.Level, msg string, fields ...zap.Field) {
+	traceFields := extractTraceContext(ctx)
+	if traceFields != nil {
+		fields = append(traceFields, fields...)
+	}
+	c.logger.Log(level, msg, fields...)
+}
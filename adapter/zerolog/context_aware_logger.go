@@ -0,0 +1,88 @@
+package zerolog
+
+import (
+	"context"
+
+	"github.com/mapoio/hyperion"
+)
+
+// contextAwareLogger wraps zerologLogger and automatically injects trace context.
+// The bound context is also attached to each event, so zerolog hooks can read it.
+type contextAwareLogger struct {
+	zerologLogger *zerologLogger
+	stdCtx        context.Context // The underlying context.Context for trace extraction
+}
+
+// newContextAwareLogger creates a context-aware logger that automatically
+// extracts trace context from the embedded context.Context.
+func newContextAwareLogger(ctx context.Context, zerologLogger *zerologLogger) hyperion.Logger {
+	return &contextAwareLogger{
+		zerologLogger: zerologLogger,
+		stdCtx:        ctx,
+	}
+}
+
+// Debug logs a debug message with trace context automatically injected.
+func (c *contextAwareLogger) Debug(msg string, fields ...any) {
+	c.zerologLogger.log(c.stdCtx, hyperion.DebugLevel, msg, fields)
+}
+
+// Info logs an info message with trace context automatically injected.
+func (c *contextAwareLogger) Info(msg string, fields ...any) {
+	c.zerologLogger.log(c.stdCtx, hyperion.InfoLevel, msg, fields)
+}
+
+// Warn logs a warning message with trace context automatically injected.
+func (c *contextAwareLogger) Warn(msg string, fields ...any) {
+	c.zerologLogger.log(c.stdCtx, hyperion.WarnLevel, msg, fields)
+}
+
+// Error logs an error message with trace context automatically injected.
+func (c *contextAwareLogger) Error(msg string, fields ...any) {
+	c.zerologLogger.log(c.stdCtx, hyperion.ErrorLevel, msg, fields)
+}
+
+// Fatal logs a fatal message with trace context automatically injected and exits.
+func (c *contextAwareLogger) Fatal(msg string, fields ...any) {
+	c.zerologLogger.log(c.stdCtx, hyperion.FatalLevel, msg, fields)
+	exit(1)
+}
+
+// LogFields logs a message with typed fields and trace context automatically injected.
+// This implements the hyperion.FieldLogger interface.
+func (c *contextAwareLogger) LogFields(level hyperion.LogLevel, msg string, fields ...hyperion.Field) {
+	c.zerologLogger.logFields(c.stdCtx, level, msg, fields)
+	if level == hyperion.FatalLevel {
+		exit(1)
+	}
+}
+
+// With creates a child logger with additional fields.
+func (c *contextAwareLogger) With(fields ...any) hyperion.Logger {
+	childLogger, ok := c.zerologLogger.With(fields...).(*zerologLogger)
+	if !ok {
+		// This should never happen since zerologLogger.With() always returns *zerologLogger
+		return c
+	}
+	return newContextAwareLogger(c.stdCtx, childLogger)
+}
+
+// WithError creates a child logger with an error field.
+func (c *contextAwareLogger) WithError(err error) hyperion.Logger {
+	return c.With("error", err)
+}
+
+// SetLevel changes the log level dynamically.
+func (c *contextAwareLogger) SetLevel(level hyperion.LogLevel) {
+	c.zerologLogger.SetLevel(level)
+}
+
+// GetLevel returns the current log level.
+func (c *contextAwareLogger) GetLevel() hyperion.LogLevel {
+	return c.zerologLogger.GetLevel()
+}
+
+// Sync flushes any buffered log entries.
+func (c *contextAwareLogger) Sync() error {
+	return c.zerologLogger.Sync()
+}
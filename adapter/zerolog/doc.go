@@ -0,0 +1,49 @@
+// Package zerolog provides a zerolog-based implementation of the hyperion.Logger interface.
+//
+// This adapter wraps github.com/rs/zerolog to provide zero-allocation JSON
+// logging with the same configuration schema and behavior as adapter/zap.
+// Both adapters pass the shared hyperiontest.RunLoggerSuite conformance tests.
+//
+// # Features
+//
+//   - Zero-allocation JSON logging
+//   - JSON and Console output encoders
+//   - Dynamic log level adjustment at runtime (shared by child loggers)
+//   - Automatic log file rotation with size/age limits
+//   - Automatic trace_id and span_id injection via hyperion.ContextAwareLogger
+//
+// # Configuration
+//
+// The logger reads configuration from the provided hyperion.Config under the "log" key:
+//
+//	log:
+//	  level: info              # debug, info, warn, error, fatal
+//	  encoding: json           # json or console
+//	  output: stdout           # stdout, stderr, or file path
+//	  file:
+//	    path: /var/log/app.log
+//	    max_size: 100          # MB
+//	    max_backups: 3
+//	    max_age: 7             # days
+//	    compress: false
+//
+// zerolog's default field names are kept: entries use "message" and "time"
+// where zap uses "msg" and "ts".
+//
+// # Usage
+//
+//	fx.New(
+//	    hyperion.CoreModule,
+//	    viper.Module,    // Provides Config
+//	    zerolog.Module,  // Provides Logger
+//	    fx.Invoke(run),
+//	).Run()
+//
+// An existing zerolog.Logger can be wrapped directly:
+//
+//	logger := zerologadapter.NewLogger(zerolog.New(os.Stdout), hyperion.InfoLevel)
+//
+// # Thread Safety
+//
+// All Logger methods are safe for concurrent use.
+package zerolog
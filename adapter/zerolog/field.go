@@ -0,0 +1,45 @@
+package zerolog
+
+import (
+	"math"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/mapoio/hyperion"
+)
+
+// appendField adds a typed hyperion field to e using zerolog's typed
+// appenders, so the value is not boxed.
+func appendField(e *zerolog.Event, f hyperion.Field) *zerolog.Event {
+	switch f.Type {
+	case hyperion.StringType:
+		return e.Str(f.Key, f.String)
+	case hyperion.Int64Type:
+		return e.Int64(f.Key, f.Integer)
+	case hyperion.Float64Type:
+		return e.Float64(f.Key, math.Float64frombits(uint64(f.Integer)))
+	case hyperion.BoolType:
+		return e.Bool(f.Key, f.Integer == 1)
+	case hyperion.DurationType:
+		return e.Dur(f.Key, time.Duration(f.Integer))
+	case hyperion.TimeType:
+		t, _ := f.Value().(time.Time)
+		return e.Time(f.Key, t)
+	case hyperion.ErrorType:
+		err, _ := f.Interface.(error)
+		return e.AnErr(f.Key, err)
+	default:
+		return e.Interface(f.Key, f.Value())
+	}
+}
+
+// keyValues flattens fields into the key-value list accepted by
+// zerolog's Fields methods.
+func keyValues(fields []hyperion.Field) []any {
+	kv := make([]any, 0, len(fields)*2)
+	for _, f := range fields {
+		kv = append(kv, f.Key, f.Value())
+	}
+	return kv
+}
@@ -0,0 +1,23 @@
+module github.com/mapoio/hyperion/adapter/zerolog
+
+go 1.24
+
+require (
+	github.com/mapoio/hyperion v0.0.0-00010101000000-000000000000
+	github.com/rs/zerolog v1.34.0
+	go.opentelemetry.io/otel/trace v1.38.0
+	go.uber.org/fx v1.24.0
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	go.opentelemetry.io/otel v1.38.0 // indirect
+	go.uber.org/dig v1.19.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	go.uber.org/zap v1.27.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
+
+replace github.com/mapoio/hyperion => ../../hyperion
@@ -0,0 +1,284 @@
+package zerolog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/mapoio/hyperion"
+)
+
+// callerSkip is the number of frames between zerolog's Caller and the
+// application code: [log, exported Logger method].
+const callerSkip = 2
+
+// exit terminates the process after a Fatal log entry.
+// It is a variable so tests can intercept the exit.
+var exit = os.Exit
+
+// zerologLogger implements hyperion.Logger interface using zerolog.
+type zerologLogger struct {
+	logger zerolog.Logger
+	level  *atomic.Int32 // Shared with child loggers so SetLevel affects them
+}
+
+// Ensure zerologLogger implements hyperion.Logger interface.
+var _ hyperion.Logger = (*zerologLogger)(nil)
+
+// Ensure zerologLogger implements hyperion.ContextAwareLogger interface.
+var _ hyperion.ContextAwareLogger = (*zerologLogger)(nil)
+
+// Ensure zerologLogger implements hyperion.FieldLogger interface.
+var _ hyperion.FieldLogger = (*zerologLogger)(nil)
+
+// Config holds configuration for zerolog logger.
+// It mirrors the "log" schema of adapter/zap.
+// Fields are ordered for optimal memory alignment.
+type Config struct {
+	FileConfig *FileConfig `mapstructure:"file"`                                                              // File rotation configuration (8 bytes pointer)
+	Level      string      `mapstructure:"level" default:"info" validate:"oneof=debug info warn error fatal"` // Log level: debug, info, warn, error, fatal (16 bytes)
+	Encoding   string      `mapstructure:"encoding" default:"json" validate:"oneof=json console"`             // Encoding format: json or console (16 bytes)
+	Output     string      `mapstructure:"output" default:"stdout"`                                           // Output destination: stdout, stderr, or file path (16 bytes)
+}
+
+// FileConfig holds file rotation configuration.
+// Fields are ordered for optimal memory alignment.
+type FileConfig struct {
+	Path       string `mapstructure:"path"`        // Log file path (16 bytes)
+	MaxSize    int    `mapstructure:"max_size"`    // Max file size in MB before rotation (8 bytes)
+	MaxBackups int    `mapstructure:"max_backups"` // Max number of old log files to keep (8 bytes)
+	MaxAge     int    `mapstructure:"max_age"`     // Max days to retain old log files (8 bytes)
+	Compress   bool   `mapstructure:"compress"`    // Whether to compress rotated files (1 byte)
+}
+
+// NewZerologLogger creates a new zerolog logger instance.
+// It reads configuration from the provided hyperion.Config under the "log" key.
+// If no configuration is found, sensible defaults are used.
+func NewZerologLogger(cfg hyperion.Config) (hyperion.Logger, error) {
+	// Read configuration
+	logCfg := &Config{
+		Level:    "info",
+		Encoding: "json",
+		Output:   "stdout",
+	}
+
+	if cfg != nil {
+		if err := cfg.Unmarshal("log", logCfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal log config: %w", err)
+		}
+	}
+
+	// Parse log level
+	zlLevel, err := zerolog.ParseLevel(logCfg.Level)
+	if err != nil || logCfg.Level == "" {
+		return nil, fmt.Errorf("invalid log level %q", logCfg.Level)
+	}
+	level, ok := reverseLevelMapping[zlLevel]
+	if !ok {
+		return nil, fmt.Errorf("unsupported log level %q", logCfg.Level)
+	}
+
+	// Configure output writer
+	var w io.Writer
+	switch logCfg.Output {
+	case "stdout":
+		w = os.Stdout
+	case "stderr":
+		w = os.Stderr
+	default:
+		// Treat as file path
+		if logCfg.FileConfig == nil {
+			// Use default file config
+			logCfg.FileConfig = &FileConfig{
+				Path:       logCfg.Output,
+				MaxSize:    100,
+				MaxBackups: 3,
+				MaxAge:     7,
+				Compress:   false,
+			}
+		} else {
+			logCfg.FileConfig.Path = logCfg.Output
+		}
+
+		w = &lumberjack.Logger{
+			Filename:   logCfg.FileConfig.Path,
+			MaxSize:    logCfg.FileConfig.MaxSize,
+			MaxBackups: logCfg.FileConfig.MaxBackups,
+			MaxAge:     logCfg.FileConfig.MaxAge,
+			Compress:   logCfg.FileConfig.Compress,
+		}
+	}
+
+	// Select encoder
+	switch logCfg.Encoding {
+	case "console":
+		w = zerolog.ConsoleWriter{Out: w}
+	case "json":
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", logCfg.Encoding)
+	}
+
+	return NewLogger(zerolog.New(w).With().Timestamp().Logger(), level), nil
+}
+
+// NewLogger wraps an existing zerolog.Logger as a hyperion.Logger.
+// The zerolog logger's own level is left permissive; filtering is done
+// against level so that SetLevel takes effect for all child loggers.
+func NewLogger(logger zerolog.Logger, level hyperion.LogLevel) hyperion.Logger {
+	l := &zerologLogger{
+		logger: logger.Level(zerolog.TraceLevel),
+		level:  new(atomic.Int32),
+	}
+	l.SetLevel(level)
+	return l
+}
+
+// Debug logs a debug message with optional fields.
+func (l *zerologLogger) Debug(msg string, fields ...any) {
+	l.log(context.Background(), hyperion.DebugLevel, msg, fields)
+}
+
+// Info logs an info message with optional fields.
+func (l *zerologLogger) Info(msg string, fields ...any) {
+	l.log(context.Background(), hyperion.InfoLevel, msg, fields)
+}
+
+// Warn logs a warning message with optional fields.
+func (l *zerologLogger) Warn(msg string, fields ...any) {
+	l.log(context.Background(), hyperion.WarnLevel, msg, fields)
+}
+
+// Error logs an error message with optional fields.
+func (l *zerologLogger) Error(msg string, fields ...any) {
+	l.log(context.Background(), hyperion.ErrorLevel, msg, fields)
+}
+
+// Fatal logs a fatal message with optional fields and exits the process.
+func (l *zerologLogger) Fatal(msg string, fields ...any) {
+	l.log(context.Background(), hyperion.FatalLevel, msg, fields)
+	exit(1)
+}
+
+// LogFields logs a message with typed fields mapped directly to zerolog's typed appenders.
+// This implements the hyperion.FieldLogger interface.
+func (l *zerologLogger) LogFields(level hyperion.LogLevel, msg string, fields ...hyperion.Field) {
+	l.logFields(context.Background(), level, msg, fields)
+	if level == hyperion.FatalLevel {
+		exit(1)
+	}
+}
+
+// With creates a child logger with additional fields.
+func (l *zerologLogger) With(fields ...any) hyperion.Logger {
+	return &zerologLogger{
+		logger: l.logger.With().Fields(keyValues(hyperion.FieldsFromArgs(fields))).Logger(),
+		level:  l.level,
+	}
+}
+
+// WithError creates a child logger with an error field.
+func (l *zerologLogger) WithError(err error) hyperion.Logger {
+	return l.With("error", err)
+}
+
+// SetLevel changes the log level dynamically.
+func (l *zerologLogger) SetLevel(level hyperion.LogLevel) {
+	if _, ok := levelMapping[level]; !ok {
+		level = hyperion.InfoLevel // default
+	}
+	l.level.Store(int32(level))
+}
+
+// GetLevel returns the current log level.
+func (l *zerologLogger) GetLevel() hyperion.LogLevel {
+	return hyperion.LogLevel(l.level.Load())
+}
+
+// Sync flushes any buffered log entries.
+// zerolog writes each entry synchronously, so there is nothing to flush.
+func (l *zerologLogger) Sync() error {
+	return nil
+}
+
+// WithContext returns a context-aware logger that automatically injects
+// trace context (trace_id and span_id) into all log entries.
+// This implements the hyperion.ContextAwareLogger interface.
+func (l *zerologLogger) WithContext(ctx context.Context) hyperion.Logger {
+	return newContextAwareLogger(ctx, l)
+}
+
+// log writes a single entry if level is enabled.
+func (l *zerologLogger) log(ctx context.Context, level hyperion.LogLevel, msg string, fields []any) {
+	e := l.event(ctx, level)
+	if e == nil {
+		return
+	}
+	for _, f := range hyperion.FieldsFromArgs(fields) {
+		e = appendField(e, f)
+	}
+	e.Caller(callerSkip).Msg(msg)
+}
+
+// logFields is log for typed fields. It is always reached through
+// hyperion.LogFields, which adds one frame to skip.
+func (l *zerologLogger) logFields(ctx context.Context, level hyperion.LogLevel, msg string, fields []hyperion.Field) {
+	e := l.event(ctx, level)
+	if e == nil {
+		return
+	}
+	for _, f := range fields {
+		e = appendField(e, f)
+	}
+	e.Caller(callerSkip + 1).Msg(msg)
+}
+
+// event starts an entry at level, or returns nil if level is disabled.
+// Trace context from ctx is injected as trace_id and span_id fields.
+func (l *zerologLogger) event(ctx context.Context, level hyperion.LogLevel) *zerolog.Event {
+	if level < l.GetLevel() {
+		return nil
+	}
+
+	e := l.logger.WithLevel(toZerologLevel(level))
+	if e == nil {
+		return nil
+	}
+	e = e.Ctx(ctx)
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		e = e.Str("trace_id", spanCtx.TraceID().String()).
+			Str("span_id", spanCtx.SpanID().String())
+	}
+	return e
+}
+
+// levelMapping defines bidirectional mapping between hyperion and zerolog log levels.
+var levelMapping = map[hyperion.LogLevel]zerolog.Level{
+	hyperion.DebugLevel: zerolog.DebugLevel,
+	hyperion.InfoLevel:  zerolog.InfoLevel,
+	hyperion.WarnLevel:  zerolog.WarnLevel,
+	hyperion.ErrorLevel: zerolog.ErrorLevel,
+	hyperion.FatalLevel: zerolog.FatalLevel,
+}
+
+// reverseLevelMapping provides reverse lookup from zerolog to hyperion levels.
+var reverseLevelMapping = map[zerolog.Level]hyperion.LogLevel{
+	zerolog.DebugLevel: hyperion.DebugLevel,
+	zerolog.InfoLevel:  hyperion.InfoLevel,
+	zerolog.WarnLevel:  hyperion.WarnLevel,
+	zerolog.ErrorLevel: hyperion.ErrorLevel,
+	zerolog.FatalLevel: hyperion.FatalLevel,
+}
+
+// toZerologLevel converts hyperion.LogLevel to zerolog.Level.
+func toZerologLevel(level hyperion.LogLevel) zerolog.Level {
+	if zlLevel, ok := levelMapping[level]; ok {
+		return zlLevel
+	}
+	return zerolog.InfoLevel // default
+}
@@ -0,0 +1,205 @@
+package zerolog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/hyperiontest"
+)
+
+// mockConfig implements hyperion.Config for testing.
+type mockConfig struct {
+	hyperion.Config
+	log map[string]string
+}
+
+func (m *mockConfig) Unmarshal(key string, rawVal any) error {
+	if logCfg, ok := rawVal.(*Config); ok && key == "log" {
+		if v, ok := m.log["level"]; ok {
+			logCfg.Level = v
+		}
+		if v, ok := m.log["encoding"]; ok {
+			logCfg.Encoding = v
+		}
+		if v, ok := m.log["output"]; ok {
+			logCfg.Output = v
+		}
+	}
+	return nil
+}
+
+// traceContext returns a context carrying a sampled span context.
+func traceContext() (ctx context.Context, traceID, spanID string) {
+	tid := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	sid := trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+	ctx = trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: trace.FlagsSampled,
+	}))
+	return ctx, tid.String(), sid.String()
+}
+
+func TestConformance(t *testing.T) {
+	hyperiontest.RunLoggerSuite(t, hyperiontest.LoggerHarness{
+		New: func(w io.Writer, level hyperion.LogLevel) hyperion.Logger {
+			return NewLogger(zerolog.New(w), level)
+		},
+		TraceContext: traceContext,
+		MessageKey:   zerolog.MessageFieldName,
+		LevelKey:     zerolog.LevelFieldName,
+	})
+}
+
+func TestNewZerologLogger_DefaultConfig(t *testing.T) {
+	logger, err := NewZerologLogger(nil)
+	if err != nil {
+		t.Fatalf("NewZerologLogger() error = %v", err)
+	}
+
+	if level := logger.GetLevel(); level != hyperion.InfoLevel {
+		t.Errorf("GetLevel() = %v, want %v", level, hyperion.InfoLevel)
+	}
+}
+
+func TestNewZerologLogger_WithConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       map[string]string
+		wantLevel hyperion.LogLevel
+		wantErr   bool
+	}{
+		{name: "debug json", log: map[string]string{"level": "debug", "encoding": "json"}, wantLevel: hyperion.DebugLevel},
+		{name: "warn console", log: map[string]string{"level": "warn", "encoding": "console"}, wantLevel: hyperion.WarnLevel},
+		{name: "error stderr", log: map[string]string{"level": "error", "output": "stderr"}, wantLevel: hyperion.ErrorLevel},
+		{name: "invalid level", log: map[string]string{"level": "verbose"}, wantErr: true},
+		{name: "unsupported level", log: map[string]string{"level": "trace"}, wantErr: true},
+		{name: "empty level", log: map[string]string{"level": ""}, wantErr: true},
+		{name: "invalid encoding", log: map[string]string{"encoding": "xml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, err := NewZerologLogger(&mockConfig{log: tt.log})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewZerologLogger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if level := logger.GetLevel(); level != tt.wantLevel {
+				t.Errorf("GetLevel() = %v, want %v", level, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestNewZerologLogger_FileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := NewZerologLogger(&mockConfig{log: map[string]string{"output": path}})
+	if err != nil {
+		t.Fatalf("NewZerologLogger() error = %v", err)
+	}
+
+	logger.Info("to file")
+	if err := logger.Sync(); err != nil {
+		t.Errorf("Sync() error = %v", err)
+	}
+}
+
+func TestZerologLogger_Caller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(zerolog.New(&buf), hyperion.DebugLevel)
+
+	logger.Info("plain")
+	logger.(hyperion.ContextAwareLogger).WithContext(context.Background()).Info("context-aware")
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		caller, _ := entry[zerolog.CallerFieldName].(string)
+		if !strings.Contains(caller, "logger_test.go") {
+			t.Errorf("%s: caller = %q, want logger_test.go", entry[zerolog.MessageFieldName], caller)
+		}
+	}
+}
+
+func TestZerologLogger_Fatal(t *testing.T) {
+	var code int
+	origExit := exit
+	exit = func(c int) { code = c }
+	defer func() { exit = origExit }()
+
+	var buf bytes.Buffer
+	logger := NewLogger(zerolog.New(&buf), hyperion.InfoLevel)
+	logger.Fatal("fatal message")
+
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	if !strings.Contains(buf.String(), `"level":"fatal"`) {
+		t.Errorf("expected fatal entry, got %s", buf.String())
+	}
+}
+
+func TestZerologLogger_SetLevelInvalid(t *testing.T) {
+	logger := NewLogger(zerolog.Nop(), hyperion.DebugLevel)
+
+	logger.SetLevel(hyperion.LogLevel(99))
+	if level := logger.GetLevel(); level != hyperion.InfoLevel {
+		t.Errorf("GetLevel() = %v, want %v", level, hyperion.InfoLevel)
+	}
+}
+
+func TestToZerologLevel(t *testing.T) {
+	for level, want := range levelMapping {
+		if got := toZerologLevel(level); got != want {
+			t.Errorf("toZerologLevel(%v) = %v, want %v", level, got, want)
+		}
+	}
+	if got := toZerologLevel(hyperion.LogLevel(99)); got != zerolog.InfoLevel {
+		t.Errorf("toZerologLevel(99) = %v, want %v", got, zerolog.InfoLevel)
+	}
+}
+
+func TestConfigSections(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("test", map[string]any{
+		"log": map[string]any{"level": "debug", "encoding": "json", "output": "stderr"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues := hyperion.LintConfig(cfg, ConfigSections()); len(issues) != 0 {
+		t.Errorf("LintConfig = %v, want no issues", issues)
+	}
+
+	var sections []hyperion.ConfigSection
+	app := fxtest.New(t,
+		fx.Provide(func() hyperion.Config { return cfg }),
+		Module,
+		fx.Invoke(fx.Annotate(
+			func(s []hyperion.ConfigSection) { sections = s },
+			fx.ParamTags(`group:"hyperion.config_sections"`),
+		)),
+	)
+	app.RequireStart()
+	app.RequireStop()
+
+	if len(sections) != 1 || sections[0].Key != "log" {
+		t.Errorf("Module config sections = %+v, want the log section", sections)
+	}
+}
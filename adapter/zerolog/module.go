@@ -0,0 +1,47 @@
+package zerolog
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/mapoio/hyperion"
+)
+
+// Module provides zerolog-based Logger implementation.
+//
+// Usage:
+//
+//	fx.New(
+//	    hyperion.CoreModule,
+//	    viper.Module,    // Provides Config (optional for zerolog)
+//	    zerolog.Module,  // Provides Logger
+//	    myapp.Module,
+//	).Run()
+var Module = fx.Module("hyperion.adapter.zerolog",
+	fx.Provide(
+		fx.Annotate(
+			NewZerologProvider,
+			fx.As(new(hyperion.Logger)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			ConfigSections,
+			fx.ResultTags(`group:"hyperion.config_sections,flatten"`),
+		),
+	),
+)
+
+// ConfigSections describes the "log" section for JSON Schema generation
+// and linting. Module contributes it to the "hyperion.config_sections" group.
+func ConfigSections() []hyperion.ConfigSection {
+	return []hyperion.ConfigSection{{
+		Key:         "log",
+		Type:        Config{},
+		Description: "Logging (adapter/zerolog)",
+	}}
+}
+
+// NewZerologProvider creates a zerolog logger.
+func NewZerologProvider(cfg hyperion.Config) (hyperion.Logger, error) {
+	return NewZerologLogger(cfg)
+}
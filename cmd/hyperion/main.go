@@ -0,0 +1,117 @@
+// Command hyperion provides tooling for hyperion services.
+//
+// Usage:
+//
+//	hyperion config [flags] lint <file>...   check config files
+//	hyperion config [flags] schema           print the config JSON Schema
+//
+// The command knows the config sections of the bundled adapters. The
+// cache, tracing and metrics sections are always checked; the database and
+// log sections are only checked once -database (gorm or sqlx) and -logger
+// (zap, slog or zerolog) select the adapter the service uses, since each
+// alternative reads different keys. To lint application sections too, run
+// the same command from the service binary with viper.ConfigCommand.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/adapter/gorm"
+	"github.com/mapoio/hyperion/adapter/memory"
+	"github.com/mapoio/hyperion/adapter/otel"
+	"github.com/mapoio/hyperion/adapter/redis"
+	"github.com/mapoio/hyperion/adapter/slog"
+	"github.com/mapoio/hyperion/adapter/sqlx"
+	"github.com/mapoio/hyperion/adapter/viper"
+	"github.com/mapoio/hyperion/adapter/zap"
+	"github.com/mapoio/hyperion/adapter/zerolog"
+)
+
+const usage = `usage: hyperion config [-database gorm|sqlx] [-logger zap|slog|zerolog] <lint <file>... | schema>
+`
+
+// databases and loggers map the -database and -logger values to the
+// sections of the matching adapter.
+var (
+	databases = map[string]func() []hyperion.ConfigSection{
+		"gorm": gorm.ConfigSections,
+		"sqlx": sqlx.ConfigSections,
+	}
+	loggers = map[string]func() []hyperion.ConfigSection{
+		"zap":     zap.ConfigSections,
+		"slog":    slog.ConfigSections,
+		"zerolog": zerolog.ConfigSections,
+	}
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "config" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	os.Exit(run(os.Args[2:], os.Stdout, os.Stderr))
+}
+
+// run parses the config command flags and runs the command.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("config", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	database := flags.String("database", "", "database adapter whose section is checked (gorm or sqlx)")
+	logger := flags.String("logger", "", "logger adapter whose section is checked (zap, slog or zerolog)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	all := sections()
+	for _, choice := range []struct {
+		flag, value string
+		adapters    map[string]func() []hyperion.ConfigSection
+	}{
+		{"database", *database, databases},
+		{"logger", *logger, loggers},
+	} {
+		if choice.value == "" {
+			continue
+		}
+		adapter, ok := choice.adapters[choice.value]
+		if !ok {
+			fmt.Fprintf(stderr, "unknown -%s %q, want one of %s\n", choice.flag, choice.value, names(choice.adapters))
+			return 2
+		}
+		all = append(all, adapter()...)
+	}
+
+	cmd, err := viper.NewConfigCommand(all)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	return cmd.Run(flags.Args(), stdout, stderr)
+}
+
+// sections returns the config sections of the bundled adapters that have
+// no alternative.
+func sections() []hyperion.ConfigSection {
+	var all []hyperion.ConfigSection
+	all = append(all, memory.ConfigSections()...)
+	all = append(all, otel.ConfigSections()...)
+	all = append(all, redis.ConfigSections()...)
+	return all
+}
+
+// names returns the sorted keys of adapters, comma separated.
+func names(adapters map[string]func() []hyperion.ConfigSection) string {
+	keys := make([]string, 0, len(adapters))
+	for key := range adapters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
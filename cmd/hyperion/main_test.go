@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_LoggerSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("log:\n  level: info\n  encoding: text\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{"no logger", nil, 0, "warning: log: no registered config section"},
+		{"slog accepts text", []string{"-logger", "slog"}, 0, ""},
+		{"zap rejects text", []string{"-logger", "zap"}, 1, "error: log.encoding: must be one of [json console], got text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append(append([]string(nil), tt.args...), "lint", path)
+			if code := run(args, &stdout, &stderr); code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d (stdout %q, stderr %q)", code, tt.wantCode, stdout.String(), stderr.String())
+			}
+			if tt.wantOut == "" && stdout.Len() != 0 {
+				t.Errorf("output = %q, want nothing", stdout.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Errorf("output = %q, want %q", stdout.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestRun_UnknownAdapter(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-database", "ent", "schema"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("exit code = %d, want 2", code)
+	}
+	if want := `unknown -database "ent", want one of gorm, sqlx`; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+}
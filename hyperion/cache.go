@@ -2,14 +2,21 @@ package hyperion
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrCacheMiss is returned by Cache.Get when the key does not exist or has
+// expired. Adapters return it, or an error wrapping it, so callers can
+// tell a miss from a failure with errors.Is.
+var ErrCacheMiss = errors.New("cache miss")
+
 // Cache is the caching abstraction.
 // Implementations should provide thread-safe caching operations.
 type Cache interface {
 	// Get retrieves the value for the given key.
-	// Returns an error if the key doesn't exist or the operation fails.
+	// Returns ErrCacheMiss if the key doesn't exist, or another error if
+	// the operation fails.
 	Get(ctx context.Context, key string) ([]byte, error)
 
 	// Set stores the value for the given key with the specified TTL.
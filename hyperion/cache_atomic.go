@@ -0,0 +1,60 @@
+package hyperion
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotInteger is returned by AtomicCache.IncrBy when the stored value is
+// not a decimal integer, or the result would overflow an int64.
+var ErrNotInteger = errors.New("cache value is not an integer")
+
+// AtomicCache is an optional Cache extension with atomic read-modify-write
+// operations, for rate limits, idempotency keys and deduplication.
+// Each method is atomic with respect to every other operation on the key.
+//
+// Callers detect support with a type assertion:
+//
+//	atomic, ok := cache.(hyperion.AtomicCache)
+//	if !ok {
+//	    return errors.New("rate limiting requires an atomic cache")
+//	}
+//	n, err := atomic.Incr(ctx, "ratelimit:"+ip, time.Minute)
+//	if err == nil && n > 100 {
+//	    return ErrTooManyRequests
+//	}
+//
+// Counters are stored as decimal strings, so Get returns them as text.
+type AtomicCache interface {
+	Cache
+
+	// Incr is IncrBy with a delta of 1.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+
+	// IncrBy adds delta to the integer stored under key and returns the
+	// result. A missing key counts as 0 and is created with ttl; the TTL of
+	// an existing key is left unchanged, so a counter covers a fixed window.
+	// A TTL of 0 means no expiration. Returns ErrNotInteger if the value is
+	// not an integer or the result would overflow.
+	IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+
+	// SetNX stores value only if key does not exist, and reports whether it did.
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+
+	// CompareAndSwap stores value with ttl only if key currently holds old,
+	// and reports whether it did. A missing key never matches.
+	CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error)
+
+	// Expire sets the TTL of an existing key and reports whether the key
+	// exists. A TTL of 0 removes the expiration.
+	Expire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// TTL returns the remaining time to live of key, or 0 if it does not
+	// expire. Returns ErrCacheMiss if the key does not exist.
+	TTL(ctx context.Context, key string) (time.Duration, error)
+
+	// GetSet stores value with ttl and returns the previous value, with
+	// found reporting whether there was one.
+	GetSet(ctx context.Context, key string, value []byte, ttl time.Duration) (old []byte, found bool, err error)
+}
@@ -0,0 +1,389 @@
+package hyperion
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// DefaultSlowCacheThreshold is the duration above which InstrumentCache
+// logs a cache operation as slow.
+const DefaultSlowCacheThreshold = 100 * time.Millisecond
+
+// CacheInstrumentOption configures InstrumentCache.
+type CacheInstrumentOption func(*cacheInstrumentConfig)
+
+type cacheInstrumentConfig struct {
+	logger        Logger
+	name          string
+	slowThreshold time.Duration
+}
+
+// WithCacheName adds a "cache.name" attribute to spans and metrics, to tell
+// several instrumented caches apart.
+func WithCacheName(name string) CacheInstrumentOption {
+	return func(cfg *cacheInstrumentConfig) {
+		cfg.name = name
+	}
+}
+
+// WithCacheLogger sets the logger for slow operations. Defaults to no logging.
+func WithCacheLogger(logger Logger) CacheInstrumentOption {
+	return func(cfg *cacheInstrumentConfig) {
+		cfg.logger = logger
+	}
+}
+
+// WithSlowCacheThreshold sets the duration above which operations are logged
+// at warn level. Defaults to DefaultSlowCacheThreshold; 0 disables logging.
+func WithSlowCacheThreshold(d time.Duration) CacheInstrumentOption {
+	return func(cfg *cacheInstrumentConfig) {
+		cfg.slowThreshold = d
+	}
+}
+
+// instrumentedCache wraps a Cache with tracing, metrics and slow-operation logging.
+type instrumentedCache struct {
+	cache    Cache
+	tagged   TaggedCache
+	tracer   Tracer
+	meter    Meter
+	logger   Logger
+	hits     Counter
+	misses   Counter
+	errs     Counter
+	duration Histogram
+	attrs    []Attribute
+	slow     time.Duration
+}
+
+// Ensure instrumentedCache implements TaggedCache interface.
+var _ TaggedCache = (*instrumentedCache)(nil)
+
+// InstrumentCache wraps cache so every operation is traced and measured.
+//
+// Each operation runs in a client span named "cache.<operation>" (for
+// example "cache.get") with "cache.operation" and "cache.key_count"
+// attributes; Get and MGet also set "cache.hit" and "cache.hit_count".
+// Errors other than ErrCacheMiss are recorded on the span.
+//
+// Metrics:
+//   - cache.hits, cache.misses: keys found and not found by Get and MGet
+//   - cache.errors: failed operations
+//   - cache.duration: operation latency in milliseconds
+//
+// All carry a "cache.operation" attribute, and "cache.name" if set with
+// WithCacheName. Operations slower than the threshold are logged at warn
+// level when a logger is set with WithCacheLogger.
+//
+// The returned Cache forwards Close and Health to cache when it implements
+// them, so lifecycle hooks keep working on the wrapped value. It also
+// implements TaggedCache, through AsTaggedCache(cache), and AtomicCache if
+// cache does.
+func InstrumentCache(cache Cache, tracer Tracer, meter Meter, opts ...CacheInstrumentOption) Cache {
+	cfg := cacheInstrumentConfig{slowThreshold: DefaultSlowCacheThreshold}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if tracer == nil {
+		tracer = NewNoOpTracer()
+	}
+	if meter == nil {
+		meter = NewNoOpMeter()
+	}
+	if cfg.logger == nil {
+		cfg.logger = NewNoOpLogger()
+	}
+
+	var attrs []Attribute
+	if cfg.name != "" {
+		attrs = append(attrs, String("cache.name", cfg.name))
+	}
+
+	c := &instrumentedCache{
+		cache:  cache,
+		tagged: AsTaggedCache(cache),
+		tracer: tracer,
+		meter:  meter,
+		logger: cfg.logger,
+		hits: meter.Counter("cache.hits",
+			WithMetricDescription("Cache lookups that found a value"),
+			WithMetricUnit("1"),
+		),
+		misses: meter.Counter("cache.misses",
+			WithMetricDescription("Cache lookups that found no value"),
+			WithMetricUnit("1"),
+		),
+		errs: meter.Counter("cache.errors",
+			WithMetricDescription("Failed cache operations"),
+			WithMetricUnit("1"),
+		),
+		duration: meter.Histogram("cache.duration",
+			WithMetricDescription("Cache operation latency"),
+			WithMetricUnit("ms"),
+		),
+		attrs: attrs,
+		slow:  cfg.slowThreshold,
+	}
+	if atomic, ok := cache.(AtomicCache); ok {
+		return &instrumentedAtomicCache{instrumentedCache: c, atomic: atomic}
+	}
+	return c
+}
+
+// CacheInstrumentationModule decorates the application's Cache with
+// InstrumentCache, using the Tracer, Meter and Logger if they are provided.
+//
+// It must be included at the top level of fx.New, not inside another
+// fx.Module, so the decoration applies to every consumer of Cache:
+//
+//	fx.New(
+//	    hyperion.CoreModule,
+//	    hyperion.CacheInstrumentationModule,
+//	    otel.Module,
+//	    redis.Module,
+//	    myapp.Module,
+//	).Run()
+var CacheInstrumentationModule = fx.Options(
+	fx.Decorate(func(params struct {
+		fx.In
+		Cache  Cache
+		Tracer Tracer `optional:"true"`
+		Meter  Meter  `optional:"true"`
+		Logger Logger `optional:"true"`
+	}) Cache {
+		return InstrumentCache(params.Cache, params.Tracer, params.Meter, WithCacheLogger(params.Logger))
+	}),
+)
+
+// cacheOp tracks one instrumented operation.
+type cacheOp struct {
+	start time.Time
+	ctx   context.Context
+	span  Span
+	name  string
+	attrs []Attribute
+	keys  int
+}
+
+// begin starts the span for an operation on keys keys.
+func (c *instrumentedCache) begin(ctx context.Context, name string, keys int) (context.Context, *cacheOp) {
+	hctx, ok := ctx.(Context)
+	if !ok {
+		hctx = New(ctx, c.logger, &noopExecutor{}, c.tracer, c.meter)
+	}
+	attrs := append([]Attribute{String("cache.operation", name)}, c.attrs...)
+	spanCtx, span := c.tracer.Start(hctx, "cache."+name, WithSpanKind(SpanKindClient))
+	span.SetAttributes(append([]Attribute{Int("cache.key_count", keys)}, attrs...)...)
+
+	// The cache receives the span's context, so client-level spans nest under it
+	return spanCtx, &cacheOp{start: time.Now(), ctx: ctx, span: span, name: name, attrs: attrs, keys: keys}
+}
+
+// end records the outcome of op. ErrCacheMiss is not counted as an error.
+func (c *instrumentedCache) end(op *cacheOp, err error) {
+	elapsed := time.Since(op.start)
+	c.duration.Record(op.ctx, float64(elapsed)/float64(time.Millisecond), op.attrs...)
+	if err != nil && !errors.Is(err, ErrCacheMiss) {
+		op.span.RecordError(err)
+		c.errs.Add(op.ctx, 1, op.attrs...)
+	}
+	op.span.End()
+
+	if c.slow > 0 && elapsed >= c.slow {
+		fields := []any{"operation", op.name, "keys", op.keys, "duration", elapsed}
+		if err != nil && !errors.Is(err, ErrCacheMiss) {
+			fields = append(fields, "error", err)
+		}
+		c.logger.Warn("slow cache operation", fields...)
+	}
+}
+
+// lookups records hits and misses for a read of op.keys keys.
+func (c *instrumentedCache) lookups(op *cacheOp, hits int) {
+	op.span.SetAttributes(Bool("cache.hit", hits == op.keys && op.keys > 0), Int("cache.hit_count", hits))
+	if hits > 0 {
+		c.hits.Add(op.ctx, int64(hits), op.attrs...)
+	}
+	if misses := op.keys - hits; misses > 0 {
+		c.misses.Add(op.ctx, int64(misses), op.attrs...)
+	}
+}
+
+// Get implements Cache.Get.
+func (c *instrumentedCache) Get(ctx context.Context, key string) ([]byte, error) {
+	ctx, op := c.begin(ctx, "get", 1)
+	value, err := c.cache.Get(ctx, key)
+	switch {
+	case err == nil:
+		c.lookups(op, 1)
+	case errors.Is(err, ErrCacheMiss):
+		c.lookups(op, 0)
+	}
+	c.end(op, err)
+	return value, err
+}
+
+// Set implements Cache.Set.
+func (c *instrumentedCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	ctx, op := c.begin(ctx, "set", 1)
+	err := c.cache.Set(ctx, key, value, ttl)
+	c.end(op, err)
+	return err
+}
+
+// Delete implements Cache.Delete.
+func (c *instrumentedCache) Delete(ctx context.Context, key string) error {
+	ctx, op := c.begin(ctx, "delete", 1)
+	err := c.cache.Delete(ctx, key)
+	c.end(op, err)
+	return err
+}
+
+// Exists implements Cache.Exists.
+func (c *instrumentedCache) Exists(ctx context.Context, key string) (bool, error) {
+	ctx, op := c.begin(ctx, "exists", 1)
+	ok, err := c.cache.Exists(ctx, key)
+	c.end(op, err)
+	return ok, err
+}
+
+// MGet implements Cache.MGet.
+func (c *instrumentedCache) MGet(ctx context.Context, keys ...string) (map[string][]byte, error) {
+	ctx, op := c.begin(ctx, "mget", len(keys))
+	values, err := c.cache.MGet(ctx, keys...)
+	if err == nil {
+		c.lookups(op, len(values))
+	}
+	c.end(op, err)
+	return values, err
+}
+
+// MSet implements Cache.MSet.
+func (c *instrumentedCache) MSet(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	ctx, op := c.begin(ctx, "mset", len(items))
+	err := c.cache.MSet(ctx, items, ttl)
+	c.end(op, err)
+	return err
+}
+
+// Clear implements Cache.Clear.
+func (c *instrumentedCache) Clear(ctx context.Context) error {
+	ctx, op := c.begin(ctx, "clear", 0)
+	err := c.cache.Clear(ctx)
+	c.end(op, err)
+	return err
+}
+
+// SetWithTags implements TaggedCache.SetWithTags.
+func (c *instrumentedCache) SetWithTags(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	ctx, op := c.begin(ctx, "set_with_tags", 1)
+	err := c.tagged.SetWithTags(ctx, key, value, ttl, tags...)
+	c.end(op, err)
+	return err
+}
+
+// InvalidateTags implements TaggedCache.InvalidateTags.
+func (c *instrumentedCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	ctx, op := c.begin(ctx, "invalidate_tags", 0)
+	err := c.tagged.InvalidateTags(ctx, tags...)
+	c.end(op, err)
+	return err
+}
+
+// DeletePrefix implements TaggedCache.DeletePrefix.
+func (c *instrumentedCache) DeletePrefix(ctx context.Context, prefix string) error {
+	ctx, op := c.begin(ctx, "delete_prefix", 0)
+	err := c.tagged.DeletePrefix(ctx, prefix)
+	c.end(op, err)
+	return err
+}
+
+// Health forwards to the wrapped cache if it has a Health method.
+func (c *instrumentedCache) Health(ctx context.Context) error {
+	if hc, ok := c.cache.(interface{ Health(context.Context) error }); ok {
+		return hc.Health(ctx)
+	}
+	return nil
+}
+
+// Close forwards to the wrapped cache if it implements io.Closer.
+func (c *instrumentedCache) Close() error {
+	if closer, ok := c.cache.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Unwrap returns the wrapped cache.
+func (c *instrumentedCache) Unwrap() Cache {
+	return c.cache
+}
+
+// instrumentedAtomicCache is an instrumentedCache over an AtomicCache.
+type instrumentedAtomicCache struct {
+	*instrumentedCache
+	atomic AtomicCache
+}
+
+// Ensure instrumentedAtomicCache implements AtomicCache interface.
+var _ AtomicCache = (*instrumentedAtomicCache)(nil)
+
+// Incr implements AtomicCache.Incr.
+func (c *instrumentedAtomicCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	ctx, op := c.begin(ctx, "incr", 1)
+	n, err := c.atomic.Incr(ctx, key, ttl)
+	c.end(op, err)
+	return n, err
+}
+
+// IncrBy implements AtomicCache.IncrBy.
+func (c *instrumentedAtomicCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ctx, op := c.begin(ctx, "incr", 1)
+	n, err := c.atomic.IncrBy(ctx, key, delta, ttl)
+	c.end(op, err)
+	return n, err
+}
+
+// SetNX implements AtomicCache.SetNX.
+func (c *instrumentedAtomicCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	ctx, op := c.begin(ctx, "setnx", 1)
+	ok, err := c.atomic.SetNX(ctx, key, value, ttl)
+	c.end(op, err)
+	return ok, err
+}
+
+// CompareAndSwap implements AtomicCache.CompareAndSwap.
+func (c *instrumentedAtomicCache) CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error) {
+	ctx, op := c.begin(ctx, "compare_and_swap", 1)
+	ok, err := c.atomic.CompareAndSwap(ctx, key, old, value, ttl)
+	c.end(op, err)
+	return ok, err
+}
+
+// Expire implements AtomicCache.Expire.
+func (c *instrumentedAtomicCache) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ctx, op := c.begin(ctx, "expire", 1)
+	ok, err := c.atomic.Expire(ctx, key, ttl)
+	c.end(op, err)
+	return ok, err
+}
+
+// TTL implements AtomicCache.TTL.
+func (c *instrumentedAtomicCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ctx, op := c.begin(ctx, "ttl", 1)
+	ttl, err := c.atomic.TTL(ctx, key)
+	c.end(op, err)
+	return ttl, err
+}
+
+// GetSet implements AtomicCache.GetSet.
+func (c *instrumentedAtomicCache) GetSet(ctx context.Context, key string, value []byte, ttl time.Duration) ([]byte, bool, error) {
+	ctx, op := c.begin(ctx, "getset", 1)
+	old, found, err := c.atomic.GetSet(ctx, key, value, ttl)
+	c.end(op, err)
+	return old, found, err
+}
@@ -0,0 +1,407 @@
+package hyperion
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+// stubCache is a map-backed Cache that can fail or stall on demand.
+type stubCache struct {
+	noopCache
+	items  map[string][]byte
+	err    error
+	delay  time.Duration
+	closed bool
+}
+
+func newStubCache() *stubCache {
+	return &stubCache{items: make(map[string][]byte)}
+}
+
+func (c *stubCache) Get(_ context.Context, key string) ([]byte, error) {
+	time.Sleep(c.delay)
+	if c.err != nil {
+		return nil, c.err
+	}
+	if v, ok := c.items[key]; ok {
+		return v, nil
+	}
+	return nil, ErrCacheMiss
+}
+
+func (c *stubCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.items[key] = value
+	return nil
+}
+
+func (c *stubCache) MGet(_ context.Context, keys ...string) (map[string][]byte, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	result := make(map[string][]byte)
+	for _, key := range keys {
+		if v, ok := c.items[key]; ok {
+			result[key] = v
+		}
+	}
+	return result, nil
+}
+
+func (c *stubCache) Close() error {
+	c.closed = true
+	return nil
+}
+
+// recordedSpan is a span captured by spanRecorder.
+type recordedSpan struct {
+	noopSpan
+	name   string
+	kind   SpanKind
+	attrs  map[string]any
+	errs   []error
+	ended  bool
+	parent Context
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordedSpan) RecordError(err error, _ ...EventOption) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *recordedSpan) End(_ ...SpanEndOption) {
+	s.ended = true
+}
+
+// spanRecorder is a Tracer that records every span it starts.
+type spanRecorder struct {
+	spans []*recordedSpan
+}
+
+func (r *spanRecorder) Start(ctx Context, name string, opts ...SpanOption) (Context, Span) {
+	var cfg spanConfig
+	for _, opt := range opts {
+		opt.applySpanStart(&cfg)
+	}
+	span := &recordedSpan{name: name, kind: cfg.SpanKind, attrs: make(map[string]any), parent: ctx}
+	r.spans = append(r.spans, span)
+	return WithSpan(ctx, span), span
+}
+
+func (r *spanRecorder) last() *recordedSpan {
+	return r.spans[len(r.spans)-1]
+}
+
+// metricRecorder is a Meter that sums counters and counts histogram records,
+// keyed by "<name>/<cache.operation>".
+type metricRecorder struct {
+	noOpMeter
+	values map[string]float64
+	mu     sync.Mutex
+}
+
+func newMetricRecorder() *metricRecorder {
+	return &metricRecorder{values: make(map[string]float64)}
+}
+
+func (m *metricRecorder) Counter(name string, _ ...MetricOption) Counter {
+	return recordFunc(func(value float64, attrs []Attribute) { m.add(name, value, attrs) })
+}
+
+func (m *metricRecorder) Histogram(name string, _ ...MetricOption) Histogram {
+	return recordFunc(func(_ float64, attrs []Attribute) { m.add(name, 1, attrs) })
+}
+
+func (m *metricRecorder) add(name string, value float64, attrs []Attribute) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, attr := range attrs {
+		if attr.Key == "cache.operation" {
+			name += "/" + attr.Value.(string)
+		}
+	}
+	m.values[name] += value
+}
+
+func (m *metricRecorder) value(key string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[key]
+}
+
+type recordFunc func(value float64, attrs []Attribute)
+
+func (f recordFunc) Add(_ context.Context, value int64, attrs ...Attribute) {
+	f(float64(value), attrs)
+}
+
+func (f recordFunc) Record(_ context.Context, value float64, attrs ...Attribute) {
+	f(value, attrs)
+}
+
+// warnLogger captures Warn calls.
+type warnLogger struct {
+	noopLogger
+	warnings []logCall
+}
+
+func (l *warnLogger) Warn(msg string, fields ...any) {
+	l.warnings = append(l.warnings, logCall{msg: msg, fields: fields})
+}
+
+func TestInstrumentCache_Spans(t *testing.T) {
+	ctx := context.Background()
+	tracer := &spanRecorder{}
+	cache := InstrumentCache(newStubCache(), tracer, nil, WithCacheName("users"))
+
+	_ = cache.Set(ctx, "a", []byte("1"), 0)
+	span := tracer.last()
+	if span.name != "cache.set" || span.kind != SpanKindClient || !span.ended {
+		t.Errorf("Set span = %q kind %d ended %v, want an ended client span cache.set", span.name, span.kind, span.ended)
+	}
+	if span.attrs["cache.operation"] != "set" || span.attrs["cache.name"] != "users" || span.attrs["cache.key_count"] != 1 {
+		t.Errorf("Set span attributes = %v", span.attrs)
+	}
+	if span.parent.DB() == nil {
+		t.Error("span started on a context without an Executor")
+	}
+
+	_, _ = cache.Get(ctx, "a")
+	if span := tracer.last(); span.name != "cache.get" || span.attrs["cache.hit"] != true {
+		t.Errorf("Get hit span = %q %v, want cache.get with cache.hit=true", span.name, span.attrs)
+	}
+
+	if _, err := cache.Get(ctx, "missing"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Get(missing) = %v, want ErrCacheMiss", err)
+	}
+	if span := tracer.last(); span.attrs["cache.hit"] != false || len(span.errs) != 0 {
+		t.Errorf("Get miss span = %v, errors %v, want cache.hit=false and no error", span.attrs, span.errs)
+	}
+
+	_, _ = cache.MGet(ctx, "a", "b", "c")
+	if span := tracer.last(); span.name != "cache.mget" || span.attrs["cache.key_count"] != 3 || span.attrs["cache.hit_count"] != 1 {
+		t.Errorf("MGet span = %q %v, want cache.mget with 3 keys and 1 hit", span.name, span.attrs)
+	}
+}
+
+func TestInstrumentCache_ParentContext(t *testing.T) {
+	tracer := &spanRecorder{}
+	cache := InstrumentCache(newStubCache(), tracer, nil)
+
+	hctx := New(context.Background(), NewNoOpLogger(), &noopExecutor{}, tracer, NewNoOpMeter())
+	_, _ = cache.Get(hctx, "a")
+	if tracer.last().parent != hctx {
+		t.Error("span not started on the caller's hyperion.Context")
+	}
+}
+
+func TestInstrumentCache_Metrics(t *testing.T) {
+	ctx := context.Background()
+	stub := newStubCache()
+	meter := newMetricRecorder()
+	tracer := &spanRecorder{}
+	cache := InstrumentCache(stub, tracer, meter)
+
+	_ = cache.Set(ctx, "a", []byte("1"), 0)
+	_, _ = cache.Get(ctx, "a")
+	_, _ = cache.Get(ctx, "missing")
+	_, _ = cache.MGet(ctx, "a", "b", "c")
+
+	errBroken := errors.New("broken")
+	stub.err = errBroken
+	if _, err := cache.Get(ctx, "a"); !errors.Is(err, errBroken) {
+		t.Fatalf("Get = %v, want %v", err, errBroken)
+	}
+	if errs := tracer.last().errs; len(errs) != 1 || errs[0] != errBroken {
+		t.Errorf("span errors = %v, want [%v]", errs, errBroken)
+	}
+
+	tests := map[string]float64{
+		"cache.hits/get":      1,
+		"cache.misses/get":    1,
+		"cache.hits/mget":     1,
+		"cache.misses/mget":   2,
+		"cache.errors/get":    1,
+		"cache.errors/set":    0,
+		"cache.duration/get":  3,
+		"cache.duration/set":  1,
+		"cache.duration/mget": 1,
+	}
+	for key, want := range tests {
+		if got := meter.value(key); got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestInstrumentCache_NoOpMiss(t *testing.T) {
+	meter := newMetricRecorder()
+	cache := InstrumentCache(NewNoOpCache(), &spanRecorder{}, meter)
+
+	if _, err := cache.Get(context.Background(), "a"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Get = %v, want ErrCacheMiss", err)
+	}
+	if got := meter.value("cache.errors/get"); got != 0 {
+		t.Errorf("cache.errors/get = %v, want 0", got)
+	}
+	if got := meter.value("cache.misses/get"); got != 1 {
+		t.Errorf("cache.misses/get = %v, want 1", got)
+	}
+}
+
+func TestInstrumentCache_SlowLog(t *testing.T) {
+	ctx := context.Background()
+	stub := newStubCache()
+	logger := &warnLogger{}
+	cache := InstrumentCache(stub, nil, nil, WithCacheLogger(logger), WithSlowCacheThreshold(10*time.Millisecond))
+
+	_, _ = cache.Get(ctx, "fast")
+	stub.delay = 20 * time.Millisecond
+	_, _ = cache.Get(ctx, "slow")
+
+	if len(logger.warnings) != 1 || logger.warnings[0].msg != "slow cache operation" {
+		t.Fatalf("warnings = %v, want one slow cache operation", logger.warnings)
+	}
+	if fields := logger.warnings[0].fields; fields[0] != "operation" || fields[1] != "get" {
+		t.Errorf("warning fields = %v, want operation=get first", fields)
+	}
+
+	// A zero threshold disables logging
+	cache = InstrumentCache(stub, nil, nil, WithCacheLogger(logger), WithSlowCacheThreshold(0))
+	_, _ = cache.Get(ctx, "slow")
+	if len(logger.warnings) != 1 {
+		t.Errorf("warnings = %d with logging disabled, want 1", len(logger.warnings))
+	}
+}
+
+func TestInstrumentCache_Forwarding(t *testing.T) {
+	stub := newStubCache()
+	cache := InstrumentCache(stub, nil, nil)
+
+	if err := cache.(interface{ Close() error }).Close(); err != nil || !stub.closed {
+		t.Errorf("Close = %v, closed %v, want forwarded to the wrapped cache", err, stub.closed)
+	}
+	if got := cache.(interface{ Unwrap() Cache }).Unwrap(); got != stub {
+		t.Errorf("Unwrap = %v, want the wrapped cache", got)
+	}
+}
+
+func TestInstrumentCache_Tagged(t *testing.T) {
+	ctx := context.Background()
+	tracer := &spanRecorder{}
+	cache := InstrumentCache(newStubCache(), tracer, nil).(TaggedCache)
+
+	if err := cache.SetWithTags(ctx, "a", []byte("1"), 0, "t"); err != nil {
+		t.Fatalf("SetWithTags failed: %v", err)
+	}
+	if err := cache.InvalidateTags(ctx, "t"); err != nil {
+		t.Fatalf("InvalidateTags failed: %v", err)
+	}
+	if span := tracer.last(); span.name != "cache.invalidate_tags" {
+		t.Errorf("span = %q, want cache.invalidate_tags", span.name)
+	}
+	if err := cache.DeletePrefix(ctx, "a"); !errors.Is(err, ErrDeletePrefixUnsupported) {
+		t.Errorf("DeletePrefix = %v, want ErrDeletePrefixUnsupported from the fallback", err)
+	}
+}
+
+// atomicStub adds AtomicCache methods to stubCache.
+type atomicStub struct {
+	*stubCache
+	n int64
+}
+
+func (c *atomicStub) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return c.IncrBy(ctx, key, 1, ttl)
+}
+
+func (c *atomicStub) IncrBy(_ context.Context, _ string, delta int64, _ time.Duration) (int64, error) {
+	c.n += delta
+	return c.n, c.err
+}
+
+func (c *atomicStub) SetNX(context.Context, string, []byte, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (c *atomicStub) CompareAndSwap(context.Context, string, []byte, []byte, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (c *atomicStub) Expire(context.Context, string, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (c *atomicStub) TTL(context.Context, string) (time.Duration, error) {
+	return time.Minute, nil
+}
+
+func (c *atomicStub) GetSet(context.Context, string, []byte, time.Duration) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func TestInstrumentCache_Atomic(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := InstrumentCache(newStubCache(), nil, nil).(AtomicCache); ok {
+		t.Error("instrumented non-atomic cache implements AtomicCache")
+	}
+
+	tracer := &spanRecorder{}
+	stub := &atomicStub{stubCache: newStubCache()}
+	cache, ok := InstrumentCache(stub, tracer, nil).(AtomicCache)
+	if !ok {
+		t.Fatal("instrumented atomic cache does not implement AtomicCache")
+	}
+
+	if n, err := cache.IncrBy(ctx, "counter", 5, 0); err != nil || n != 5 {
+		t.Errorf("IncrBy = %d, %v, want 5", n, err)
+	}
+	if span := tracer.last(); span.name != "cache.incr" {
+		t.Errorf("span = %q, want cache.incr", span.name)
+	}
+	if ttl, err := cache.TTL(ctx, "counter"); err != nil || ttl != time.Minute {
+		t.Errorf("TTL = %s, %v, want 1m", ttl, err)
+	}
+	if span := tracer.last(); span.name != "cache.ttl" {
+		t.Errorf("span = %q, want cache.ttl", span.name)
+	}
+	if _, ok := cache.(TaggedCache); !ok {
+		t.Error("instrumented atomic cache does not implement TaggedCache")
+	}
+}
+
+func TestCacheInstrumentationModule(t *testing.T) {
+	stub := newStubCache()
+	tracer := &spanRecorder{}
+	var seen Cache
+
+	app := fxtest.New(t,
+		fx.Provide(func() Cache { return stub }),
+		fx.Provide(func() Tracer { return tracer }),
+		CacheInstrumentationModule,
+		// Adapter modules see the decorated cache too
+		fx.Module("adapter", fx.Invoke(func(c Cache) { seen = c })),
+	)
+	app.RequireStart().RequireStop()
+
+	if _, ok := seen.(*instrumentedCache); !ok {
+		t.Fatalf("cache = %T, want the instrumented cache", seen)
+	}
+	_, _ = seen.Get(context.Background(), "key")
+	if len(tracer.spans) != 1 {
+		t.Errorf("spans = %d, want 1", len(tracer.spans))
+	}
+}
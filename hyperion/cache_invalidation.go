@@ -0,0 +1,89 @@
+package hyperion
+
+import (
+	"context"
+	"sync"
+)
+
+// Invalidation is a message telling cache instances to drop local entries.
+type Invalidation struct {
+	// Source identifies the publishing instance, so it can ignore its own messages.
+	Source string `json:"source"`
+
+	// Keys lists the keys to drop.
+	Keys []string `json:"keys,omitempty"`
+
+	// Tags lists tags whose keys to drop, after TaggedCache.InvalidateTags.
+	Tags []string `json:"tags,omitempty"`
+
+	// Prefixes lists key prefixes to drop, after TaggedCache.DeletePrefix.
+	Prefixes []string `json:"prefixes,omitempty"`
+
+	// All drops every entry, after a Clear.
+	All bool `json:"all,omitempty"`
+}
+
+// InvalidationBus broadcasts Invalidations between instances sharing a
+// cache, so each can evict stale entries from its in-process tier.
+//
+// NewMemoryBus connects caches within one process; adapter/redis provides
+// a bus on Redis pub/sub for multiple processes. Delivery is best-effort:
+// messages published while a subscriber is disconnected may be lost, which
+// the short L1 TTL of TieredCache bounds.
+type InvalidationBus interface {
+	// Publish sends msg to every subscriber, including the publisher's own.
+	Publish(ctx context.Context, msg Invalidation) error
+
+	// Subscribe calls handler for every message published after it returns.
+	// Calling stop ends the subscription.
+	Subscribe(handler func(Invalidation)) (stop func(), err error)
+}
+
+// MemoryBus is an InvalidationBus within a single process.
+// Handlers are called synchronously by Publish.
+type MemoryBus struct {
+	handlers map[int]func(Invalidation)
+	nextID   int
+	mu       sync.RWMutex
+}
+
+// Ensure MemoryBus implements InvalidationBus interface.
+var _ InvalidationBus = (*MemoryBus)(nil)
+
+// NewMemoryBus creates an in-process InvalidationBus.
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{handlers: make(map[int]func(Invalidation))}
+}
+
+// Publish calls every subscribed handler with msg.
+func (b *MemoryBus) Publish(_ context.Context, msg Invalidation) error {
+	b.mu.RLock()
+	handlers := make([]func(Invalidation), 0, len(b.handlers))
+	for _, h := range b.handlers {
+		handlers = append(handlers, h)
+	}
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(msg)
+	}
+	return nil
+}
+
+// Subscribe registers handler until stop is called.
+func (b *MemoryBus) Subscribe(handler func(Invalidation)) (func(), error) {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.handlers, id)
+			b.mu.Unlock()
+		})
+	}, nil
+}
@@ -3,12 +3,18 @@ package hyperion
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 )
 
 var (
 	// ErrNoOpCache is returned by no-op cache operations.
 	ErrNoOpCache = errors.New("no-op cache: no adapter provided")
+
+	// errNoOpCacheMiss is returned by the no-op Get. It matches both
+	// ErrNoOpCache and ErrCacheMiss, so callers following the Cache
+	// contract treat it as a miss.
+	errNoOpCacheMiss = fmt.Errorf("%w: %w", ErrNoOpCache, ErrCacheMiss)
 )
 
 // noopCache is a no-op implementation of Cache interface.
@@ -20,7 +26,7 @@ func NewNoOpCache() Cache {
 }
 
 func (c *noopCache) Get(ctx context.Context, key string) ([]byte, error) {
-	return nil, ErrNoOpCache
+	return nil, errNoOpCacheMiss
 }
 
 func (c *noopCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
@@ -0,0 +1,183 @@
+package hyperion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrDeletePrefixUnsupported is returned by the DeletePrefix of the
+// AsTaggedCache fallback, since a plain Cache cannot list its keys.
+var ErrDeletePrefixUnsupported = errors.New("cache does not support DeletePrefix")
+
+// tagIndexPrefix namespaces the tag indexes kept by the AsTaggedCache
+// fallback. The leading NUL byte keeps them apart from application keys.
+const tagIndexPrefix = "\x00hyperion:tag:"
+
+// tagIndexSlack pads the expiry of tag index members, so latency and
+// clock drift between the process and the cache never drop a member, or
+// expire the index, while the member's key is still stored.
+const tagIndexSlack = time.Second
+
+// TaggedCache is an optional Cache extension for evicting groups of keys.
+//
+// Tags name the entities a value was derived from, so every derived key
+// can be evicted at once when the entity changes:
+//
+//	tc := hyperion.AsTaggedCache(cache)
+//	_ = tc.SetWithTags(ctx, "user:42:profile", data, time.Hour, "user:42")
+//	_ = tc.SetWithTags(ctx, "team:7:members", data, time.Hour, "user:42", "team:7")
+//
+//	// After updating user 42
+//	_ = tc.InvalidateTags(ctx, "user:42")
+//
+// Invalidating a tag removes every key set with it. Implementations may
+// keep the association after a key is overwritten with Set or deleted, so
+// an invalidation can remove such a key too, but never misses one.
+type TaggedCache interface {
+	Cache
+
+	// SetWithTags stores value like Set and associates key with tags.
+	SetWithTags(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error
+
+	// InvalidateTags removes every key associated with any of tags.
+	InvalidateTags(ctx context.Context, tags ...string) error
+
+	// DeletePrefix removes every key starting with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// AsTaggedCache returns cache as a TaggedCache.
+//
+// Caches that implement TaggedCache, such as the memory and redis adapters,
+// are returned unchanged. Others are wrapped in a portable fallback that
+// stores each tag's keys in an index entry of the cache itself. The
+// fallback's index updates are not atomic across processes, and its
+// DeletePrefix returns ErrDeletePrefixUnsupported. An index expires with
+// its longest-lived key, and drops expired keys whenever it is rewritten.
+func AsTaggedCache(cache Cache) TaggedCache {
+	if tc, ok := cache.(TaggedCache); ok {
+		return tc
+	}
+	return &tagIndexCache{Cache: cache, now: time.Now}
+}
+
+// tagIndexCache implements TaggedCache on any Cache with per-tag key indexes.
+type tagIndexCache struct {
+	Cache
+	now func() time.Time
+	mu  sync.Mutex
+}
+
+// tagIndexMember is a key recorded in a tag index.
+type tagIndexMember struct {
+	Key string `json:"key"`
+
+	// Expires is when the key expires, in Unix nanoseconds; 0 means never.
+	Expires int64 `json:"expires,omitempty"`
+}
+
+// Ensure tagIndexCache implements TaggedCache interface.
+var _ TaggedCache = (*tagIndexCache)(nil)
+
+// index returns the keys recorded for tag.
+func (c *tagIndexCache) index(ctx context.Context, tag string) ([]tagIndexMember, error) {
+	data, err := c.Cache.Get(ctx, tagIndexPrefix+tag)
+	if errors.Is(err, ErrCacheMiss) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var members []tagIndexMember
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, fmt.Errorf("failed to decode index of tag %s: %w", tag, err)
+	}
+	return members, nil
+}
+
+// SetWithTags stores value, then records key in the index of each tag.
+// Rewriting an index drops its expired keys, and its TTL covers the
+// longest-lived key left.
+func (c *tagIndexCache) SetWithTags(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	if err := c.Cache.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+
+	now := c.now()
+	member := tagIndexMember{Key: key}
+	if ttl > 0 {
+		member.Expires = now.Add(ttl + tagIndexSlack).UnixNano()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, tag := range tags {
+		members, err := c.index(ctx, tag)
+		if err != nil {
+			return err
+		}
+		members = slices.DeleteFunc(members, func(m tagIndexMember) bool {
+			return m.Key == key || (m.Expires != 0 && m.Expires <= now.UnixNano())
+		})
+		members = append(members, member)
+
+		// The index lives as long as its longest-lived member
+		var expires int64
+		for _, m := range members {
+			if m.Expires == 0 {
+				expires = 0
+				break
+			}
+			expires = max(expires, m.Expires)
+		}
+		var indexTTL time.Duration
+		if expires != 0 {
+			indexTTL = time.Duration(expires - now.UnixNano())
+		}
+
+		data, err := json.Marshal(members)
+		if err != nil {
+			return fmt.Errorf("failed to encode index of tag %s: %w", tag, err)
+		}
+		if err := c.Cache.Set(ctx, tagIndexPrefix+tag, data, indexTTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InvalidateTags deletes the keys in each tag's index, then the index.
+func (c *tagIndexCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, tag := range tags {
+		members, err := c.index(ctx, tag)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			if err := c.Cache.Delete(ctx, m.Key); err != nil {
+				return err
+			}
+		}
+		if err := c.Cache.Delete(ctx, tagIndexPrefix+tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeletePrefix returns ErrDeletePrefixUnsupported.
+func (c *tagIndexCache) DeletePrefix(context.Context, string) error {
+	return ErrDeletePrefixUnsupported
+}
+
+// Unwrap returns the wrapped cache.
+func (c *tagIndexCache) Unwrap() Cache {
+	return c.Cache
+}
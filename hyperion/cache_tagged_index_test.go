@@ -0,0 +1,76 @@
+package hyperion
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+// ttlStubCache is a stubCache recording the TTL of each Set.
+type ttlStubCache struct {
+	*stubCache
+	ttls map[string]time.Duration
+}
+
+func (c *ttlStubCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.ttls[key] = ttl
+	return c.stubCache.Set(ctx, key, value, ttl)
+}
+
+func TestTagIndexCache_IndexExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(0, 0)
+	inner := &ttlStubCache{stubCache: newStubCache(), ttls: make(map[string]time.Duration)}
+	cache := &tagIndexCache{Cache: inner, now: func() time.Time { return now }}
+	index := tagIndexPrefix + "user:1"
+
+	members := func() []string {
+		t.Helper()
+		var decoded []tagIndexMember
+		if err := json.Unmarshal(inner.items[index], &decoded); err != nil {
+			t.Fatalf("failed to decode index: %v", err)
+		}
+		keys := make([]string, 0, len(decoded))
+		for _, m := range decoded {
+			keys = append(keys, m.Key)
+		}
+		return keys
+	}
+
+	if err := cache.SetWithTags(ctx, "a", []byte("1"), time.Minute, "user:1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.SetWithTags(ctx, "b", []byte("2"), time.Hour, "user:1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := inner.ttls[index]; got < time.Hour {
+		t.Errorf("index TTL = %s, want at least the longest member TTL (1h)", got)
+	}
+
+	// Rewriting the index after a expired drops it, and a shorter-lived
+	// member does not shorten the index
+	now = now.Add(2 * time.Minute)
+	if err := cache.SetWithTags(ctx, "c", []byte("3"), time.Second, "user:1"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := members(), []string{"b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("index members = %v, want %v", got, want)
+	}
+	if got := inner.ttls[index]; got < time.Hour-2*time.Minute {
+		t.Errorf("index TTL = %s, want at least b's remaining TTL", got)
+	}
+
+	// A key rewritten with tags is recorded once, and a member without
+	// expiration keeps the index forever
+	if err := cache.SetWithTags(ctx, "b", []byte("2"), 0, "user:1"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := members(), []string{"c", "b"}; !slices.Equal(got, want) {
+		t.Errorf("index members = %v, want %v", got, want)
+	}
+	if got := inner.ttls[index]; got != 0 {
+		t.Errorf("index TTL = %s, want 0 (no expiration)", got)
+	}
+}
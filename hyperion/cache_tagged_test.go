@@ -0,0 +1,56 @@
+package hyperion_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/hyperiontest"
+)
+
+func TestAsTaggedCache_Fallback(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	hyperiontest.RunTaggedCacheSuite(t, hyperiontest.CacheHarness{
+		New: func(t *testing.T) hyperion.Cache {
+			return hyperion.AsTaggedCache(newClockCache(clock))
+		},
+		Advance: clock.Advance,
+	})
+
+	cache := hyperion.AsTaggedCache(newClockCache(clock))
+	if err := cache.DeletePrefix(context.Background(), "a"); !errors.Is(err, hyperion.ErrDeletePrefixUnsupported) {
+		t.Errorf("DeletePrefix = %v, want ErrDeletePrefixUnsupported", err)
+	}
+}
+
+func TestAsTaggedCache_Native(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	tiered, err := hyperion.NewTieredCache(newClockCache(clock), newClockCache(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hyperion.AsTaggedCache(tiered); got != hyperion.TaggedCache(tiered) {
+		t.Errorf("AsTaggedCache wrapped a TaggedCache: %T", got)
+	}
+}
+
+func TestAsTaggedCache_IndexErrors(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	inner := newClockCache(clock)
+	cache := hyperion.AsTaggedCache(inner)
+
+	if err := cache.SetWithTags(ctx, "key", []byte("v"), 0, "tag"); err != nil {
+		t.Fatal(err)
+	}
+	errDown := errors.New("down")
+	inner.setErr(errDown)
+	if err := cache.InvalidateTags(ctx, "tag"); !errors.Is(err, errDown) {
+		t.Errorf("InvalidateTags = %v, want %v", err, errDown)
+	}
+	if err := cache.SetWithTags(ctx, "key", []byte("v"), 0, "tag"); !errors.Is(err, errDown) {
+		t.Errorf("SetWithTags = %v, want %v", err, errDown)
+	}
+}
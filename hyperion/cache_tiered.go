@@ -0,0 +1,377 @@
+package hyperion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// DefaultL1TTL is the longest time TieredCache keeps an entry in L1.
+const DefaultL1TTL = time.Minute
+
+// tieredTagsPrefix namespaces the L2 entries holding the tags of a key
+// written with TieredCache.SetWithTags. The entry carries the same tags and
+// TTL as the key, and is removed with the key. Writes without tags leave it
+// in place to save a round trip; it records a checksum of the tagged value
+// so it is ignored once the key holds another value. Rewriting the same
+// bytes without tags keeps the old tags, which at worst evicts L1 copies
+// early.
+const tieredTagsPrefix = "\x00hyperion:tags:"
+
+// tieredTags is the L2 entry stored under tieredTagsPrefix+key.
+type tieredTags struct {
+	Tags []string `json:"tags"`
+	Sum  uint64   `json:"sum"`
+}
+
+// valueSum returns the checksum tieredTags uses to recognise its value.
+func valueSum(value []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(value)
+	return h.Sum64()
+}
+
+// TieredCache is a Cache that composes a fast, in-process L1 with a shared L2.
+//
+// Reads try L1 first and fall back to L2, copying L2 hits into L1. Writes
+// go to L2 and then L1, where the TTL is capped at the L1 TTL so entries
+// changed by other instances are refreshed within that bound. When an
+// InvalidationBus is configured, every write, delete and clear is broadcast
+// so other instances evict their L1 copies right away.
+//
+// L2 is the source of truth: its errors are returned, while L1 errors are
+// treated as misses.
+//
+// TieredCache implements TaggedCache on both tiers through AsTaggedCache,
+// and broadcasts tag and prefix invalidations like deletes.
+//
+// Example:
+//
+//	bus := redis.NewInvalidationBus(client, "cache:invalidate")
+//	cache, err := hyperion.NewTieredCache(local, shared,
+//	    hyperion.WithL1TTL(30*time.Second),
+//	    hyperion.WithInvalidationBus(bus),
+//	)
+//	defer cache.Close()
+type TieredCache struct {
+	l1    TaggedCache
+	l2    TaggedCache
+	bus   InvalidationBus
+	l1TTL time.Duration
+	id    string
+	stop  func()
+	once  sync.Once
+}
+
+// Ensure TieredCache implements TaggedCache interface.
+var _ TaggedCache = (*TieredCache)(nil)
+
+// TieredCacheOption configures a TieredCache.
+type TieredCacheOption func(*tieredCacheConfig)
+
+type tieredCacheConfig struct {
+	bus   InvalidationBus
+	l1TTL time.Duration
+}
+
+// WithL1TTL sets the longest time an entry stays in L1. Defaults to DefaultL1TTL.
+func WithL1TTL(ttl time.Duration) TieredCacheOption {
+	return func(cfg *tieredCacheConfig) {
+		cfg.l1TTL = ttl
+	}
+}
+
+// WithInvalidationBus broadcasts writes, deletes and clears through bus,
+// and evicts L1 entries on messages from other instances.
+func WithInvalidationBus(bus InvalidationBus) TieredCacheOption {
+	return func(cfg *tieredCacheConfig) {
+		cfg.bus = bus
+	}
+}
+
+// NewTieredCache creates a TieredCache over l1 and l2.
+// Close stops the bus subscription; it does not close l1 or l2.
+func NewTieredCache(l1, l2 Cache, opts ...TieredCacheOption) (*TieredCache, error) {
+	cfg := tieredCacheConfig{l1TTL: DefaultL1TTL}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.l1TTL <= 0 {
+		return nil, fmt.Errorf("L1 TTL must be positive, got %s", cfg.l1TTL)
+	}
+
+	c := &TieredCache{
+		l1:    AsTaggedCache(l1),
+		l2:    AsTaggedCache(l2),
+		bus:   cfg.bus,
+		l1TTL: cfg.l1TTL,
+		id:    fmt.Sprintf("%016x", rand.Uint64()),
+	}
+	if c.bus != nil {
+		stop, err := c.bus.Subscribe(c.onInvalidation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe to invalidation bus: %w", err)
+		}
+		c.stop = stop
+	}
+	return c, nil
+}
+
+// onInvalidation evicts the L1 entries named by a message from another instance.
+func (c *TieredCache) onInvalidation(msg Invalidation) {
+	if msg.Source == c.id {
+		return
+	}
+	ctx := context.Background()
+	if msg.All {
+		_ = c.l1.Clear(ctx)
+		return
+	}
+	for _, key := range msg.Keys {
+		_ = c.l1.Delete(ctx, key)
+	}
+	if len(msg.Tags) > 0 {
+		_ = c.l1.InvalidateTags(ctx, msg.Tags...)
+	}
+	for _, prefix := range msg.Prefixes {
+		_ = c.l1.DeletePrefix(ctx, prefix)
+	}
+}
+
+// publish broadcasts an invalidation, if a bus is configured.
+func (c *TieredCache) publish(ctx context.Context, msg Invalidation) error {
+	if c.bus == nil {
+		return nil
+	}
+	msg.Source = c.id
+	if err := c.bus.Publish(ctx, msg); err != nil {
+		return fmt.Errorf("failed to publish cache invalidation: %w", err)
+	}
+	return nil
+}
+
+// localTTL caps ttl at the L1 TTL; 0 (no expiration) also becomes the L1 TTL.
+func (c *TieredCache) localTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 || ttl > c.l1TTL {
+		return c.l1TTL
+	}
+	return ttl
+}
+
+// setLocal writes to L1. On failure the key is dropped from L1 instead,
+// so an older value cannot outlive the write.
+func (c *TieredCache) setLocal(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if err := c.l1.Set(ctx, key, value, c.localTTL(ttl)); err != nil {
+		_ = c.l1.Delete(ctx, key)
+	}
+}
+
+// Get returns the value from L1, or from L2 and copies it into L1.
+func (c *TieredCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if value, err := c.l1.Get(ctx, key); err == nil {
+		return value, nil
+	}
+	found, err := c.readThrough(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	value, ok := found[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	return value, nil
+}
+
+// readThrough fetches keys from L2 and copies the values found into L1.
+// Each key's tags are fetched in the same MGet, so the L1 copy can be
+// evicted by a tag invalidation from another instance. Tags recorded for
+// another value of the key are ignored.
+func (c *TieredCache) readThrough(ctx context.Context, keys []string) (map[string][]byte, error) {
+	lookup := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		lookup = append(lookup, key, tieredTagsPrefix+key)
+	}
+	found, err := c.l2.MGet(ctx, lookup...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]byte, len(keys))
+	untagged := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		value, ok := found[key]
+		if !ok {
+			continue
+		}
+		result[key] = value
+
+		var entry tieredTags
+		if data, ok := found[tieredTagsPrefix+key]; ok {
+			_ = json.Unmarshal(data, &entry)
+		}
+		tags := entry.Tags
+		if entry.Sum != valueSum(value) {
+			tags = nil
+		}
+		if len(tags) == 0 {
+			untagged[key] = value
+		} else if err := c.l1.SetWithTags(ctx, key, value, c.l1TTL, tags...); err != nil {
+			_ = c.l1.Delete(ctx, key)
+		}
+	}
+	if len(untagged) > 0 {
+		if err := c.l1.MSet(ctx, untagged, c.l1TTL); err != nil {
+			for key := range untagged {
+				_ = c.l1.Delete(ctx, key)
+			}
+		}
+	}
+	return result, nil
+}
+
+// Set writes value to L2 and L1, and invalidates key on other instances.
+// If only the broadcast fails, the value is stored and the error is returned.
+func (c *TieredCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.l2.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	c.setLocal(ctx, key, value, ttl)
+	return c.publish(ctx, Invalidation{Keys: []string{key}})
+}
+
+// Delete removes key from L2 and L1, and invalidates it on other instances.
+func (c *TieredCache) Delete(ctx context.Context, key string) error {
+	if err := c.l2.Delete(ctx, key); err != nil {
+		return err
+	}
+	if err := c.l2.Delete(ctx, tieredTagsPrefix+key); err != nil {
+		return err
+	}
+	_ = c.l1.Delete(ctx, key)
+	return c.publish(ctx, Invalidation{Keys: []string{key}})
+}
+
+// Exists reports whether key is in L1 or L2.
+func (c *TieredCache) Exists(ctx context.Context, key string) (bool, error) {
+	if ok, err := c.l1.Exists(ctx, key); err == nil && ok {
+		return true, nil
+	}
+	return c.l2.Exists(ctx, key)
+}
+
+// MGet returns the values found in L1, and fetches the rest from L2,
+// copying them into L1.
+func (c *TieredCache) MGet(ctx context.Context, keys ...string) (map[string][]byte, error) {
+	result, err := c.l1.MGet(ctx, keys...)
+	if err != nil || result == nil {
+		result = make(map[string][]byte, len(keys))
+	}
+
+	missing := make([]string, 0, len(keys)-len(result))
+	for _, key := range keys {
+		if _, ok := result[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	found, err := c.readThrough(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range found {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// MSet writes items to L2 and L1, and invalidates them on other instances.
+func (c *TieredCache) MSet(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	if len(items) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	if err := c.l2.MSet(ctx, items, ttl); err != nil {
+		return err
+	}
+	if err := c.l1.MSet(ctx, items, c.localTTL(ttl)); err != nil {
+		for _, key := range keys {
+			_ = c.l1.Delete(ctx, key)
+		}
+	}
+	return c.publish(ctx, Invalidation{Keys: keys})
+}
+
+// Clear empties L2 and L1, and clears L1 on other instances.
+func (c *TieredCache) Clear(ctx context.Context) error {
+	if err := c.l2.Clear(ctx); err != nil {
+		return err
+	}
+	_ = c.l1.Clear(ctx)
+	return c.publish(ctx, Invalidation{All: true})
+}
+
+// SetWithTags writes value with tags to L2 and L1, and invalidates key on
+// other instances. The tags are also stored in L2 next to the value, for
+// other instances reading the key into their L1. That entry is tagged
+// itself, so InvalidateTags removes it with the key.
+func (c *TieredCache) SetWithTags(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	if len(tags) > 0 {
+		data, err := json.Marshal(tieredTags{Tags: tags, Sum: valueSum(value)})
+		if err != nil {
+			return fmt.Errorf("failed to encode tags of %s: %w", key, err)
+		}
+		if err := c.l2.SetWithTags(ctx, tieredTagsPrefix+key, data, ttl, tags...); err != nil {
+			return err
+		}
+	}
+	if err := c.l2.SetWithTags(ctx, key, value, ttl, tags...); err != nil {
+		return err
+	}
+	if err := c.l1.SetWithTags(ctx, key, value, c.localTTL(ttl), tags...); err != nil {
+		_ = c.l1.Delete(ctx, key)
+	}
+	return c.publish(ctx, Invalidation{Keys: []string{key}})
+}
+
+// InvalidateTags removes the tagged keys from L2 and L1, and from L1 on
+// other instances.
+func (c *TieredCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	if err := c.l2.InvalidateTags(ctx, tags...); err != nil {
+		return err
+	}
+	_ = c.l1.InvalidateTags(ctx, tags...)
+	return c.publish(ctx, Invalidation{Tags: tags})
+}
+
+// DeletePrefix removes the keys starting with prefix from L2 and L1, and
+// from L1 on other instances.
+func (c *TieredCache) DeletePrefix(ctx context.Context, prefix string) error {
+	if err := c.l2.DeletePrefix(ctx, prefix); err != nil {
+		return err
+	}
+	if err := c.l2.DeletePrefix(ctx, tieredTagsPrefix+prefix); err != nil {
+		return err
+	}
+	_ = c.l1.DeletePrefix(ctx, prefix)
+	return c.publish(ctx, Invalidation{Prefixes: []string{prefix}})
+}
+
+// Close stops listening for invalidations. It is safe to call more than once.
+func (c *TieredCache) Close() error {
+	c.once.Do(func() {
+		if c.stop != nil {
+			c.stop()
+		}
+	})
+	return nil
+}
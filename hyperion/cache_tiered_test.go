@@ -0,0 +1,628 @@
+package hyperion_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/hyperiontest"
+)
+
+// fakeClock is a manually advanced clock shared by clockCaches.
+type fakeClock struct {
+	now time.Time
+	mu  sync.Mutex
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type clockEntry struct {
+	expires time.Time
+	value   []byte
+	ttl     time.Duration
+}
+
+// clockCache is a complete hyperion.Cache with TTLs driven by a fakeClock.
+type clockCache struct {
+	clock *fakeClock
+	items map[string]clockEntry
+	err   error
+	mu    sync.Mutex
+}
+
+func newClockCache(clock *fakeClock) *clockCache {
+	return &clockCache{clock: clock, items: make(map[string]clockEntry)}
+}
+
+func (c *clockCache) lookup(key string) (clockEntry, bool) {
+	e, ok := c.items[key]
+	if ok && !e.expires.IsZero() && !c.clock.Now().Before(e.expires) {
+		delete(c.items, key)
+		return clockEntry{}, false
+	}
+	return e, ok
+}
+
+func (c *clockCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	if e, ok := c.lookup(key); ok {
+		return append([]byte(nil), e.value...), nil
+	}
+	return nil, hyperion.ErrCacheMiss
+}
+
+func (c *clockCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	e := clockEntry{value: append([]byte(nil), value...), ttl: ttl}
+	if ttl > 0 {
+		e.expires = c.clock.Now().Add(ttl)
+	}
+	c.items[key] = e
+	return nil
+}
+
+func (c *clockCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	delete(c.items, key)
+	return nil
+}
+
+func (c *clockCache) Exists(_ context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return false, c.err
+	}
+	_, ok := c.lookup(key)
+	return ok, nil
+}
+
+func (c *clockCache) MGet(ctx context.Context, keys ...string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		value, err := c.Get(ctx, key)
+		if errors.Is(err, hyperion.ErrCacheMiss) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+func (c *clockCache) MSet(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	for key, value := range items {
+		if err := c.Set(ctx, key, value, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *clockCache) Clear(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.items = make(map[string]clockEntry)
+	return nil
+}
+
+func (c *clockCache) entry(key string) (clockEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lookup(key)
+}
+
+func (c *clockCache) setErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+func newTiered(t *testing.T, l1, l2 hyperion.Cache, opts ...hyperion.TieredCacheOption) *hyperion.TieredCache {
+	t.Helper()
+	cache, err := hyperion.NewTieredCache(l1, l2, opts...)
+	if err != nil {
+		t.Fatalf("NewTieredCache failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cache.Close() })
+	return cache
+}
+
+func TestTieredCache_Conformance(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	hyperiontest.RunCacheSuite(t, hyperiontest.CacheHarness{
+		New: func(t *testing.T) hyperion.Cache {
+			return newTiered(t, newClockCache(clock), newClockCache(clock),
+				hyperion.WithInvalidationBus(hyperion.NewMemoryBus()))
+		},
+		Advance: clock.Advance,
+	})
+}
+
+func TestTieredCache_TaggedConformance(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	hyperiontest.RunTaggedCacheSuite(t, hyperiontest.CacheHarness{
+		New: func(t *testing.T) hyperion.Cache {
+			return newTiered(t, newClockCache(clock), newClockCache(clock))
+		},
+		Advance: clock.Advance,
+	})
+}
+
+func TestTieredCache_TagInvalidation(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	bus := hyperion.NewMemoryBus()
+	shared := newClockCache(clock)
+	a := newTiered(t, newClockCache(clock), shared, hyperion.WithInvalidationBus(bus))
+	b := newTiered(t, newClockCache(clock), shared, hyperion.WithInvalidationBus(bus))
+
+	if err := a.SetWithTags(ctx, "user:1:profile", []byte("p"), 0, "user:1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Get(ctx, "user:1:profile"); err != nil {
+		t.Fatal(err)
+	}
+
+	// b's L1 copy came from a read-through, which copied its tags from L2
+	if err := a.InvalidateTags(ctx, "user:1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Get(ctx, "user:1:profile"); !errors.Is(err, hyperion.ErrCacheMiss) {
+		t.Errorf("Get after InvalidateTags = %v, want ErrCacheMiss", err)
+	}
+}
+
+// taggedClockCache is a TaggedCache over clockCache that forgets a key's
+// tags when the key is overwritten or deleted.
+type taggedClockCache struct {
+	*clockCache
+	tags map[string][]string
+	mu   sync.Mutex
+}
+
+func newTaggedClockCache(clock *fakeClock) *taggedClockCache {
+	return &taggedClockCache{clockCache: newClockCache(clock), tags: make(map[string][]string)}
+}
+
+func (c *taggedClockCache) forget(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.tags, key)
+	}
+}
+
+func (c *taggedClockCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.forget(key)
+	return c.clockCache.Set(ctx, key, value, ttl)
+}
+
+func (c *taggedClockCache) MSet(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	for key, value := range items {
+		if err := c.Set(ctx, key, value, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *taggedClockCache) Delete(ctx context.Context, key string) error {
+	c.forget(key)
+	return c.clockCache.Delete(ctx, key)
+}
+
+func (c *taggedClockCache) SetWithTags(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	if err := c.clockCache.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tags[key] = tags
+	return nil
+}
+
+func (c *taggedClockCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	c.mu.Lock()
+	var keys []string
+	for key, keyTags := range c.tags {
+		for _, tag := range tags {
+			if slices.Contains(keyTags, tag) {
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	c.mu.Unlock()
+	for _, key := range keys {
+		if err := c.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *taggedClockCache) DeletePrefix(ctx context.Context, prefix string) error {
+	c.clockCache.mu.Lock()
+	var keys []string
+	for key := range c.clockCache.items {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	c.clockCache.mu.Unlock()
+	for _, key := range keys {
+		if err := c.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestTieredCache_StaleTags(t *testing.T) {
+	ctx := context.Background()
+	removals := map[string]func(c *hyperion.TieredCache) error{
+		"None":           func(*hyperion.TieredCache) error { return nil },
+		"Delete":         func(c *hyperion.TieredCache) error { return c.Delete(ctx, "user:1:profile") },
+		"InvalidateTags": func(c *hyperion.TieredCache) error { return c.InvalidateTags(ctx, "user:1") },
+		"DeletePrefix":   func(c *hyperion.TieredCache) error { return c.DeletePrefix(ctx, "user:1:") },
+	}
+	writes := map[string]func(c *hyperion.TieredCache) error{
+		"Set": func(c *hyperion.TieredCache) error { return c.Set(ctx, "user:1:profile", []byte("plain"), 0) },
+		"MSet": func(c *hyperion.TieredCache) error {
+			return c.MSet(ctx, map[string][]byte{"user:1:profile": []byte("plain")}, 0)
+		},
+		"SetWithTags": func(c *hyperion.TieredCache) error {
+			return c.SetWithTags(ctx, "user:1:profile", []byte("plain"), 0)
+		},
+	}
+
+	for removeName, remove := range removals {
+		for writeName, write := range writes {
+			t.Run(removeName+"/"+writeName, func(t *testing.T) {
+				clock := &fakeClock{now: time.Unix(0, 0)}
+				shared := newTaggedClockCache(clock)
+				local := newTaggedClockCache(clock)
+				bus := hyperion.NewMemoryBus()
+				a := newTiered(t, newTaggedClockCache(clock), shared, hyperion.WithInvalidationBus(bus))
+				b := newTiered(t, local, shared, hyperion.WithInvalidationBus(bus))
+
+				if err := a.SetWithTags(ctx, "user:1:profile", []byte("tagged"), 0, "user:1"); err != nil {
+					t.Fatal(err)
+				}
+				if err := remove(a); err != nil {
+					t.Fatal(err)
+				}
+				if err := write(a); err != nil {
+					t.Fatal(err)
+				}
+
+				// b reads the untagged value through into its L1
+				if got, err := b.Get(ctx, "user:1:profile"); err != nil || string(got) != "plain" {
+					t.Fatalf("Get = %q, %v, want plain", got, err)
+				}
+				if err := a.InvalidateTags(ctx, "user:1"); err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := local.entry("user:1:profile"); !ok {
+					t.Error("InvalidateTags evicted a key no longer tagged, using stale L2 tags")
+				}
+				if got, err := a.Get(ctx, "user:1:profile"); err != nil || string(got) != "plain" {
+					t.Errorf("Get after InvalidateTags = %q, %v, want plain", got, err)
+				}
+			})
+		}
+	}
+}
+
+// countingCache is a TaggedCache counting the calls made to it.
+type countingCache struct {
+	hyperion.TaggedCache
+	calls map[string]int
+	mu    sync.Mutex
+}
+
+func (c *countingCache) count(op string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[op]++
+}
+
+func (c *countingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.count("Set")
+	return c.TaggedCache.Set(ctx, key, value, ttl)
+}
+
+func (c *countingCache) Delete(ctx context.Context, key string) error {
+	c.count("Delete")
+	return c.TaggedCache.Delete(ctx, key)
+}
+
+func (c *countingCache) MSet(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	c.count("MSet")
+	return c.TaggedCache.MSet(ctx, items, ttl)
+}
+
+func (c *countingCache) SetWithTags(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	c.count("SetWithTags")
+	return c.TaggedCache.SetWithTags(ctx, key, value, ttl, tags...)
+}
+
+func TestTieredCache_UntaggedWritesRoundTrips(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	shared := &countingCache{TaggedCache: newTaggedClockCache(clock), calls: make(map[string]int)}
+	cache := newTiered(t, newTaggedClockCache(clock), shared)
+
+	if err := cache.Set(ctx, "a", []byte("1"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.MSet(ctx, map[string][]byte{"b": []byte("2"), "c": []byte("3")}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.SetWithTags(ctx, "d", []byte("4"), 0); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"Set": 1, "MSet": 1, "SetWithTags": 1}
+	if !reflect.DeepEqual(shared.calls, want) {
+		t.Errorf("L2 calls = %v, want %v", shared.calls, want)
+	}
+}
+
+func TestTieredCache_ReadThrough(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l1, l2 := newClockCache(clock), newClockCache(clock)
+	cache := newTiered(t, l1, l2, hyperion.WithL1TTL(30*time.Second))
+
+	if err := l2.Set(ctx, "key", []byte("value"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	got, err := cache.Get(ctx, "key")
+	if err != nil || string(got) != "value" {
+		t.Fatalf("Get = %q, %v, want value", got, err)
+	}
+	if e, ok := l1.entry("key"); !ok || e.ttl != 30*time.Second {
+		t.Errorf("L1 entry after Get = %+v, %v, want backfill with the L1 TTL", e, ok)
+	}
+
+	// Served from L1 while L2 is down
+	l2.setErr(errors.New("l2 down"))
+	if got, err := cache.Get(ctx, "key"); err != nil || string(got) != "value" {
+		t.Errorf("Get from L1 = %q, %v, want value", got, err)
+	}
+	if _, err := cache.Get(ctx, "other"); err == nil || errors.Is(err, hyperion.ErrCacheMiss) {
+		t.Errorf("Get with L2 down = %v, want the L2 error", err)
+	}
+	l2.setErr(nil)
+
+	// MGet fills only the keys missing from L1
+	if err := l2.MSet(ctx, map[string][]byte{"a": []byte("1"), "b": []byte("2")}, 0); err != nil {
+		t.Fatal(err)
+	}
+	values, err := cache.MGet(ctx, "key", "a", "b", "missing")
+	if err != nil || len(values) != 3 || string(values["a"]) != "1" {
+		t.Fatalf("MGet = %q, %v, want key, a and b", values, err)
+	}
+	if _, ok := l1.entry("b"); !ok {
+		t.Error("MGet did not backfill L1")
+	}
+
+	// L1 failures are treated as misses
+	l1.setErr(errors.New("l1 down"))
+	if got, err := cache.Get(ctx, "a"); err != nil || string(got) != "1" {
+		t.Errorf("Get with L1 down = %q, %v, want 1", got, err)
+	}
+}
+
+func TestTieredCache_WriteThrough(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l1, l2 := newClockCache(clock), newClockCache(clock)
+	cache := newTiered(t, l1, l2, hyperion.WithL1TTL(30*time.Second))
+
+	tests := []struct {
+		ttl, wantL1 time.Duration
+	}{
+		{ttl: time.Hour, wantL1: 30 * time.Second},
+		{ttl: 10 * time.Second, wantL1: 10 * time.Second},
+		{ttl: 0, wantL1: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if err := cache.Set(ctx, "key", []byte("value"), tt.ttl); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+		if e, _ := l1.entry("key"); e.ttl != tt.wantL1 {
+			t.Errorf("Set(ttl=%s): L1 TTL = %s, want %s", tt.ttl, e.ttl, tt.wantL1)
+		}
+		if e, _ := l2.entry("key"); e.ttl != tt.ttl {
+			t.Errorf("Set(ttl=%s): L2 TTL = %s, want %s", tt.ttl, e.ttl, tt.ttl)
+		}
+	}
+
+	// A failed L2 write leaves L1 untouched
+	l2.setErr(errors.New("l2 down"))
+	if err := cache.Set(ctx, "key", []byte("new"), 0); err == nil {
+		t.Error("Set with L2 down succeeded")
+	}
+	if e, _ := l1.entry("key"); string(e.value) != "value" {
+		t.Errorf("L1 value after failed Set = %q, want value", e.value)
+	}
+}
+
+func TestTieredCache_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	bus := hyperion.NewMemoryBus()
+	shared := newClockCache(clock)
+	a := newTiered(t, newClockCache(clock), shared, hyperion.WithInvalidationBus(bus))
+	b := newTiered(t, newClockCache(clock), shared, hyperion.WithInvalidationBus(bus))
+
+	expect := func(cache hyperion.Cache, key, want string) {
+		t.Helper()
+		got, err := cache.Get(ctx, key)
+		if want == "" {
+			if !errors.Is(err, hyperion.ErrCacheMiss) {
+				t.Errorf("Get(%q) = %q, %v, want ErrCacheMiss", key, got, err)
+			}
+			return
+		}
+		if err != nil || string(got) != want {
+			t.Errorf("Get(%q) = %q, %v, want %q", key, got, err, want)
+		}
+	}
+
+	if err := a.Set(ctx, "key", []byte("v1"), 0); err != nil {
+		t.Fatal(err)
+	}
+	expect(b, "key", "v1") // now cached in both L1s
+
+	if err := b.Set(ctx, "key", []byte("v2"), 0); err != nil {
+		t.Fatal(err)
+	}
+	expect(a, "key", "v2")
+
+	if err := b.MSet(ctx, map[string][]byte{"key": []byte("v3")}, 0); err != nil {
+		t.Fatal(err)
+	}
+	expect(a, "key", "v3")
+
+	if err := b.Delete(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+	expect(a, "key", "")
+
+	if err := a.Set(ctx, "key", []byte("v4"), 0); err != nil {
+		t.Fatal(err)
+	}
+	expect(b, "key", "v4")
+	if err := a.Clear(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expect(b, "key", "")
+
+	// After Close, a no longer hears about b's writes
+	expect(a, "key", "")
+	if err := a.Set(ctx, "key", []byte("v5"), 0); err != nil {
+		t.Fatal(err)
+	}
+	_ = a.Close()
+	if err := b.Set(ctx, "key", []byte("v6"), 0); err != nil {
+		t.Fatal(err)
+	}
+	expect(a, "key", "v5")
+}
+
+func TestTieredCache_StaleWithoutBus(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	shared := newClockCache(clock)
+	a := newTiered(t, newClockCache(clock), shared, hyperion.WithL1TTL(time.Second))
+	b := newTiered(t, newClockCache(clock), shared, hyperion.WithL1TTL(time.Second))
+
+	_ = a.Set(ctx, "key", []byte("v1"), 0)
+	_ = b.Set(ctx, "key", []byte("v2"), 0)
+	if got, _ := a.Get(ctx, "key"); string(got) != "v1" {
+		t.Errorf("Get before L1 expiry = %q, want the stale v1", got)
+	}
+
+	// The L1 TTL bounds staleness
+	clock.Advance(time.Second)
+	if got, _ := a.Get(ctx, "key"); string(got) != "v2" {
+		t.Errorf("Get after L1 expiry = %q, want v2", got)
+	}
+}
+
+type failingBus struct {
+	hyperion.InvalidationBus
+	publishErr, subscribeErr error
+}
+
+func (b failingBus) Publish(context.Context, hyperion.Invalidation) error {
+	return b.publishErr
+}
+
+func (b failingBus) Subscribe(func(hyperion.Invalidation)) (func(), error) {
+	return func() {}, b.subscribeErr
+}
+
+func TestTieredCache_BusErrors(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	errBus := errors.New("bus down")
+
+	_, err := hyperion.NewTieredCache(newClockCache(clock), newClockCache(clock),
+		hyperion.WithInvalidationBus(failingBus{subscribeErr: errBus}))
+	if !errors.Is(err, errBus) {
+		t.Errorf("NewTieredCache with failing Subscribe = %v, want %v", err, errBus)
+	}
+
+	l2 := newClockCache(clock)
+	cache := newTiered(t, newClockCache(clock), l2,
+		hyperion.WithInvalidationBus(failingBus{publishErr: errBus}))
+	if err := cache.Set(ctx, "key", []byte("value"), 0); !errors.Is(err, errBus) {
+		t.Errorf("Set with failing Publish = %v, want %v", err, errBus)
+	}
+	if _, ok := l2.entry("key"); !ok {
+		t.Error("Set with failing Publish did not store the value")
+	}
+}
+
+func TestNewTieredCache_InvalidL1TTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	if _, err := hyperion.NewTieredCache(newClockCache(clock), newClockCache(clock), hyperion.WithL1TTL(0)); err == nil {
+		t.Error("NewTieredCache with a zero L1 TTL succeeded")
+	}
+}
+
+func TestMemoryBus(t *testing.T) {
+	bus := hyperion.NewMemoryBus()
+	var got []hyperion.Invalidation
+	stop, err := bus.Subscribe(func(msg hyperion.Invalidation) { got = append(got, msg) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := hyperion.Invalidation{Source: "a", Keys: []string{"k"}}
+	_ = bus.Publish(context.Background(), msg)
+	stop()
+	stop()
+	_ = bus.Publish(context.Background(), msg)
+
+	if len(got) != 1 || got[0].Keys[0] != "k" {
+		t.Errorf("received %+v, want one message for k", got)
+	}
+}
@@ -0,0 +1,217 @@
+package hyperion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// negativeEntry is the value stored for a cached "not found" result.
+// The leading NUL byte keeps it apart from JSON, gob, msgpack and
+// protobuf encodings of real values.
+var negativeEntry = []byte("\x00hyperion:not-found")
+
+// TypedCache stores values of type T in a Cache, encoded with a Codec.
+//
+// Besides typed Get and Set, it implements cache-aside loading with
+// GetOrLoad: concurrent misses for the same key share a single call to the
+// loader, "not found" results can be cached for a short time, and TTLs can
+// be jittered so entries written together do not expire together.
+//
+// Example:
+//
+//	users := hyperion.NewTypedCache[User](cache,
+//	    hyperion.WithNegativeTTL(30*time.Second),
+//	    hyperion.WithTTLJitter(0.1),
+//	)
+//
+//	user, err := users.GetOrLoad(ctx, "user:"+id, 10*time.Minute,
+//	    func(ctx context.Context) (User, error) {
+//	        return repo.FindByID(ctx, id) // returns hyperion.ErrNotFound if missing
+//	    })
+type TypedCache[T any] struct {
+	cache   Cache
+	codec   Codec
+	flights flightGroup[T]
+	negTTL  time.Duration
+	jitter  float64
+}
+
+// TypedCacheOption configures a TypedCache.
+type TypedCacheOption func(*typedCacheConfig)
+
+type typedCacheConfig struct {
+	codec  Codec
+	negTTL time.Duration
+	jitter float64
+}
+
+// WithCodec sets the codec used to encode values. Defaults to JSON.
+func WithCodec(codec Codec) TypedCacheOption {
+	return func(cfg *typedCacheConfig) {
+		cfg.codec = codec
+	}
+}
+
+// WithNegativeTTL caches loader results of ErrNotFound for ttl, so repeated
+// lookups of a missing entity do not reach the loader. Disabled by default.
+func WithNegativeTTL(ttl time.Duration) TypedCacheOption {
+	return func(cfg *typedCacheConfig) {
+		cfg.negTTL = ttl
+	}
+}
+
+// WithTTLJitter randomizes every TTL by up to ±fraction of its value,
+// e.g. 0.1 turns 10m into 9m to 11m. fraction is clamped to [0, 1).
+func WithTTLJitter(fraction float64) TypedCacheOption {
+	return func(cfg *typedCacheConfig) {
+		cfg.jitter = min(max(fraction, 0), 0.99)
+	}
+}
+
+// NewTypedCache creates a TypedCache over cache.
+func NewTypedCache[T any](cache Cache, opts ...TypedCacheOption) *TypedCache[T] {
+	cfg := typedCacheConfig{codec: NewJSONCodec()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &TypedCache[T]{
+		cache:  cache,
+		codec:  cfg.codec,
+		negTTL: cfg.negTTL,
+		jitter: cfg.jitter,
+	}
+}
+
+// Get returns the value cached under key.
+// It returns ErrCacheMiss if the key is not cached, and ErrNotFound if a
+// "not found" result is cached for it.
+func (c *TypedCache[T]) Get(ctx context.Context, key string) (T, error) {
+	var value T
+	data, err := c.cache.Get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+	if string(data) == string(negativeEntry) {
+		return value, ErrNotFound
+	}
+	if err := c.codec.Unmarshal(data, &value); err != nil {
+		return value, fmt.Errorf("failed to decode cached %s with %s codec: %w", key, c.codec.Name(), err)
+	}
+	return value, nil
+}
+
+// Set caches value under key for ttl, adjusted by the configured jitter.
+// A TTL of 0 means no expiration.
+func (c *TypedCache[T]) Set(ctx context.Context, key string, value T, ttl time.Duration) error {
+	data, err := c.codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s with %s codec: %w", key, c.codec.Name(), err)
+	}
+	return c.cache.Set(ctx, key, data, c.jitterTTL(ttl))
+}
+
+// Delete removes key, including a cached "not found" result.
+func (c *TypedCache[T]) Delete(ctx context.Context, key string) error {
+	return c.cache.Delete(ctx, key)
+}
+
+// GetOrLoad returns the value cached under key, calling loader to produce
+// and cache it on a miss.
+//
+// Concurrent calls for the same key share one loader call. The loader runs
+// detached from the cancellation of any single caller, so a caller giving
+// up does not fail the others; callers whose ctx ends stop waiting and
+// return ctx.Err(). Bound the loader's own work with a timeout if needed.
+//
+// If the loader returns ErrNotFound (or an error wrapping it) and a
+// negative TTL is configured, the result is cached and later calls return
+// ErrNotFound without calling the loader. Other loader errors are returned
+// and not cached. Cache read and write failures do not fail the call; the
+// loader is used instead.
+//
+// All callers sharing a load receive the same value, so values containing
+// pointers, slices or maps should be treated as read-only.
+func (c *TypedCache[T]) GetOrLoad(ctx context.Context, key string, ttl time.Duration, loader func(ctx context.Context) (T, error)) (T, error) {
+	value, err := c.Get(ctx, key)
+	if err == nil || errors.Is(err, ErrNotFound) {
+		return value, err
+	}
+
+	call := c.flights.do(key, func() (T, error) {
+		loadCtx := context.WithoutCancel(ctx)
+		value, err := loader(loadCtx)
+		switch {
+		case err == nil:
+			_ = c.Set(loadCtx, key, value, ttl)
+		case errors.Is(err, ErrNotFound) && c.negTTL > 0:
+			_ = c.cache.Set(loadCtx, key, negativeEntry, c.jitterTTL(c.negTTL))
+		}
+		return value, err
+	})
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// jitterTTL applies the configured jitter to ttl.
+func (c *TypedCache[T]) jitterTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 || c.jitter == 0 {
+		return ttl
+	}
+	delta := (rand.Float64()*2 - 1) * c.jitter * float64(ttl) //nolint:gosec // jitter does not need a secure source
+	return ttl + time.Duration(delta)
+}
+
+// flightGroup deduplicates concurrent calls by key.
+type flightGroup[T any] struct {
+	calls map[string]*flightCall[T]
+	mu    sync.Mutex
+}
+
+// flightCall is an in-flight or completed call; done is closed when
+// value and err are set.
+type flightCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// do starts fn in a new goroutine unless a call for key is already in
+// flight, and returns the call to wait on. A panic in fn is returned as
+// an error to every waiter.
+func (g *flightGroup[T]) do(key string, fn func() (T, error)) *flightCall[T] {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		return call
+	}
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall[T])
+	}
+	call := &flightCall[T]{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				call.err = fmt.Errorf("cache loader for %s panicked: %v", key, r)
+			}
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(call.done)
+		}()
+		call.value, call.err = fn()
+	}()
+	return call
+}
@@ -0,0 +1,339 @@
+package hyperion_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+// memCache is a minimal thread-safe hyperion.Cache that records TTLs.
+type memCache struct {
+	hyperion.Cache
+	items  map[string][]byte
+	ttls   map[string]time.Duration
+	getErr error
+	mu     sync.Mutex
+}
+
+func newMemCache() *memCache {
+	return &memCache{
+		Cache: hyperion.NewNoOpCache(),
+		items: make(map[string][]byte),
+		ttls:  make(map[string]time.Duration),
+	}
+}
+
+func (c *memCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.getErr != nil {
+		return nil, c.getErr
+	}
+	if v, ok := c.items[key]; ok {
+		return v, nil
+	}
+	return nil, hyperion.ErrCacheMiss
+}
+
+func (c *memCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = value
+	c.ttls[key] = ttl
+	return nil
+}
+
+func (c *memCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+	return nil
+}
+
+func (c *memCache) ttl(key string) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ttls[key]
+}
+
+type cachedUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func TestTypedCache_GetSet(t *testing.T) {
+	ctx := context.Background()
+	for _, codec := range []hyperion.Codec{hyperion.NewJSONCodec(), hyperion.NewGobCodec()} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			users := hyperion.NewTypedCache[cachedUser](newMemCache(), hyperion.WithCodec(codec))
+
+			if _, err := users.Get(ctx, "user:1"); !errors.Is(err, hyperion.ErrCacheMiss) {
+				t.Errorf("Get of a missing key returned %v, want ErrCacheMiss", err)
+			}
+
+			want := cachedUser{Name: "alice", Email: "alice@example.com"}
+			if err := users.Set(ctx, "user:1", want, time.Minute); err != nil {
+				t.Fatalf("Set failed: %v", err)
+			}
+			got, err := users.Get(ctx, "user:1")
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if got != want {
+				t.Errorf("Get = %+v, want %+v", got, want)
+			}
+
+			if err := users.Delete(ctx, "user:1"); err != nil {
+				t.Fatalf("Delete failed: %v", err)
+			}
+			if _, err := users.Get(ctx, "user:1"); !errors.Is(err, hyperion.ErrCacheMiss) {
+				t.Errorf("Get after Delete returned %v, want ErrCacheMiss", err)
+			}
+		})
+	}
+}
+
+func TestTypedCache_DecodeError(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemCache()
+	_ = cache.Set(ctx, "user:1", []byte("not json"), 0)
+
+	users := hyperion.NewTypedCache[cachedUser](cache)
+	if _, err := users.Get(ctx, "user:1"); err == nil || errors.Is(err, hyperion.ErrCacheMiss) {
+		t.Errorf("Get of an undecodable value returned %v, want a decode error", err)
+	}
+
+	// GetOrLoad treats an undecodable value as a miss and replaces it
+	got, err := users.GetOrLoad(ctx, "user:1", 0, func(context.Context) (cachedUser, error) {
+		return cachedUser{Name: "alice"}, nil
+	})
+	if err != nil || got.Name != "alice" {
+		t.Fatalf("GetOrLoad = %+v, %v, want alice", got, err)
+	}
+	if got, err := users.Get(ctx, "user:1"); err != nil || got.Name != "alice" {
+		t.Errorf("Get after GetOrLoad = %+v, %v, want alice", got, err)
+	}
+}
+
+func TestTypedCache_GetOrLoad(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemCache()
+	users := hyperion.NewTypedCache[cachedUser](cache)
+
+	var loads atomic.Int32
+	loader := func(context.Context) (cachedUser, error) {
+		loads.Add(1)
+		return cachedUser{Name: "alice"}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		got, err := users.GetOrLoad(ctx, "user:1", time.Minute, loader)
+		if err != nil || got.Name != "alice" {
+			t.Fatalf("GetOrLoad = %+v, %v, want alice", got, err)
+		}
+	}
+	if n := loads.Load(); n != 1 {
+		t.Errorf("loader called %d times, want 1", n)
+	}
+	if ttl := cache.ttl("user:1"); ttl != time.Minute {
+		t.Errorf("cached TTL = %v, want 1m", ttl)
+	}
+
+	// Loader errors are returned and not cached
+	boom := errors.New("db down")
+	_, err := users.GetOrLoad(ctx, "user:2", time.Minute, func(context.Context) (cachedUser, error) {
+		return cachedUser{}, boom
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("GetOrLoad returned %v, want the loader error", err)
+	}
+	if _, err := users.Get(ctx, "user:2"); !errors.Is(err, hyperion.ErrCacheMiss) {
+		t.Errorf("loader error was cached: Get returned %v", err)
+	}
+}
+
+func TestTypedCache_Singleflight(t *testing.T) {
+	ctx := context.Background()
+	users := hyperion.NewTypedCache[cachedUser](newMemCache())
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	loader := func(context.Context) (cachedUser, error) {
+		loads.Add(1)
+		<-release
+		return cachedUser{Name: "alice"}, nil
+	}
+
+	const callers = 20
+	var wg sync.WaitGroup
+	var started sync.WaitGroup
+	started.Add(callers)
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			got, err := users.GetOrLoad(ctx, "user:1", time.Minute, loader)
+			if err == nil && got.Name != "alice" {
+				err = fmt.Errorf("got %+v", got)
+			}
+			errs <- err
+		}()
+	}
+
+	started.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetOrLoad failed: %v", err)
+		}
+	}
+	if n := loads.Load(); n != 1 {
+		t.Errorf("loader called %d times for %d concurrent callers, want 1", n, callers)
+	}
+}
+
+func TestTypedCache_CallerCancel(t *testing.T) {
+	users := hyperion.NewTypedCache[cachedUser](newMemCache())
+
+	release := make(chan struct{})
+	loaded := make(chan struct{})
+	loader := func(ctx context.Context) (cachedUser, error) {
+		<-release
+		defer close(loaded)
+		// The shared load is not canceled with its first caller
+		if err := ctx.Err(); err != nil {
+			return cachedUser{}, err
+		}
+		return cachedUser{Name: "alice"}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := users.GetOrLoad(ctx, "user:1", time.Minute, loader)
+		done <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled GetOrLoad returned %v, want context.Canceled", err)
+	}
+
+	close(release)
+	<-loaded
+	deadline := time.Now().Add(time.Second)
+	for {
+		got, err := users.Get(context.Background(), "user:1")
+		if err == nil {
+			if got.Name != "alice" {
+				t.Errorf("Get = %+v, want alice", got)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("value was not cached after the caller canceled: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestTypedCache_LoaderPanic(t *testing.T) {
+	users := hyperion.NewTypedCache[cachedUser](newMemCache())
+	_, err := users.GetOrLoad(context.Background(), "user:1", 0, func(context.Context) (cachedUser, error) {
+		panic("boom")
+	})
+	if err == nil {
+		t.Fatal("GetOrLoad returned nil for a panicking loader")
+	}
+}
+
+func TestTypedCache_NegativeCaching(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemCache()
+	users := hyperion.NewTypedCache[cachedUser](cache, hyperion.WithNegativeTTL(30*time.Second))
+
+	var loads atomic.Int32
+	loader := func(context.Context) (cachedUser, error) {
+		loads.Add(1)
+		return cachedUser{}, fmt.Errorf("user 404: %w", hyperion.ErrNotFound)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := users.GetOrLoad(ctx, "user:404", time.Minute, loader); !errors.Is(err, hyperion.ErrNotFound) {
+			t.Fatalf("GetOrLoad returned %v, want ErrNotFound", err)
+		}
+	}
+	if n := loads.Load(); n != 1 {
+		t.Errorf("loader called %d times, want 1", n)
+	}
+	if ttl := cache.ttl("user:404"); ttl != 30*time.Second {
+		t.Errorf("negative TTL = %v, want 30s", ttl)
+	}
+	if _, err := users.Get(ctx, "user:404"); !errors.Is(err, hyperion.ErrNotFound) {
+		t.Errorf("Get of a cached not-found returned %v, want ErrNotFound", err)
+	}
+
+	// Without a negative TTL, not-found results are not cached
+	plain := hyperion.NewTypedCache[cachedUser](newMemCache())
+	loads.Store(0)
+	for i := 0; i < 2; i++ {
+		_, _ = plain.GetOrLoad(ctx, "user:404", time.Minute, loader)
+	}
+	if n := loads.Load(); n != 2 {
+		t.Errorf("loader called %d times without negative caching, want 2", n)
+	}
+}
+
+func TestTypedCache_Jitter(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemCache()
+	users := hyperion.NewTypedCache[cachedUser](cache, hyperion.WithTTLJitter(0.1))
+
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 50; i++ {
+		key := fmt.Sprintf("user:%d", i)
+		if err := users.Set(ctx, key, cachedUser{}, 10*time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		ttl := cache.ttl(key)
+		if ttl < 9*time.Minute || ttl > 11*time.Minute {
+			t.Errorf("jittered TTL %v outside [9m, 11m]", ttl)
+		}
+		seen[ttl] = true
+	}
+	if len(seen) < 2 {
+		t.Error("jitter produced identical TTLs")
+	}
+
+	// No expiration stays no expiration
+	_ = users.Set(ctx, "forever", cachedUser{}, 0)
+	if ttl := cache.ttl("forever"); ttl != 0 {
+		t.Errorf("jittered TTL of 0 = %v, want 0", ttl)
+	}
+}
+
+func TestTypedCache_CacheFailure(t *testing.T) {
+	cache := newMemCache()
+	cache.getErr = errors.New("connection refused")
+	users := hyperion.NewTypedCache[cachedUser](cache)
+
+	got, err := users.GetOrLoad(context.Background(), "user:1", time.Minute, func(context.Context) (cachedUser, error) {
+		return cachedUser{Name: "alice"}, nil
+	})
+	if err != nil || got.Name != "alice" {
+		t.Errorf("GetOrLoad with a failing cache = %+v, %v, want the loaded value", got, err)
+	}
+}
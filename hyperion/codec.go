@@ -0,0 +1,58 @@
+package hyperion
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec converts values to and from bytes for storage, e.g. in a Cache.
+//
+// JSON and gob codecs are built in; adapter/codec provides msgpack and
+// protobuf codecs.
+type Codec interface {
+	// Name identifies the codec, e.g. "json".
+	Name() string
+
+	// Marshal encodes v.
+	Marshal(v any) ([]byte, error)
+
+	// Unmarshal decodes data into the value pointed to by v.
+	Unmarshal(data []byte, v any) error
+}
+
+// NewJSONCodec returns a Codec using encoding/json.
+func NewJSONCodec() Codec {
+	return jsonCodec{}
+}
+
+// NewGobCodec returns a Codec using encoding/gob.
+// Each value is encoded as a self-contained stream, including its type
+// description, so it can be decoded independently of other values.
+func NewGobCodec() Codec {
+	return gobCodec{}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return "json" }
+
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+type gobCodec struct{}
+
+func (gobCodec) Name() string { return "gob" }
+
+func (gobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
@@ -48,12 +48,73 @@ type ConfigWatcher interface {
 	Watch(callback func(event ChangeEvent)) (stop func(), err error)
 }
 
+// ConfigValidator validates a candidate configuration before a reload is
+// applied. Watchers that support validation reject the candidate, and keep
+// the previous configuration, if any validator returns an error.
+//
+// Validators are contributed via the "hyperion.config_validators" fx group:
+//
+//	fx.Provide(
+//	    fx.Annotate(
+//	        func() hyperion.ConfigValidator { return validateServerConfig },
+//	        fx.ResultTags(`group:"hyperion.config_validators"`),
+//	    ),
+//	)
+type ConfigValidator func(candidate Config) error
+
+// ChangeType describes how a configuration key changed.
+type ChangeType uint8
+
+const (
+	// ChangeModified means the key existed before and after the change
+	// with different values.
+	ChangeModified ChangeType = iota
+
+	// ChangeAdded means the key did not exist before the change.
+	ChangeAdded
+
+	// ChangeRemoved means the key no longer exists after the change.
+	ChangeRemoved
+
+	// ChangeRejected means a reload was rejected because the candidate
+	// configuration could not be loaded or failed validation. The previous
+	// configuration stays in effect and ChangeEvent.Err holds the reason.
+	ChangeRejected
+)
+
+// String returns the lowercase name of the change type.
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeRejected:
+		return "rejected"
+	default:
+		return "modified"
+	}
+}
+
 // ChangeEvent represents a configuration change event.
+//
+// Watchers that can compute key-level diffs emit one event per changed key,
+// with both the old and the new value.
 type ChangeEvent struct {
-	// Value is the new value, or nil for file-based events.
+	// Value is the new value, or nil if the key was removed.
+	// It is also nil for file-based events from watchers without key diffs.
 	Value any
 
+	// OldValue is the previous value, or nil if the key was added.
+	OldValue any
+
+	// Err is the reason a reload was rejected, set for ChangeRejected only.
+	Err error
+
 	// Key is the configuration key that changed.
-	// For file-based watchers, this may be the filename.
+	// For file-based watchers without key diffs, this may be the filename.
 	Key string
+
+	// Type tells whether the key was added, modified or removed.
+	Type ChangeType
 }
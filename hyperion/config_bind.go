@@ -0,0 +1,191 @@
+package hyperion
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Bind loads the configuration at key into a new T.
+//
+// Binding happens in three steps:
+//
+//  1. Fields tagged `default:"..."` are set to their default value.
+//  2. The configuration at key is unmarshaled over the defaults, so only
+//     keys present in the configuration override them.
+//  3. Fields tagged `validate:"..."` are checked, and if *T has a
+//     Validate() error method it is called last.
+//
+// Validation failures are reported together as a *ValidationError whose
+// field paths use the configuration key names, e.g. "database.pool.max_open".
+//
+// Supported validate rules, separated by commas:
+//
+//	required       the value must not be the zero value
+//	omitempty      skip the remaining rules if the value is the zero value
+//	min=N, max=N   bounds for numbers, and for the length of strings, slices and maps;
+//	               durations accept units, e.g. min=1s
+//	oneof=a b c    the value must be one of the space-separated options
+//	hostname       the string must be an RFC 1123 hostname
+//	ip             the string must be an IPv4 or IPv6 address
+//
+// A rule may list alternatives separated by "|", e.g. hostname|ip, and
+// passes if any of them does.
+//
+// Example:
+//
+//	type PoolConfig struct {
+//	    MaxOpen int           `mapstructure:"max_open" default:"25" validate:"min=1"`
+//	    Timeout time.Duration `mapstructure:"timeout" default:"5s"`
+//	    Mode    string        `mapstructure:"mode" default:"lazy" validate:"oneof=lazy eager"`
+//	}
+//
+//	pool, err := hyperion.Bind[PoolConfig](cfg, "database.pool")
+func Bind[T any](cfg Config, key string) (T, error) {
+	var v T
+	if err := bindInto(cfg, key, &v); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// bindInto implements Bind for a pointer to a struct.
+func bindInto(cfg Config, key string, ptr any) error {
+	rv := reflect.ValueOf(ptr).Elem()
+
+	if err := applyDefaults(rv, key); err != nil {
+		return err
+	}
+	if err := cfg.Unmarshal(key, ptr); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", displayKey(key), err)
+	}
+	return validateValue(rv, key, ptr)
+}
+
+// applyDefaults sets every zero field with a default tag, recursing into structs.
+func applyDefaults(v reflect.Value, path string) error {
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		fieldPath := joinFieldPath(path, field)
+
+		if def, ok := field.Tag.Lookup("default"); ok && fv.IsZero() {
+			if err := setFromString(fv, def); err != nil {
+				return fmt.Errorf("invalid default for %s: %w", displayKey(fieldPath), err)
+			}
+			continue
+		}
+
+		switch {
+		case fv.Kind() == reflect.Struct:
+			if err := applyDefaults(fv, fieldPath); err != nil {
+				return err
+			}
+		case fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct && !fv.IsNil():
+			if err := applyDefaults(fv.Elem(), fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setFromString parses s into v according to v's type.
+// Pointers are allocated; slices take comma-separated elements.
+func setFromString(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := setFromString(elem.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		parts := strings.Split(s, ",")
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setFromString(slice.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// joinFieldPath appends the configuration key name of field to path.
+// Embedded and squashed fields share their parent's path.
+func joinFieldPath(path string, field reflect.StructField) string {
+	name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if field.Anonymous && name == "" || strings.Contains(opts, "squash") {
+		return path
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// displayKey names the configuration root in messages.
+func displayKey(key string) string {
+	if key == "" {
+		return "config"
+	}
+	return key
+}
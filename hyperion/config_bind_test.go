@@ -0,0 +1,306 @@
+package hyperion_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+// mapConfig is a ConfigWatcher backed by a nested map.
+// Unmarshal round-trips through JSON, so test structs carry json tags
+// alongside mapstructure tags.
+type mapConfig struct {
+	hyperion.Config
+	mu        sync.Mutex
+	data      map[string]any
+	callbacks []func(hyperion.ChangeEvent)
+}
+
+func newMapConfig(data map[string]any) *mapConfig {
+	return &mapConfig{Config: hyperion.NewNoOpConfig(), data: data}
+}
+
+func (c *mapConfig) Get(key string) any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var v any = c.data
+	for _, part := range strings.Split(key, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[part]
+	}
+	return v
+}
+
+func (c *mapConfig) Unmarshal(key string, rawVal any) error {
+	v := c.Get(key)
+	if key == "" {
+		v = c.data
+	}
+	if v == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, rawVal)
+}
+
+func (c *mapConfig) Watch(callback func(hyperion.ChangeEvent)) (func(), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.callbacks = append(c.callbacks, callback)
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.callbacks = nil
+	}, nil
+}
+
+// set replaces the section at top-level key and emits a change event.
+func (c *mapConfig) set(key string, value any) {
+	c.mu.Lock()
+	c.data[key] = value
+	c.mu.Unlock()
+	c.emit(hyperion.ChangeEvent{Key: key + ".changed", Value: value})
+}
+
+// emit delivers event to the registered callbacks.
+func (c *mapConfig) emit(event hyperion.ChangeEvent) {
+	c.mu.Lock()
+	callbacks := append([]func(hyperion.ChangeEvent){}, c.callbacks...)
+	c.mu.Unlock()
+
+	for _, cb := range callbacks {
+		cb(event)
+	}
+}
+
+type poolConfig struct {
+	MaxOpen int           `json:"max_open" mapstructure:"max_open" default:"25" validate:"min=1,max=100"`
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout" default:"5s" validate:"min=1ms"`
+	Mode    string        `json:"mode" mapstructure:"mode" default:"lazy" validate:"oneof=lazy eager"`
+	Tags    []string      `json:"tags" mapstructure:"tags" default:"a, b"`
+}
+
+type databaseConfig struct {
+	Host string     `json:"host" mapstructure:"host" validate:"required"`
+	Pool poolConfig `json:"pool" mapstructure:"pool"`
+}
+
+func TestBindAppliesDefaults(t *testing.T) {
+	cfg := newMapConfig(map[string]any{
+		"database": map[string]any{
+			"host": "localhost",
+			"pool": map[string]any{"max_open": 10},
+		},
+	})
+
+	got, err := hyperion.Bind[databaseConfig](cfg, "database")
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	want := databaseConfig{
+		Host: "localhost",
+		Pool: poolConfig{MaxOpen: 10, Timeout: 5 * time.Second, Mode: "lazy", Tags: []string{"a", "b"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bind() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBindReportsFieldPaths(t *testing.T) {
+	cfg := newMapConfig(map[string]any{
+		"database": map[string]any{
+			"pool": map[string]any{"max_open": 500, "mode": "greedy"},
+		},
+	})
+
+	_, err := hyperion.Bind[databaseConfig](cfg, "database")
+
+	var verr *hyperion.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Bind() error = %v, want *ValidationError", err)
+	}
+
+	var paths []string
+	for _, fe := range verr.Errors {
+		paths = append(paths, fe.Path+" "+fe.Rule)
+	}
+	want := []string{"database.host required", "database.pool.max_open max=100", "database.pool.mode oneof=lazy eager"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("field errors = %q, want %q", paths, want)
+	}
+}
+
+type listenerConfig struct {
+	Addr string `json:"addr" mapstructure:"addr" validate:"required"`
+}
+
+type serverConfig struct {
+	Listeners []listenerConfig `json:"listeners" mapstructure:"listeners" validate:"min=1"`
+	Debug     *bool            `json:"debug" mapstructure:"debug" default:"false"`
+}
+
+func (c *serverConfig) Validate() error {
+	if len(c.Listeners) > 2 {
+		return errors.New("at most two listeners are supported")
+	}
+	return nil
+}
+
+func TestBindSlicesAndValidateMethod(t *testing.T) {
+	cfg := newMapConfig(map[string]any{
+		"server": map[string]any{
+			"listeners": []any{map[string]any{"addr": ":80"}, map[string]any{}},
+		},
+	})
+
+	_, err := hyperion.Bind[serverConfig](cfg, "server")
+	if err == nil || !strings.Contains(err.Error(), "server.listeners[1].addr: is required") {
+		t.Errorf("Bind() error = %v, want indexed field path", err)
+	}
+
+	cfg = newMapConfig(map[string]any{
+		"server": map[string]any{
+			"listeners": []any{map[string]any{"addr": ":80"}, map[string]any{"addr": ":81"}, map[string]any{"addr": ":82"}},
+		},
+	})
+	if _, err := hyperion.Bind[serverConfig](cfg, "server"); err == nil || !strings.Contains(err.Error(), "at most two listeners") {
+		t.Errorf("Bind() error = %v, want Validate() error", err)
+	}
+
+	cfg = newMapConfig(map[string]any{
+		"server": map[string]any{"listeners": []any{map[string]any{"addr": ":80"}}},
+	})
+	got, err := hyperion.Bind[serverConfig](cfg, "server")
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if got.Debug == nil || *got.Debug {
+		t.Errorf("Debug = %v, want pointer to false", got.Debug)
+	}
+}
+
+type endpointConfig struct {
+	Host string `json:"host" mapstructure:"host" validate:"omitempty,hostname|ip"`
+}
+
+func TestBindHostRules(t *testing.T) {
+	for _, host := range []string{"", "localhost", "db-1.internal.example.com", "10.0.0.1", "::1"} {
+		if _, err := hyperion.Bind[endpointConfig](newMapConfig(map[string]any{"host": host}), ""); err != nil {
+			t.Errorf("Bind() with host %q error = %v, want nil", host, err)
+		}
+	}
+
+	for _, host := range []string{"-db.example.com", "db_1", "bad host"} {
+		_, err := hyperion.Bind[endpointConfig](newMapConfig(map[string]any{"host": host}), "")
+		var verr *hyperion.ValidationError
+		if !errors.As(err, &verr) || verr.Errors[0].Rule != "hostname|ip" {
+			t.Errorf("Bind() with host %q error = %v, want a hostname|ip field error", host, err)
+		}
+	}
+}
+
+func TestBindInvalidTags(t *testing.T) {
+	type badDefault struct {
+		Port int `default:"http"`
+	}
+	if _, err := hyperion.Bind[badDefault](newMapConfig(map[string]any{}), ""); err == nil {
+		t.Error("Bind() with unparsable default should fail")
+	}
+
+	type badRule struct {
+		Port int `validate:"port"`
+	}
+	if _, err := hyperion.Bind[badRule](newMapConfig(map[string]any{}), ""); err == nil || !strings.Contains(err.Error(), `unknown rule "port"`) {
+		t.Errorf("Bind() error = %v, want unknown rule", err)
+	}
+}
+
+func TestWatchRebinds(t *testing.T) {
+	cfg := newMapConfig(map[string]any{
+		"pool": map[string]any{"max_open": 10},
+	})
+
+	live, err := hyperion.Watch[poolConfig](cfg, "pool")
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer live.Stop()
+
+	if got := live.Load().MaxOpen; got != 10 {
+		t.Fatalf("Load().MaxOpen = %d, want 10", got)
+	}
+
+	var changes [][2]int
+	var errs []error
+	live.OnChange(func(old, new poolConfig) {
+		changes = append(changes, [2]int{old.MaxOpen, new.MaxOpen})
+	})
+	live.OnError(func(err error) {
+		errs = append(errs, err)
+	})
+
+	cfg.set("pool", map[string]any{"max_open": 20})
+	cfg.set("pool", map[string]any{"max_open": 20})
+	if got := live.Load().MaxOpen; got != 20 {
+		t.Errorf("Load().MaxOpen = %d after change, want 20", got)
+	}
+	if want := [][2]int{{10, 20}}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("OnChange calls = %v, want %v", changes, want)
+	}
+
+	// An invalid value keeps the previous one.
+	cfg.set("pool", map[string]any{"max_open": 0, "mode": "greedy"})
+	if got := live.Load().MaxOpen; got != 20 {
+		t.Errorf("Load().MaxOpen = %d after invalid change, want 20", got)
+	}
+	if len(errs) != 1 {
+		t.Errorf("OnError calls = %d, want 1", len(errs))
+	}
+
+	// Changes to other sections are ignored.
+	cfg.set("other", map[string]any{"max_open": 30})
+	if len(changes) != 1 || len(errs) != 1 {
+		t.Errorf("unrelated change triggered callbacks: changes=%v errs=%v", changes, errs)
+	}
+}
+
+func TestGetDurationAndStringMap(t *testing.T) {
+	cfg := newMapConfig(map[string]any{
+		"server": map[string]any{
+			"timeout": "1m30s",
+			"idle":    float64(500),
+			"labels":  map[any]any{"team": "core", 1: "one"},
+		},
+	})
+
+	if got := hyperion.GetDuration(cfg, "server.timeout"); got != 90*time.Second {
+		t.Errorf("GetDuration(timeout) = %v, want 1m30s", got)
+	}
+	if got := hyperion.GetDuration(cfg, "server.idle"); got != 500 {
+		t.Errorf("GetDuration(idle) = %v, want 500ns", got)
+	}
+	if got := hyperion.GetDuration(cfg, "server.missing"); got != 0 {
+		t.Errorf("GetDuration(missing) = %v, want 0", got)
+	}
+
+	want := map[string]any{"team": "core", "1": "one"}
+	if got := hyperion.GetStringMap(cfg, "server.labels"); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStringMap(labels) = %v, want %v", got, want)
+	}
+	if got := hyperion.GetStringMap(cfg, "server.timeout"); len(got) != 0 {
+		t.Errorf("GetStringMap(timeout) = %v, want empty map", got)
+	}
+}
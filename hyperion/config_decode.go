@@ -0,0 +1,336 @@
+package hyperion
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// decodeInto decodes a configuration value, as produced by LayeredConfig,
+// into out. Struct fields are matched by their mapstructure tag, or
+// case-insensitively by name. Strings are converted to the target type,
+// since environment variables, flags and KV stores only carry strings.
+func decodeInto(in any, out any) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("decode target must be a non-nil pointer, got %T", out)
+	}
+	return decodeValue(in, rv.Elem(), "")
+}
+
+// decodeValue decodes in into v. path names v in error messages.
+func decodeValue(in any, v reflect.Value, path string) error {
+	if in == nil {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeValue(in, v.Elem(), path)
+	}
+
+	if v.Kind() == reflect.Interface {
+		v.Set(reflect.ValueOf(in))
+		return nil
+	}
+
+	if v.Type() == durationType {
+		d, err := toDuration(in)
+		if err != nil {
+			return decodeError(path, err)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return decodeStruct(in, v, path)
+	case reflect.Map:
+		return decodeMap(in, v, path)
+	case reflect.Slice:
+		return decodeSlice(in, v, path)
+	case reflect.String:
+		v.SetString(toString(in))
+	case reflect.Bool:
+		b, err := toBool(in)
+		if err != nil {
+			return decodeError(path, err)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt64(in)
+		if err != nil {
+			return decodeError(path, err)
+		}
+		if v.OverflowInt(n) {
+			return decodeError(path, fmt.Errorf("%d overflows %s", n, v.Type()))
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toInt64(in)
+		if err != nil {
+			return decodeError(path, err)
+		}
+		if n < 0 || v.OverflowUint(uint64(n)) {
+			return decodeError(path, fmt.Errorf("%d overflows %s", n, v.Type()))
+		}
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(in)
+		if err != nil {
+			return decodeError(path, err)
+		}
+		v.SetFloat(f)
+	default:
+		return decodeError(path, fmt.Errorf("unsupported type %s", v.Type()))
+	}
+	return nil
+}
+
+func decodeStruct(in any, v reflect.Value, path string) error {
+	m, ok := in.(map[string]any)
+	if !ok {
+		return decodeError(path, fmt.Errorf("expected a map, got %T", in))
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" || strings.Contains(opts, "squash") {
+			if err := decodeValue(m, v.Field(i), path); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		value, found := lookupFold(m, name)
+		if !found {
+			continue
+		}
+		if err := decodeValue(value, v.Field(i), joinPath(path, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeMap(in any, v reflect.Value, path string) error {
+	m, ok := in.(map[string]any)
+	if !ok {
+		return decodeError(path, fmt.Errorf("expected a map, got %T", in))
+	}
+	if v.Type().Key().Kind() != reflect.String {
+		return decodeError(path, fmt.Errorf("unsupported map key type %s", v.Type().Key()))
+	}
+
+	if v.IsNil() {
+		v.Set(reflect.MakeMapWithSize(v.Type(), len(m)))
+	}
+	for key, value := range m {
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := decodeValue(value, elem, joinPath(path, key)); err != nil {
+			return err
+		}
+		v.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+	}
+	return nil
+}
+
+func decodeSlice(in any, v reflect.Value, path string) error {
+	var items []any
+	switch x := in.(type) {
+	case []any:
+		items = x
+	case []string:
+		for _, s := range x {
+			items = append(items, s)
+		}
+	case string:
+		for _, s := range splitList(x) {
+			items = append(items, s)
+		}
+	default:
+		return decodeError(path, fmt.Errorf("expected a list, got %T", in))
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+	for i, item := range items {
+		if err := decodeValue(item, slice.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+// lookupFold returns the value of the key in m that equals name case-insensitively.
+func lookupFold(m map[string]any, name string) (any, bool) {
+	if value, ok := m[name]; ok {
+		return value, true
+	}
+	for key, value := range m {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func decodeError(path string, err error) error {
+	return fmt.Errorf("cannot decode %s: %w", displayKey(path), err)
+}
+
+// splitList splits a comma-separated string, as used for lists in
+// environment variables and flags.
+func splitList(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// toString converts a configuration value to a string.
+func toString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toBool converts a configuration value to a bool.
+func toBool(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(strings.TrimSpace(v))
+	default:
+		n, err := toInt64(value)
+		if err != nil {
+			return false, fmt.Errorf("cannot convert %T to bool", value)
+		}
+		return n != 0, nil
+	}
+}
+
+// toInt64 converts a configuration value to an int64.
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("%d overflows int64", v)
+		}
+		return int64(v), nil
+	case float32:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case time.Duration:
+		return int64(v), nil
+	case string:
+		s := strings.TrimSpace(v)
+		if n, err := strconv.ParseInt(s, 0, 64); err == nil {
+			return n, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert %q to a number", v)
+		}
+		return int64(f), nil
+	default:
+		return 0, fmt.Errorf("cannot convert %T to a number", value)
+	}
+}
+
+// toFloat64 converts a configuration value to a float64.
+func toFloat64(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert %q to a number", v)
+		}
+		return f, nil
+	default:
+		n, err := toInt64(value)
+		return float64(n), err
+	}
+}
+
+// toStringSlice converts a configuration value to a string slice.
+func toStringSlice(value any) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []string:
+		return v
+	case []any:
+		out := make([]string, len(v))
+		for i, item := range v {
+			out[i] = toString(item)
+		}
+		return out
+	case string:
+		return splitList(v)
+	default:
+		return []string{toString(v)}
+	}
+}
@@ -0,0 +1,128 @@
+package hyperion
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// defaultSecretKeyPatterns are key-name fragments that mark a value as
+// secret. They are matched case-insensitively against the last key segment.
+var defaultSecretKeyPatterns = []string{
+	"password", "passwd", "secret", "token", "apikey", "api_key",
+	"private_key", "credential", "dsn",
+}
+
+// ConfigEntry describes one effective configuration value.
+type ConfigEntry struct {
+	// Value is the effective value, or SecretMask if Secret is true.
+	Value any `json:"value"`
+
+	// Key is the configuration key, e.g. "database.host".
+	Key string `json:"key"`
+
+	// Source names where the value came from, e.g. "env" or
+	// "file:configs/config.yaml". It is empty if the Config does not
+	// implement OriginConfig.
+	Source string `json:"source,omitempty"`
+
+	// Secret reports whether the value is masked.
+	Secret bool `json:"secret"`
+}
+
+// ConfigInspector reports the effective configuration, where each value
+// came from, and which values are secret. Secret values are always masked.
+//
+// A value is secret if the Config marks it (see SecretConfig), as config
+// providers do for values resolved from ${file:...} and other secret
+// placeholders, or if the last segment of its key contains a secret-like
+// word such as "password" or "token".
+//
+// ConfigInspector is an http.Handler serving the entries as JSON, for
+// mounting on an admin port:
+//
+//	mux.Handle("/debug/config", hyperion.NewConfigInspector(cfg))
+type ConfigInspector struct {
+	cfg      Config
+	patterns []string
+}
+
+// NewConfigInspector creates an inspector for cfg. Additional key-name
+// fragments that mark values as secret can be passed as secretKeys.
+func NewConfigInspector(cfg Config, secretKeys ...string) *ConfigInspector {
+	patterns := append([]string(nil), defaultSecretKeyPatterns...)
+	for _, key := range secretKeys {
+		patterns = append(patterns, strings.ToLower(key))
+	}
+	return &ConfigInspector{cfg: cfg, patterns: patterns}
+}
+
+// Entries returns every key from AllKeys, sorted, with its masked value and source.
+func (i *ConfigInspector) Entries() []ConfigEntry {
+	keys := append([]string(nil), i.cfg.AllKeys()...)
+	sort.Strings(keys)
+	entries := make([]ConfigEntry, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, i.Entry(key))
+	}
+	return entries
+}
+
+// Entry returns the masked value and source of key.
+func (i *ConfigInspector) Entry(key string) ConfigEntry {
+	entry := ConfigEntry{
+		Key:    key,
+		Value:  i.cfg.Get(key),
+		Secret: i.IsSecret(key),
+	}
+	if oc, ok := i.cfg.(OriginConfig); ok {
+		entry.Source = oc.Origin(key)
+	}
+	if entry.Secret {
+		entry.Value = SecretMask
+	}
+	return entry
+}
+
+// IsSecret reports whether the value at key is masked.
+func (i *ConfigInspector) IsSecret(key string) bool {
+	if IsSecret(i.cfg, key) {
+		return true
+	}
+
+	name := strings.ToLower(key)
+	if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+		name = name[dot+1:]
+	}
+	for _, pattern := range i.patterns {
+		if strings.Contains(name, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP writes the entries as JSON. The optional "prefix" query
+// parameter limits the output to keys under a prefix, e.g. ?prefix=database.
+func (i *ConfigInspector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	prefix := r.URL.Query().Get("prefix")
+	entries := make([]ConfigEntry, 0)
+	for _, entry := range i.Entries() {
+		if KeyHasPrefix(entry.Key, prefix) {
+			entries = append(entries, entry)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(struct {
+		Entries []ConfigEntry `json:"entries"`
+	}{Entries: entries})
+}
@@ -0,0 +1,88 @@
+package hyperion_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/mapoio/hyperion"
+)
+
+// secretMarkingConfig marks keys as secret, as providers do for values
+// resolved from secret placeholders.
+type secretMarkingConfig struct {
+	*hyperion.LayeredConfig
+	secrets map[string]bool
+}
+
+func (c secretMarkingConfig) IsSecret(key string) bool { return c.secrets[key] }
+
+func newInspectedConfig(t *testing.T) hyperion.Config {
+	t.Helper()
+	cfg, err := hyperion.NewLayeredConfig(
+		hyperion.MapSource("defaults", map[string]any{
+			"database": map[string]any{"host": "localhost", "password": "hunter2", "dsn": "postgres://u:p@h/db"},
+			"api":      map[string]any{"auth_token": "t0k3n", "key_id": "k1"},
+		}),
+		hyperion.MapSource("env", map[string]any{"database.host": "db.internal", "vendor.cert": "-----BEGIN"}),
+	)
+	if err != nil {
+		t.Fatalf("NewLayeredConfig() error = %v", err)
+	}
+	return secretMarkingConfig{LayeredConfig: cfg, secrets: map[string]bool{"vendor.cert": true}}
+}
+
+func TestConfigInspectorEntries(t *testing.T) {
+	inspector := hyperion.NewConfigInspector(newInspectedConfig(t), "KEY_ID")
+
+	want := []hyperion.ConfigEntry{
+		{Key: "api.auth_token", Value: hyperion.SecretMask, Source: "defaults", Secret: true},
+		{Key: "api.key_id", Value: hyperion.SecretMask, Source: "defaults", Secret: true},
+		{Key: "database.dsn", Value: hyperion.SecretMask, Source: "defaults", Secret: true},
+		{Key: "database.host", Value: "db.internal", Source: "env"},
+		{Key: "database.password", Value: hyperion.SecretMask, Source: "defaults", Secret: true},
+		{Key: "vendor.cert", Value: hyperion.SecretMask, Source: "env", Secret: true},
+	}
+	if got := inspector.Entries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestConfigInspectorHandler(t *testing.T) {
+	inspector := hyperion.NewConfigInspector(newInspectedConfig(t))
+
+	rec := httptest.NewRecorder()
+	inspector.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/config?prefix=database", nil))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("response = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	var body struct {
+		Entries []struct {
+			Value  any    `json:"value"`
+			Key    string `json:"key"`
+			Source string `json:"source"`
+			Secret bool   `json:"secret"`
+		} `json:"entries"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(body.Entries) != 3 {
+		t.Fatalf("entries = %+v, want 3 database keys", body.Entries)
+	}
+	for _, entry := range body.Entries {
+		if entry.Key == "database.password" && (entry.Value != hyperion.SecretMask || !entry.Secret) {
+			t.Errorf("password entry = %+v, want masked", entry)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	inspector.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/config", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", rec.Code)
+	}
+}
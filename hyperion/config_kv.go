@@ -0,0 +1,139 @@
+package hyperion
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// KVStore is a remote key/value backend such as etcd or Consul.
+// Keys are slash-separated paths, e.g. "orders/database/host".
+type KVStore interface {
+	// List returns every entry whose key starts with prefix.
+	List(ctx context.Context, prefix string) (map[string]string, error)
+
+	// WatchPrefix calls onChange whenever an entry under prefix changes,
+	// until stop is called.
+	WatchPrefix(prefix string, onChange func()) (stop func(), err error)
+}
+
+// KVSource returns a WatchableSource serving the entries of store under
+// prefix. The prefix is stripped and slashes become dots, so with prefix
+// "orders/" the entry "orders/database/host" provides "database.host".
+//
+// Changes reported by the store are delivered to LayeredConfig watchers
+// as per-key ChangeEvents.
+func KVSource(store KVStore, prefix string) WatchableSource {
+	return &kvSource{store: store, prefix: prefix}
+}
+
+type kvSource struct {
+	store  KVStore
+	prefix string
+}
+
+func (s *kvSource) Name() string { return "kv:" + s.prefix }
+
+func (s *kvSource) Load(ctx context.Context) (map[string]any, error) {
+	entries, err := s.store.List(ctx, s.prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]any, len(entries))
+	for key, value := range entries {
+		key = strings.Trim(strings.TrimPrefix(key, s.prefix), "/")
+		if key == "" {
+			continue
+		}
+		settings[strings.ReplaceAll(key, "/", ".")] = value
+	}
+	return settings, nil
+}
+
+func (s *kvSource) Watch(onChange func()) (stop func(), err error) {
+	return s.store.WatchPrefix(s.prefix, onChange)
+}
+
+// MemoryKVStore is an in-memory KVStore, intended for tests.
+type MemoryKVStore struct {
+	mu       sync.Mutex
+	entries  map[string]string
+	watchers map[uint64]memoryKVWatcher
+	nextID   uint64
+}
+
+type memoryKVWatcher struct {
+	prefix   string
+	onChange func()
+}
+
+// Ensure MemoryKVStore implements KVStore interface.
+var _ KVStore = (*MemoryKVStore)(nil)
+
+// NewMemoryKVStore returns an empty MemoryKVStore.
+func NewMemoryKVStore() *MemoryKVStore {
+	return &MemoryKVStore{
+		entries:  make(map[string]string),
+		watchers: make(map[uint64]memoryKVWatcher),
+	}
+}
+
+// List returns a copy of the entries under prefix.
+func (s *MemoryKVStore) List(_ context.Context, prefix string) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make(map[string]string)
+	for key, value := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			entries[key] = value
+		}
+	}
+	return entries, nil
+}
+
+// WatchPrefix calls onChange after every Put or Delete under prefix.
+func (s *MemoryKVStore) WatchPrefix(prefix string, onChange func()) (stop func(), err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	id := s.nextID
+	s.watchers[id] = memoryKVWatcher{prefix: prefix, onChange: onChange}
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.watchers, id)
+	}, nil
+}
+
+// Put stores value under key and notifies watchers.
+func (s *MemoryKVStore) Put(key, value string) {
+	s.mu.Lock()
+	s.entries[key] = value
+	s.mu.Unlock()
+	s.notify(key)
+}
+
+// Delete removes key and notifies watchers.
+func (s *MemoryKVStore) Delete(key string) {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+	s.notify(key)
+}
+
+// notify calls the watchers of key outside the lock.
+func (s *MemoryKVStore) notify(key string) {
+	s.mu.Lock()
+	var callbacks []func()
+	for _, w := range s.watchers {
+		if strings.HasPrefix(key, w.prefix) {
+			callbacks = append(callbacks, w.onChange)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, onChange := range callbacks {
+		onChange()
+	}
+}
@@ -0,0 +1,351 @@
+package hyperion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LayeredConfig is a ConfigWatcher that merges settings from a chain of
+// sources. Sources are listed from lowest to highest precedence: for each
+// key, the last source that provides it wins, and Origin reports which one.
+//
+// A key set by a higher source also shadows conflicting keys of lower
+// sources: a file's "database.host" is dropped if flags set "database" to
+// a scalar, and vice versa.
+//
+// Example:
+//
+//	cfg, err := hyperion.NewLayeredConfig(
+//	    hyperion.MapSource("defaults", map[string]any{"server.port": 8080}),
+//	    viper.FileSource("configs/config.yaml"),
+//	    hyperion.KVSource(consulStore, "orders/"),
+//	    hyperion.EnvSource("APP"),
+//	    viper.FlagSource(pflag.CommandLine),
+//	)
+//
+//	cfg.Origin("server.port") // "env" if APP_SERVER_PORT is set
+type LayeredConfig struct {
+	sources   []ConfigSource
+	loaded    []map[string]any             // Flattened settings per source
+	settings  map[string]any               // Effective flattened settings
+	origins   map[string]string            // Winning source name per key
+	callbacks map[uint64]func(ChangeEvent) // Registered callbacks
+	stops     []func()                     // Stops source watches
+	mu        sync.RWMutex                 // Protects all fields above
+	nextID    uint64                       // Atomic counter for callback IDs
+}
+
+// Ensure LayeredConfig implements ConfigWatcher interface.
+var _ ConfigWatcher = (*LayeredConfig)(nil)
+
+// Ensure LayeredConfig implements TypedConfig interface.
+var _ TypedConfig = (*LayeredConfig)(nil)
+
+// Ensure LayeredConfig implements OriginConfig interface.
+var _ OriginConfig = (*LayeredConfig)(nil)
+
+// NewLayeredConfig loads every source and merges them.
+// Returns an error if any source fails to load.
+func NewLayeredConfig(sources ...ConfigSource) (*LayeredConfig, error) {
+	c := &LayeredConfig{
+		sources:   sources,
+		loaded:    make([]map[string]any, len(sources)),
+		callbacks: make(map[uint64]func(ChangeEvent)),
+	}
+	for i, source := range sources {
+		settings, err := source.Load(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config source %s: %w", source.Name(), err)
+		}
+		c.loaded[i] = flattenSettings(settings)
+	}
+	c.settings, c.origins = mergeSources(c.sources, c.loaded)
+	return c, nil
+}
+
+// mergeSources computes the effective settings and the winning source of each key.
+func mergeSources(sources []ConfigSource, loaded []map[string]any) (map[string]any, map[string]string) {
+	keys := make(map[string]struct{})
+	for _, settings := range loaded {
+		for key := range settings {
+			keys[key] = struct{}{}
+		}
+	}
+
+	settings := make(map[string]any, len(keys))
+	origins := make(map[string]string, len(keys))
+	rank := make(map[string]int, len(keys))
+	for key := range keys {
+		for i := len(sources) - 1; i >= 0; i-- {
+			value, ok := lookupSource(sources[i], loaded[i], key)
+			if ok {
+				settings[key], origins[key], rank[key] = value, sources[i].Name(), i
+				break
+			}
+		}
+	}
+
+	// Drop the lower-ranked side of every key that conflicts with an ancestor
+	var shadowed []string
+	for key := range settings {
+		for i := strings.IndexByte(key, '.'); i >= 0; i = nextDot(key, i) {
+			ancestor := key[:i]
+			if _, ok := settings[ancestor]; !ok {
+				continue
+			}
+			if rank[ancestor] > rank[key] {
+				shadowed = append(shadowed, key)
+			} else {
+				shadowed = append(shadowed, ancestor)
+			}
+		}
+	}
+	for _, key := range shadowed {
+		delete(settings, key)
+		delete(origins, key)
+	}
+	return settings, origins
+}
+
+// nextDot returns the index of the next '.' in key after i, or -1.
+func nextDot(key string, i int) int {
+	next := strings.IndexByte(key[i+1:], '.')
+	if next < 0 {
+		return -1
+	}
+	return i + 1 + next
+}
+
+// lookupSource returns key from a source's loaded settings, or asks a LookupSource.
+func lookupSource(source ConfigSource, loaded map[string]any, key string) (any, bool) {
+	if value, ok := loaded[key]; ok {
+		return value, true
+	}
+	if ls, ok := source.(LookupSource); ok {
+		return ls.Lookup(key)
+	}
+	return nil, false
+}
+
+// Unmarshal decodes the configuration at key into rawVal.
+// Struct fields are matched by their mapstructure tag, and string values
+// are converted to the field type.
+func (c *LayeredConfig) Unmarshal(key string, rawVal any) error {
+	return decodeInto(c.Get(key), rawVal)
+}
+
+// Get returns the value for key. A key with nested keys below it returns
+// them as a map[string]any.
+func (c *LayeredConfig) Get(key string) any {
+	key = strings.ToLower(key)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if value, ok := c.settings[key]; ok {
+		return value
+	}
+	if section := c.section(key); section != nil {
+		return section
+	}
+	for i := len(c.sources) - 1; i >= 0; i-- {
+		if ls, ok := c.sources[i].(LookupSource); ok {
+			if value, ok := ls.Lookup(key); ok {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+// section returns the settings below key as nested maps, or nil if there
+// are none. The caller must hold c.mu.
+func (c *LayeredConfig) section(key string) map[string]any {
+	var section map[string]any
+	prefix := key + "."
+	if key == "" {
+		prefix = ""
+	}
+	for k, value := range c.settings {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		if section == nil {
+			section = make(map[string]any)
+		}
+		parts := strings.Split(k[len(prefix):], ".")
+		m := section
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := m[part].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				m[part] = next
+			}
+			m = next
+		}
+		m[parts[len(parts)-1]] = value
+	}
+	return section
+}
+
+// GetString returns the value for the given key as a string.
+func (c *LayeredConfig) GetString(key string) string {
+	return toString(c.Get(key))
+}
+
+// GetInt returns the value for the given key as an int.
+func (c *LayeredConfig) GetInt(key string) int {
+	return int(c.GetInt64(key))
+}
+
+// GetInt64 returns the value for the given key as an int64.
+func (c *LayeredConfig) GetInt64(key string) int64 {
+	n, _ := toInt64(c.Get(key))
+	return n
+}
+
+// GetBool returns the value for the given key as a bool.
+func (c *LayeredConfig) GetBool(key string) bool {
+	b, _ := toBool(c.Get(key))
+	return b
+}
+
+// GetFloat64 returns the value for the given key as a float64.
+func (c *LayeredConfig) GetFloat64(key string) float64 {
+	f, _ := toFloat64(c.Get(key))
+	return f
+}
+
+// GetStringSlice returns the value for the given key as a string slice.
+// Strings are split on commas.
+func (c *LayeredConfig) GetStringSlice(key string) []string {
+	return toStringSlice(c.Get(key))
+}
+
+// GetDuration returns the value for the given key as a time.Duration.
+// This implements the TypedConfig interface.
+func (c *LayeredConfig) GetDuration(key string) time.Duration {
+	d, _ := toDuration(c.Get(key))
+	return d
+}
+
+// GetStringMap returns the value for the given key as a map.
+// This implements the TypedConfig interface.
+func (c *LayeredConfig) GetStringMap(key string) map[string]any {
+	return toStringMap(c.Get(key))
+}
+
+// IsSet checks if the key is set in the configuration.
+func (c *LayeredConfig) IsSet(key string) bool {
+	return c.Get(key) != nil
+}
+
+// AllKeys returns all keys in the configuration, sorted.
+func (c *LayeredConfig) AllKeys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return sortedKeys(c.settings)
+}
+
+// Origin returns the name of the source that provided the value at key.
+// This implements the OriginConfig interface.
+func (c *LayeredConfig) Origin(key string) string {
+	key = strings.ToLower(key)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.origins[key]
+}
+
+// Watch registers callback for changes reported by WatchableSources.
+// On each change the source is reloaded and callback is invoked once per
+// changed key. If the source fails to reload, callback receives a single
+// ChangeRejected event and the previous settings stay in effect.
+func (c *LayeredConfig) Watch(callback func(event ChangeEvent)) (stop func(), err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := atomic.AddUint64(&c.nextID, 1)
+	c.callbacks[id] = callback
+
+	// Watch the sources once, when the first callback is registered
+	if len(c.callbacks) == 1 {
+		if err := c.watchSources(); err != nil {
+			delete(c.callbacks, id)
+			return nil, err
+		}
+	}
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if _, ok := c.callbacks[id]; !ok {
+			return
+		}
+		delete(c.callbacks, id)
+		if len(c.callbacks) == 0 {
+			c.stopSources()
+		}
+	}, nil
+}
+
+// watchSources starts watching every WatchableSource. The caller must hold c.mu.
+func (c *LayeredConfig) watchSources() error {
+	for i, source := range c.sources {
+		ws, ok := source.(WatchableSource)
+		if !ok {
+			continue
+		}
+		index := i
+		stop, err := ws.Watch(func() { c.reload(index) })
+		if err != nil {
+			c.stopSources()
+			return fmt.Errorf("failed to watch config source %s: %w", source.Name(), err)
+		}
+		c.stops = append(c.stops, stop)
+	}
+	return nil
+}
+
+// stopSources stops every source watch. The caller must hold c.mu.
+func (c *LayeredConfig) stopSources() {
+	for _, stop := range c.stops {
+		stop()
+	}
+	c.stops = nil
+}
+
+// reload reloads the source at index and notifies callbacks of each changed key.
+func (c *LayeredConfig) reload(index int) {
+	source := c.sources[index]
+	settings, err := source.Load(context.Background())
+
+	c.mu.Lock()
+	var changes []ChangeEvent
+	if err != nil {
+		err = fmt.Errorf("failed to reload config source %s: %w", source.Name(), err)
+		changes = []ChangeEvent{{Type: ChangeRejected, Err: err}}
+	} else {
+		c.loaded[index] = flattenSettings(settings)
+		before := c.settings
+		c.settings, c.origins = mergeSources(c.sources, c.loaded)
+		changes = DiffSettings(before, c.settings)
+	}
+
+	callbacks := make([]func(ChangeEvent), 0, len(c.callbacks))
+	for _, cb := range c.callbacks {
+		callbacks = append(callbacks, cb)
+	}
+	c.mu.Unlock()
+
+	for _, event := range changes {
+		for _, cb := range callbacks {
+			cb(event)
+		}
+	}
+}
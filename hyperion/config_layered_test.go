@@ -0,0 +1,228 @@
+package hyperion_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+func TestLayeredConfigPrecedence(t *testing.T) {
+	t.Setenv("LAYERED_DATABASE_MAX_OPEN", "50")
+
+	cfg, err := hyperion.NewLayeredConfig(
+		hyperion.MapSource("defaults", map[string]any{
+			"database": map[string]any{"host": "localhost", "port": 5432, "max_open": 10},
+			"log":      map[string]any{"level": "info"},
+		}),
+		hyperion.MapSource("file", map[string]any{
+			"database.host": "db.internal",
+			"log.level":     "debug",
+		}),
+		hyperion.EnvSource("LAYERED"),
+		hyperion.MapSource("flags", map[string]any{"log.level": "warn"}),
+	)
+	if err != nil {
+		t.Fatalf("NewLayeredConfig() error = %v", err)
+	}
+
+	for key, want := range map[string]string{
+		"database.host":     "file",
+		"database.port":     "defaults",
+		"database.max_open": "env",
+		"log.level":         "flags",
+		"missing":           "",
+	} {
+		if got := cfg.Origin(key); got != want {
+			t.Errorf("Origin(%q) = %q, want %q", key, got, want)
+		}
+	}
+
+	if got := cfg.GetString("database.host"); got != "db.internal" {
+		t.Errorf("GetString(database.host) = %q", got)
+	}
+	if got := cfg.GetInt("database.max_open"); got != 50 {
+		t.Errorf("GetInt(database.max_open) = %d, want 50", got)
+	}
+	if got := cfg.GetString("LOG.Level"); got != "warn" {
+		t.Errorf("GetString(LOG.Level) = %q, want warn", got)
+	}
+
+	want := []string{"database.host", "database.max_open", "database.port", "log.level"}
+	if got := cfg.AllKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AllKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestLayeredConfigShadowing(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(
+		hyperion.MapSource("file", map[string]any{"cache": map[string]any{"size": 10}, "log": "stdout"}),
+		hyperion.MapSource("override", map[string]any{"cache": "disabled", "log.level": "debug"}),
+	)
+	if err != nil {
+		t.Fatalf("NewLayeredConfig() error = %v", err)
+	}
+
+	if cfg.IsSet("cache.size") || cfg.GetString("cache") != "disabled" {
+		t.Errorf("higher scalar should shadow nested keys: cache=%v cache.size set=%v", cfg.Get("cache"), cfg.IsSet("cache.size"))
+	}
+	if got := cfg.GetStringMap("log"); !reflect.DeepEqual(got, map[string]any{"level": "debug"}) {
+		t.Errorf("higher nested key should shadow scalar: log = %v", got)
+	}
+}
+
+func TestLayeredConfigUnmarshal(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(
+		hyperion.MapSource("defaults", map[string]any{
+			"server": map[string]any{"port": 8080, "timeout": "30s", "hosts": []any{"a", "b"}},
+		}),
+		hyperion.MapSource("flags", map[string]any{
+			"server.port":    "9090",
+			"server.debug":   "true",
+			"server.labels":  map[string]any{"team": "core"},
+			"server.hosts":   "x, y",
+			"server.ratio":   "0.5",
+			"server.retries": "3",
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewLayeredConfig() error = %v", err)
+	}
+
+	type server struct {
+		Port    int               `mapstructure:"port"`
+		Timeout time.Duration     `mapstructure:"timeout"`
+		Debug   bool              `mapstructure:"debug"`
+		Hosts   []string          `mapstructure:"hosts"`
+		Labels  map[string]string `mapstructure:"labels"`
+		Ratio   float64           `mapstructure:"ratio"`
+		Retries *uint             `mapstructure:"retries"`
+	}
+	var got server
+	if err := cfg.Unmarshal("server", &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	retries := uint(3)
+	want := server{
+		Port: 9090, Timeout: 30 * time.Second, Debug: true, Hosts: []string{"x", "y"},
+		Labels: map[string]string{"team": "core"}, Ratio: 0.5, Retries: &retries,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+	}
+
+	var bad struct {
+		Port bool `mapstructure:"port"`
+	}
+	if err := cfg.Unmarshal("server", &bad); err == nil {
+		t.Error("Unmarshal() of a number into bool should fail")
+	}
+
+	// Bind works on top of Unmarshal
+	type withDefaults struct {
+		Port int    `mapstructure:"port"`
+		Mode string `mapstructure:"mode" default:"lazy"`
+	}
+	bound, err := hyperion.Bind[withDefaults](cfg, "server")
+	if err != nil || bound.Port != 9090 || bound.Mode != "lazy" {
+		t.Errorf("Bind() = %+v, %v", bound, err)
+	}
+}
+
+func TestLayeredConfigKVWatch(t *testing.T) {
+	store := hyperion.NewMemoryKVStore()
+	store.Put("orders/database/host", "kv-host")
+	store.Put("other/database/host", "ignored")
+
+	cfg, err := hyperion.NewLayeredConfig(
+		hyperion.MapSource("defaults", map[string]any{"database.host": "localhost", "database.port": 5432}),
+		hyperion.KVSource(store, "orders/"),
+	)
+	if err != nil {
+		t.Fatalf("NewLayeredConfig() error = %v", err)
+	}
+	if got := cfg.GetString("database.host"); got != "kv-host" {
+		t.Fatalf("GetString(database.host) = %q, want kv-host", got)
+	}
+	if got := cfg.Origin("database.host"); got != "kv:orders/" {
+		t.Errorf("Origin(database.host) = %q, want kv:orders/", got)
+	}
+
+	var events []hyperion.ChangeEvent
+	stop, err := cfg.Watch(func(event hyperion.ChangeEvent) {
+		events = append(events, event)
+	})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	store.Put("orders/database/port", "6432")
+	store.Delete("orders/database/host")
+	store.Put("other/database/port", "1")
+
+	want := []hyperion.ChangeEvent{
+		{Key: "database.port", Value: "6432", OldValue: 5432, Type: hyperion.ChangeModified},
+		{Key: "database.host", Value: "localhost", OldValue: "kv-host", Type: hyperion.ChangeModified},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+	if got := cfg.Origin("database.host"); got != "defaults" {
+		t.Errorf("Origin(database.host) after delete = %q, want defaults", got)
+	}
+
+	stop()
+	store.Put("orders/database/port", "7000")
+	if len(events) != 2 {
+		t.Errorf("events after stop = %d, want 2", len(events))
+	}
+}
+
+type failingSource struct {
+	hyperion.ConfigSource
+	err      error
+	onChange func()
+}
+
+func (s *failingSource) Load(ctx context.Context) (map[string]any, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.ConfigSource.Load(ctx)
+}
+
+func (s *failingSource) Watch(onChange func()) (func(), error) {
+	s.onChange = onChange
+	return func() {}, nil
+}
+
+func TestLayeredConfigRejectedReload(t *testing.T) {
+	source := &failingSource{ConfigSource: hyperion.MapSource("remote", map[string]any{"a": 1})}
+	cfg, err := hyperion.NewLayeredConfig(source)
+	if err != nil {
+		t.Fatalf("NewLayeredConfig() error = %v", err)
+	}
+
+	var events []hyperion.ChangeEvent
+	if _, err := cfg.Watch(func(event hyperion.ChangeEvent) { events = append(events, event) }); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	source.err = errors.New("connection refused")
+	source.onChange()
+
+	if len(events) != 1 || events[0].Type != hyperion.ChangeRejected || !errors.Is(events[0].Err, source.err) {
+		t.Errorf("events = %+v, want one rejected event", events)
+	}
+	if got := cfg.GetInt("a"); got != 1 {
+		t.Errorf("GetInt(a) after rejected reload = %d, want 1", got)
+	}
+
+	if _, err := hyperion.NewLayeredConfig(source); err == nil {
+		t.Error("NewLayeredConfig() with a failing source should fail")
+	}
+}
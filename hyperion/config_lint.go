@@ -0,0 +1,346 @@
+package hyperion
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// LintSeverity classifies a LintIssue.
+type LintSeverity uint8
+
+const (
+	// LintError is a problem that would break or be silently ignored at
+	// runtime: an unknown key, a type mismatch or a validation failure.
+	LintError LintSeverity = iota
+
+	// LintWarning is a key in a section no ConfigSection describes.
+	LintWarning
+)
+
+// String returns the lowercase name of the severity.
+func (s LintSeverity) String() string {
+	if s == LintWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// LintIssue is a problem found by LintConfig.
+type LintIssue struct {
+	// Key is the configuration key the issue refers to.
+	Key string
+
+	// Message describes the issue.
+	Message string
+
+	// Severity tells whether the issue is an error or a warning.
+	Severity LintSeverity
+}
+
+// String formats the issue as "severity: key: message".
+func (i LintIssue) String() string {
+	return i.Severity.String() + ": " + i.Key + ": " + i.Message
+}
+
+// LintConfig checks cfg against the registered sections and returns the
+// issues found, sorted by key:
+//
+//   - keys that match no field of their section, with a suggestion for
+//     likely typos ("tracing.sample_rte: unknown key, did you mean sample_rate?")
+//   - values that cannot be converted to their field type
+//   - validation failures, from ConfigSection.Validate or the validate tags
+//   - top-level keys outside every section, as warnings
+//
+// Values containing unresolved ${...} placeholders are not type-checked.
+func LintConfig(cfg Config, sections []ConfigSection) []LintIssue {
+	var issues []LintIssue
+	typeErrors := make(map[string]bool)
+	unregistered := make(map[string]bool)
+
+	keys := append([]string(nil), cfg.AllKeys()...)
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		section, ok := sectionFor(sections, key)
+		if !ok {
+			top, _, _ := strings.Cut(key, ".")
+			if !unregistered[top] {
+				unregistered[top] = true
+				issues = append(issues, LintIssue{Key: top, Message: "no registered config section", Severity: LintWarning})
+			}
+			continue
+		}
+
+		rel := strings.TrimPrefix(strings.TrimPrefix(key, strings.ToLower(section.Key)), ".")
+		t, msg := lookupKeyType(reflect.TypeOf(section.Type), rel)
+		if msg != "" {
+			issues = append(issues, LintIssue{Key: key, Message: msg})
+			continue
+		}
+		if msg = checkValueType(cfg.Get(key), t); msg != "" {
+			issues = append(issues, LintIssue{Key: key, Message: msg})
+			typeErrors[section.Key] = true
+		}
+	}
+
+	// Validate sections whose values all have the right type
+	for _, section := range sections {
+		if typeErrors[section.Key] || !cfg.IsSet(section.Key) {
+			continue
+		}
+		issues = append(issues, validateSection(cfg, section)...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Key < issues[j].Key
+	})
+	return issues
+}
+
+// sectionFor returns the section with the longest key that key belongs to.
+func sectionFor(sections []ConfigSection, key string) (ConfigSection, bool) {
+	var best ConfigSection
+	found := false
+	for _, section := range sections {
+		if KeyHasPrefix(key, section.Key) && (!found || len(section.Key) > len(best.Key)) {
+			best, found = section, true
+		}
+	}
+	return best, found
+}
+
+// validateSection runs the section's validation and converts the result to issues.
+func validateSection(cfg Config, section ConfigSection) []LintIssue {
+	view := lintView{Config: cfg}
+
+	var err error
+	if section.Validate != nil {
+		err = section.Validate(view)
+	} else if t := reflect.TypeOf(section.Type); t != nil {
+		err = bindInto(view, section.Key, reflect.New(t).Interface())
+	}
+	if err == nil {
+		return nil
+	}
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return []LintIssue{{Key: section.Key, Message: err.Error()}}
+	}
+	issues := make([]LintIssue, len(verr.Errors))
+	for i, fe := range verr.Errors {
+		issues[i] = LintIssue{Key: fe.Path, Message: fe.Message}
+	}
+	return issues
+}
+
+// lintView is a Config whose Unmarshal skips values with unresolved
+// ${...} placeholders, so sections using them can still be validated.
+type lintView struct {
+	Config
+}
+
+// Unmarshal decodes the settings at key, without placeholder values, into rawVal.
+func (v lintView) Unmarshal(key string, rawVal any) error {
+	key = strings.ToLower(key)
+	section := make(map[string]any)
+	for _, k := range v.AllKeys() {
+		if !KeyHasPrefix(k, key) {
+			continue
+		}
+		value := v.Get(k)
+		if s, ok := value.(string); ok && strings.Contains(s, "${") {
+			continue
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(k), key), ".")
+		if rel == "" {
+			return decodeInto(value, rawVal)
+		}
+
+		parts := strings.Split(rel, ".")
+		m := section
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := m[part].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				m[part] = next
+			}
+			m = next
+		}
+		m[parts[len(parts)-1]] = value
+	}
+	return decodeInto(section, rawVal)
+}
+
+// lookupKeyType resolves the dotted key rel within type t. It returns the
+// type of the value at rel, or a message if rel matches no field.
+func lookupKeyType(t reflect.Type, rel string) (reflect.Type, string) {
+	if rel == "" {
+		return t, ""
+	}
+
+	for _, part := range strings.Split(rel, ".") {
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch {
+		case t == nil || t.Kind() == reflect.Interface:
+			return nil, ""
+		case t.Kind() == reflect.Map:
+			t = t.Elem()
+		case t.Kind() == reflect.Struct && t != durationType:
+			names := fieldTypes(t)
+			ft, ok := names[strings.ToLower(part)]
+			if !ok {
+				return nil, unknownKeyMessage(part, names)
+			}
+			t = ft
+		default:
+			return nil, fmt.Sprintf("unknown key, %s is not an object", describeType(t))
+		}
+	}
+	return t, ""
+}
+
+// fieldTypes returns the lowercase configuration names of the fields of t,
+// including squashed and embedded ones.
+func fieldTypes(t reflect.Type) map[string]reflect.Type {
+	names := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.ToLower(joinFieldPath("", field))
+		switch name {
+		case "-":
+		case "":
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for embedded, et := range fieldTypes(ft) {
+					names[embedded] = et
+				}
+			}
+		default:
+			names[name] = field.Type
+		}
+	}
+	return names
+}
+
+// unknownKeyMessage reports an unknown key, suggesting the closest field name.
+func unknownKeyMessage(part string, names map[string]reflect.Type) string {
+	best, bestDistance := "", 3
+	for name := range names {
+		if d := editDistance(strings.ToLower(part), name); d < bestDistance || d == bestDistance && name < best {
+			best, bestDistance = name, d
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf("unknown key, did you mean %s?", best)
+	}
+	return "unknown key"
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// checkValueType returns a message if value cannot be decoded into type t.
+func checkValueType(value any, t reflect.Type) string {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || value == nil || t.Kind() == reflect.Interface {
+		return ""
+	}
+	if s, ok := value.(string); ok && strings.Contains(s, "${") {
+		return "" // Resolved at load time
+	}
+
+	var err error
+	switch {
+	case t == durationType:
+		_, err = toDuration(value)
+	case t.Kind() == reflect.Struct || t.Kind() == reflect.Map:
+		if _, ok := value.(map[string]any); !ok {
+			err = errors.New("not an object")
+		}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		switch items := value.(type) {
+		case string, []string:
+		case []any:
+			for i, item := range items {
+				if msg := checkValueType(item, t.Elem()); msg != "" {
+					return fmt.Sprintf("item %d: %s", i, msg)
+				}
+			}
+		default:
+			err = errors.New("not a list")
+		}
+	case t.Kind() == reflect.String:
+		switch value.(type) {
+		case map[string]any, []any:
+			err = errors.New("not a scalar")
+		}
+	case t.Kind() == reflect.Bool:
+		_, err = toBool(value)
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		_, err = toFloat64(value)
+	default:
+		var f float64
+		if f, err = toFloat64(value); err == nil && f != float64(int64(f)) {
+			err = errors.New("not a whole number")
+		} else if err == nil && f < 0 && t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64 {
+			err = errors.New("negative")
+		}
+	}
+	if err != nil {
+		return fmt.Sprintf("expected %s, got %T %v", describeType(t), value, value)
+	}
+	return ""
+}
+
+// describeType names t in lint messages.
+func describeType(t reflect.Type) string {
+	if t == durationType {
+		return "duration"
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	default:
+		return "integer"
+	}
+}
@@ -0,0 +1,121 @@
+package hyperion
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Live holds a typed configuration section that is re-bound whenever the
+// section changes. Load is lock-free and safe for concurrent use.
+//
+// Create a Live with Watch.
+type Live[T any] struct {
+	value atomic.Pointer[T]
+
+	mu        sync.Mutex
+	onChange  []func(old, new T)
+	onError   []func(err error)
+	stopWatch func()
+}
+
+// Watch binds the section at key into T, as Bind does, and re-binds it
+// whenever a key under that section changes.
+//
+// If a re-bind fails (for example because the new values fail validation),
+// the previous value stays in effect and OnError callbacks are invoked.
+// Rejected reloads reported by the watcher are ignored, since the watcher
+// keeps the previous configuration too.
+//
+// Example:
+//
+//	limits, err := hyperion.Watch[RateLimitConfig](watcher, "ratelimit")
+//	if err != nil {
+//	    return err
+//	}
+//	defer limits.Stop()
+//
+//	limits.OnChange(func(old, new RateLimitConfig) {
+//	    limiter.SetLimit(new.RPS)
+//	})
+//
+//	rps := limits.Load().RPS
+func Watch[T any](watcher ConfigWatcher, key string) (*Live[T], error) {
+	initial, err := Bind[T](watcher, key)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Live[T]{}
+	l.value.Store(&initial)
+
+	stop, err := WatchKey(watcher, key, func(event ChangeEvent) {
+		if event.Type == ChangeRejected {
+			return
+		}
+		l.rebind(watcher, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	l.stopWatch = stop
+	return l, nil
+}
+
+// Load returns the current value.
+func (l *Live[T]) Load() T {
+	return *l.value.Load()
+}
+
+// OnChange registers a callback invoked with the old and new value after
+// each successful re-bind that changed the value.
+func (l *Live[T]) OnChange(callback func(old, new T)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onChange = append(l.onChange, callback)
+}
+
+// OnError registers a callback invoked when a re-bind fails.
+func (l *Live[T]) OnError(callback func(err error)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onError = append(l.onError, callback)
+}
+
+// Stop stops watching for changes. The last value remains available.
+func (l *Live[T]) Stop() {
+	l.mu.Lock()
+	stop := l.stopWatch
+	l.stopWatch = nil
+	l.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+}
+
+// rebind binds the section again and publishes the result.
+// Watchers emit one event per changed key, so rebind runs once per key;
+// the DeepEqual check keeps OnChange to one call per actual change.
+func (l *Live[T]) rebind(cfg Config, key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	next, err := Bind[T](cfg, key)
+	if err != nil {
+		for _, callback := range l.onError {
+			callback(err)
+		}
+		return
+	}
+
+	old := l.value.Load()
+	if reflect.DeepEqual(*old, next) {
+		return
+	}
+	l.value.Store(&next)
+
+	for _, callback := range l.onChange {
+		callback(*old, next)
+	}
+}
@@ -0,0 +1,227 @@
+package hyperion
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ConfigSection registers the struct a top-level configuration section
+// decodes into, so tools can describe and check the whole config file.
+//
+// Adapters and applications contribute sections via the
+// "hyperion.config_sections" fx group, which viper.Module collects into
+// the config lint and schema command:
+//
+//	fx.Provide(
+//	    fx.Annotate(
+//	        func() hyperion.ConfigSection {
+//	            return hyperion.ConfigSection{Key: "orders", Type: OrdersConfig{}}
+//	        },
+//	        fx.ResultTags(`group:"hyperion.config_sections"`),
+//	    ),
+//	)
+type ConfigSection struct {
+	// Type is a value of the section's struct type, e.g. OrdersConfig{}.
+	Type any
+
+	// Validate checks the section beyond decoding. If nil, LintConfig
+	// binds the section with Bind, which applies the validate tags.
+	// Set it for structs whose validate tags use another validator's syntax.
+	Validate ConfigValidator
+
+	// Key is the section's configuration key, e.g. "database".
+	Key string
+
+	// Description is included in the generated JSON Schema.
+	Description string
+}
+
+// GenerateConfigSchema returns a JSON Schema (draft 2020-12) describing a
+// config file made of sections, ready to be encoded with encoding/json.
+//
+// Property names follow the mapstructure tags; default tags become
+// "default", and the required, min, max and oneof validate rules become
+// the matching schema keywords. Sections reject unknown keys, while
+// unregistered top-level sections are allowed.
+func GenerateConfigSchema(sections []ConfigSection) map[string]any {
+	root := map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"type":       "object",
+		"properties": map[string]any{},
+	}
+
+	for _, section := range sections {
+		schema := typeSchema(reflect.TypeOf(section.Type))
+		if section.Description != "" {
+			schema["description"] = section.Description
+		}
+
+		// Dotted keys nest the section inside intermediate objects
+		parent := root
+		parts := strings.Split(strings.ToLower(section.Key), ".")
+		for _, part := range parts[:len(parts)-1] {
+			props := parent["properties"].(map[string]any)
+			next, ok := props[part].(map[string]any)
+			if !ok {
+				next = map[string]any{"type": "object", "properties": map[string]any{}}
+				props[part] = next
+			}
+			parent = next
+		}
+		parent["properties"].(map[string]any)[parts[len(parts)-1]] = schema
+	}
+	return root
+}
+
+// typeSchema returns the schema of values of type t.
+func typeSchema(t reflect.Type) map[string]any {
+	if t == nil {
+		return map[string]any{}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == durationType {
+		return map[string]any{
+			"type":        []string{"string", "integer"},
+			"description": "Duration such as \"30s\" or \"1m30s\", or nanoseconds",
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return structSchema(t)
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema returns an object schema with one property per field.
+func structSchema(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	var required []string
+	addStructProperties(t, props, &required)
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// addStructProperties adds the fields of t, including squashed and
+// embedded ones, to props.
+func addStructProperties(t reflect.Type, props map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := joinFieldPath("", field)
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addStructProperties(ft, props, required)
+			}
+			continue
+		}
+
+		schema := typeSchema(field.Type)
+		if def, ok := field.Tag.Lookup("default"); ok {
+			schema["default"] = defaultValue(field.Type, def)
+		}
+		if applyRuleKeywords(schema, field.Type, field.Tag.Get("validate")) {
+			*required = append(*required, name)
+		}
+		props[name] = schema
+	}
+}
+
+// defaultValue parses a default tag into a JSON value of type t.
+// Durations and unparsable defaults are kept as strings.
+func defaultValue(t reflect.Type, def string) any {
+	if t == durationType {
+		return def
+	}
+	v := reflect.New(t).Elem()
+	if err := setFromString(v, def); err != nil {
+		return def
+	}
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+// applyRuleKeywords translates validate rules into schema keywords and
+// reports whether the field is required. Rules without a schema
+// equivalent are skipped.
+func applyRuleKeywords(schema map[string]any, t reflect.Type, tag string) (required bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "required":
+			required = true
+		case "oneof":
+			var enum []any
+			for _, option := range strings.Fields(param) {
+				enum = append(enum, defaultValue(t, option))
+			}
+			schema["enum"] = enum
+		case "min", "max":
+			if t == durationType {
+				continue
+			}
+			limit, err := parseLimit(reflect.New(t).Elem(), param)
+			if err != nil {
+				continue
+			}
+			schema[limitKeyword(t, name)] = limit
+		}
+	}
+	return required
+}
+
+// limitKeyword returns the schema keyword for a min or max rule on type t.
+func limitKeyword(t reflect.Type, rule string) string {
+	switch t.Kind() {
+	case reflect.String:
+		return rule + "Length"
+	case reflect.Slice, reflect.Array:
+		return rule + "Items"
+	case reflect.Map:
+		return rule + "Properties"
+	default:
+		if rule == "min" {
+			return "minimum"
+		}
+		return "maximum"
+	}
+}
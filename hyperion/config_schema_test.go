@@ -0,0 +1,155 @@
+package hyperion_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+type TracingSection struct {
+	Attributes map[string]string `mapstructure:"attributes"`
+	Exporter   string            `mapstructure:"exporter" default:"otlp" validate:"oneof=otlp jaeger"`
+	SampleRate float64           `mapstructure:"sample_rate" default:"1" validate:"min=0,max=1"`
+	Interval   time.Duration     `mapstructure:"interval" default:"10s"`
+	Name       string            `mapstructure:"service_name" validate:"required,max=64"`
+}
+
+type appSection struct {
+	TracingSection `mapstructure:",squash"`
+	Tags           []string `mapstructure:"tags" validate:"max=3"`
+	Workers        *uint    `mapstructure:"workers"`
+}
+
+func testSections() []hyperion.ConfigSection {
+	return []hyperion.ConfigSection{
+		{Key: "tracing", Type: TracingSection{}, Description: "Tracing"},
+		{Key: "services.app", Type: appSection{}},
+		{Key: "database", Type: struct {
+			Port int `mapstructure:"port"`
+		}{}, Validate: func(cfg hyperion.Config) error {
+			if cfg.GetInt("database.port") == 1 {
+				return errors.New("port 1 is reserved")
+			}
+			return nil
+		}},
+	}
+}
+
+func TestGenerateConfigSchema(t *testing.T) {
+	schema := hyperion.GenerateConfigSchema(testSections())
+
+	// Round-trip through JSON to compare against plain values
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("schema is not JSON-encodable: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	tracing := doc["properties"].(map[string]any)["tracing"].(map[string]any)
+	if tracing["additionalProperties"] != false || tracing["description"] != "Tracing" {
+		t.Errorf("tracing schema = %v", tracing)
+	}
+	if got := tracing["required"]; !reflect.DeepEqual(got, []any{"service_name"}) {
+		t.Errorf("required = %v, want [service_name]", got)
+	}
+
+	props := tracing["properties"].(map[string]any)
+	want := map[string]any{
+		"attributes":   map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+		"exporter":     map[string]any{"type": "string", "default": "otlp", "enum": []any{"otlp", "jaeger"}},
+		"sample_rate":  map[string]any{"type": "number", "default": float64(1), "minimum": float64(0), "maximum": float64(1)},
+		"service_name": map[string]any{"type": "string", "maxLength": float64(64)},
+	}
+	for name, w := range want {
+		if !reflect.DeepEqual(props[name], w) {
+			t.Errorf("property %s = %v, want %v", name, props[name], w)
+		}
+	}
+	if interval := props["interval"].(map[string]any); interval["default"] != "10s" {
+		t.Errorf("interval = %v, want default 10s", interval)
+	}
+
+	// Dotted section keys nest; squashed fields are inlined
+	app := doc["properties"].(map[string]any)["services"].(map[string]any)["properties"].(map[string]any)["app"].(map[string]any)
+	appProps := app["properties"].(map[string]any)
+	for _, name := range []string{"exporter", "tags", "workers"} {
+		if _, ok := appProps[name]; !ok {
+			t.Errorf("services.app is missing property %s", name)
+		}
+	}
+	if got := appProps["workers"].(map[string]any)["minimum"]; got != float64(0) {
+		t.Errorf("workers minimum = %v, want 0", got)
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("file", map[string]any{
+		"tracing": map[string]any{
+			"service_name": "orders",
+			"sample_rte":   0.5,
+			"interval":     "often",
+		},
+		"services": map[string]any{"app": map[string]any{
+			"service_name": "app",
+			"exporter":     "zipkin",
+			"tags":         []any{"a", "b", "c", "d"},
+			"workers":      "${WORKERS}",
+		}},
+		"database": map[string]any{"port": 1},
+		"orders":   map[string]any{"enabled": true, "limit": 3},
+	}))
+	if err != nil {
+		t.Fatalf("NewLayeredConfig() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range hyperion.LintConfig(cfg, testSections()) {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		"error: database: port 1 is reserved",
+		"warning: orders: no registered config section",
+		"error: services.app.exporter: must be one of [otlp jaeger], got zipkin",
+		"error: services.app.tags: length must be at most 3",
+		"error: tracing.interval: expected duration, got string often",
+		"error: tracing.sample_rte: unknown key, did you mean sample_rate?",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LintConfig() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestLintConfigTypeMismatch(t *testing.T) {
+	cfg, err := hyperion.NewLayeredConfig(hyperion.MapSource("file", map[string]any{
+		"services": map[string]any{"app": map[string]any{
+			"service_name": map[string]any{"first": "a"},
+			"workers":      -2,
+			"tags":         "x,y",
+		}},
+		"tracing": "off",
+	}))
+	if err != nil {
+		t.Fatalf("NewLayeredConfig() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range hyperion.LintConfig(cfg, testSections()) {
+		got = append(got, issue.Key+": "+issue.Message)
+	}
+	want := []string{
+		"services.app.service_name.first: unknown key, string is not an object",
+		"services.app.workers: expected integer, got int -2",
+		"tracing: expected object, got string off",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LintConfig() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,266 @@
+package hyperion
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// SecretMask replaces secret values in configuration dumps.
+const SecretMask = "******"
+
+// SecretResolver resolves placeholder references of one scheme.
+// The placeholder ${file:/run/secrets/db} is resolved by the resolver whose
+// Scheme is "file", with ref "/run/secrets/db".
+//
+// Implement SecretResolver to read secrets from an external store such as
+// Vault, and register it with the config provider.
+type SecretResolver interface {
+	// Scheme returns the placeholder scheme handled by the resolver, e.g. "env".
+	Scheme() string
+
+	// Resolve returns the value referenced by ref.
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretConfig is an optional interface for Config implementations that
+// track which values were resolved from secrets.
+//
+// Use the IsSecret function rather than asserting this interface directly.
+type SecretConfig interface {
+	// IsSecret reports whether the value at key was resolved from a secret.
+	IsSecret(key string) bool
+}
+
+// IsSecret reports whether the value at key was resolved from a secret.
+// It returns false if cfg does not implement SecretConfig.
+func IsSecret(cfg Config, key string) bool {
+	if sc, ok := cfg.(SecretConfig); ok {
+		return sc.IsSecret(key)
+	}
+	return false
+}
+
+// NewEnvResolver returns a SecretResolver for ${env:NAME} placeholders.
+// It fails if the environment variable is not set.
+func NewEnvResolver() SecretResolver {
+	return envResolver{}
+}
+
+type envResolver struct{}
+
+func (envResolver) Scheme() string { return "env" }
+
+func (envResolver) Resolve(_ context.Context, ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref)
+	}
+	return value, nil
+}
+
+// NewFileResolver returns a SecretResolver for ${file:/path} placeholders,
+// as used for Docker and Kubernetes secrets mounted as files.
+// A single trailing newline is trimmed from the file content.
+func NewFileResolver() SecretResolver {
+	return fileResolver{}
+}
+
+type fileResolver struct{}
+
+func (fileResolver) Scheme() string { return "file" }
+
+func (fileResolver) Resolve(_ context.Context, ref string) (string, error) {
+	data, err := os.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(value, "\r"), nil
+}
+
+// MemoryResolver is a SecretResolver backed by a map, intended for tests.
+type MemoryResolver struct {
+	scheme  string
+	mu      sync.RWMutex
+	secrets map[string]string
+}
+
+// NewMemoryResolver returns a MemoryResolver for scheme holding a copy of secrets.
+func NewMemoryResolver(scheme string, secrets map[string]string) *MemoryResolver {
+	r := &MemoryResolver{scheme: scheme, secrets: make(map[string]string, len(secrets))}
+	for ref, value := range secrets {
+		r.secrets[ref] = value
+	}
+	return r
+}
+
+// Scheme returns the scheme passed to NewMemoryResolver.
+func (r *MemoryResolver) Scheme() string { return r.scheme }
+
+// Resolve returns the secret stored under ref.
+func (r *MemoryResolver) Resolve(_ context.Context, ref string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	value, ok := r.secrets[ref]
+	if !ok {
+		return "", fmt.Errorf("secret %s:%s not found", r.scheme, ref)
+	}
+	return value, nil
+}
+
+// Set stores value under ref, e.g. to simulate a rotated secret.
+func (r *MemoryResolver) Set(ref, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secrets[ref] = value
+}
+
+// Interpolator expands placeholders in configuration values.
+//
+// Supported placeholders:
+//
+//	${NAME}                 the environment variable NAME; fails if unset
+//	${NAME:-default}        the environment variable NAME, or default if unset or empty
+//	${scheme:ref}           the value resolved by the SecretResolver for scheme
+//	${scheme:ref:-default}  as above, or default if the resolver fails
+//	$${...}                 a literal ${...}
+//
+// Values produced by a SecretResolver are secrets; see Expand.
+type Interpolator struct {
+	resolvers map[string]SecretResolver
+	lookupEnv func(string) (string, bool)
+}
+
+// NewInterpolator returns an Interpolator with the env and file resolvers
+// plus the given resolvers. A resolver replaces an earlier one with the
+// same scheme.
+func NewInterpolator(resolvers ...SecretResolver) *Interpolator {
+	i := &Interpolator{
+		resolvers: make(map[string]SecretResolver),
+		lookupEnv: os.LookupEnv,
+	}
+	for _, r := range append([]SecretResolver{NewEnvResolver(), NewFileResolver()}, resolvers...) {
+		if r != nil {
+			i.resolvers[r.Scheme()] = r
+		}
+	}
+	return i
+}
+
+// Expand replaces every placeholder in s. It reports secret as true if any
+// placeholder was resolved by a SecretResolver.
+func (i *Interpolator) Expand(ctx context.Context, s string) (value string, secret bool, err error) {
+	if !strings.Contains(s, "${") {
+		return s, false, nil
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			b.WriteString(s)
+			break
+		}
+		if start > 0 && s[start-1] == '$' {
+			// Escaped: $${...} is written as ${...}
+			b.WriteString(s[:start-1])
+			b.WriteString("${")
+			s = s[start+2:]
+			continue
+		}
+
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return "", false, fmt.Errorf("unterminated placeholder in %q", s)
+		}
+		end += start
+
+		resolved, fromSecret, err := i.resolve(ctx, s[start+2:end])
+		if err != nil {
+			return "", false, err
+		}
+		secret = secret || fromSecret
+
+		b.WriteString(s[:start])
+		b.WriteString(resolved)
+		s = s[end+1:]
+	}
+	return b.String(), secret, nil
+}
+
+// resolve resolves the body of one placeholder.
+func (i *Interpolator) resolve(ctx context.Context, body string) (string, bool, error) {
+	ref, def, hasDefault := strings.Cut(body, ":-")
+
+	scheme, schemeRef, hasScheme := strings.Cut(ref, ":")
+	if !hasScheme {
+		if value, ok := i.lookupEnv(ref); ok && (value != "" || !hasDefault) {
+			return value, false, nil
+		}
+		if hasDefault {
+			return def, false, nil
+		}
+		return "", false, fmt.Errorf("placeholder ${%s}: environment variable %s is not set", body, ref)
+	}
+
+	r, ok := i.resolvers[scheme]
+	if !ok {
+		return "", false, fmt.Errorf("placeholder ${%s}: no secret resolver for scheme %q", body, scheme)
+	}
+	value, err := r.Resolve(ctx, schemeRef)
+	if err != nil {
+		if hasDefault {
+			return def, false, nil
+		}
+		return "", false, fmt.Errorf("placeholder ${%s}: %w", body, err)
+	}
+	return value, true, nil
+}
+
+// ExpandValue expands placeholders in every string within value, recursing
+// into slices and maps as produced by configuration decoders. It returns
+// the expanded value and whether any secret was resolved.
+func (i *Interpolator) ExpandValue(ctx context.Context, value any) (any, bool, error) {
+	switch v := value.(type) {
+	case string:
+		return i.Expand(ctx, v)
+	case []string:
+		out := make([]string, len(v))
+		secret := false
+		for idx, s := range v {
+			expanded, isSecret, err := i.Expand(ctx, s)
+			if err != nil {
+				return nil, false, err
+			}
+			out[idx], secret = expanded, secret || isSecret
+		}
+		return out, secret, nil
+	case []any:
+		out := make([]any, len(v))
+		secret := false
+		for idx, elem := range v {
+			expanded, isSecret, err := i.ExpandValue(ctx, elem)
+			if err != nil {
+				return nil, false, err
+			}
+			out[idx], secret = expanded, secret || isSecret
+		}
+		return out, secret, nil
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		secret := false
+		for key, elem := range v {
+			expanded, isSecret, err := i.ExpandValue(ctx, elem)
+			if err != nil {
+				return nil, false, err
+			}
+			out[key], secret = expanded, secret || isSecret
+		}
+		return out, secret, nil
+	default:
+		return value, false, nil
+	}
+}
@@ -0,0 +1,97 @@
+package hyperion_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mapoio/hyperion"
+)
+
+func TestInterpolatorExpand(t *testing.T) {
+	t.Setenv("HYPERION_TEST_HOST", "db.internal")
+	t.Setenv("HYPERION_TEST_EMPTY", "")
+
+	secretFile := filepath.Join(t.TempDir(), "db")
+	if err := os.WriteFile(secretFile, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	interp := hyperion.NewInterpolator(hyperion.NewMemoryResolver("vault", map[string]string{
+		"db/password": "from-vault",
+	}))
+
+	tests := []struct {
+		in         string
+		want       string
+		wantSecret bool
+	}{
+		{"plain", "plain", false},
+		{"${HYPERION_TEST_HOST}:5432", "db.internal:5432", false},
+		{"${HYPERION_TEST_MISSING:-localhost}", "localhost", false},
+		{"${HYPERION_TEST_EMPTY:-fallback}", "fallback", false},
+		{"${env:HYPERION_TEST_HOST}", "db.internal", true},
+		{"${file:" + secretFile + "}", "s3cret", true},
+		{"user:${vault:db/password}@host", "user:from-vault@host", true},
+		{"${vault:missing:-none}", "none", false},
+		{"$${HYPERION_TEST_HOST}", "${HYPERION_TEST_HOST}", false},
+	}
+	for _, tt := range tests {
+		got, secret, err := interp.Expand(context.Background(), tt.in)
+		if err != nil {
+			t.Errorf("Expand(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want || secret != tt.wantSecret {
+			t.Errorf("Expand(%q) = %q, %v; want %q, %v", tt.in, got, secret, tt.want, tt.wantSecret)
+		}
+	}
+}
+
+func TestInterpolatorExpandErrors(t *testing.T) {
+	interp := hyperion.NewInterpolator()
+	for _, in := range []string{
+		"${HYPERION_TEST_MISSING}",
+		"${env:HYPERION_TEST_MISSING}",
+		"${file:/nonexistent/secret}",
+		"${vault:db/password}",
+		"${unterminated",
+	} {
+		if _, _, err := interp.Expand(context.Background(), in); err == nil {
+			t.Errorf("Expand(%q) should fail", in)
+		}
+	}
+}
+
+func TestInterpolatorExpandValue(t *testing.T) {
+	interp := hyperion.NewInterpolator(hyperion.NewMemoryResolver("mem", map[string]string{"a": "A"}))
+
+	in := []any{"${mem:a}", map[string]any{"nested": "x-${mem:a}"}, 42}
+	got, secret, err := interp.ExpandValue(context.Background(), in)
+	if err != nil {
+		t.Fatalf("ExpandValue() error = %v", err)
+	}
+	want := []any{"A", map[string]any{"nested": "x-A"}, 42}
+	if !reflect.DeepEqual(got, want) || !secret {
+		t.Errorf("ExpandValue() = %v, %v; want %v, true", got, secret, want)
+	}
+}
+
+func TestMemoryResolverSet(t *testing.T) {
+	r := hyperion.NewMemoryResolver("mem", nil)
+	if _, err := r.Resolve(context.Background(), "key"); err == nil {
+		t.Error("Resolve() of missing secret should fail")
+	}
+	r.Set("key", "rotated")
+	if got, err := r.Resolve(context.Background(), "key"); err != nil || got != "rotated" {
+		t.Errorf("Resolve() = %q, %v; want rotated", got, err)
+	}
+}
+
+func TestIsSecret(t *testing.T) {
+	if hyperion.IsSecret(hyperion.NewNoOpConfig(), "database.password") {
+		t.Error("IsSecret() should be false for configs without SecretConfig")
+	}
+}
@@ -0,0 +1,122 @@
+package hyperion
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ConfigSource provides settings to a LayeredConfig.
+type ConfigSource interface {
+	// Name identifies the source in Origin, e.g. "file:config.yaml" or "env".
+	Name() string
+
+	// Load returns the source's settings. Values may be nested maps, as
+	// decoded from a file, or flat dotted keys such as "database.host".
+	Load(ctx context.Context) (map[string]any, error)
+}
+
+// LookupSource is a ConfigSource whose keys cannot be listed
+// unambiguously, such as environment variables: APP_DATABASE_MAX_OPEN may
+// mean "database.max_open" or "database.max.open". LayeredConfig asks it
+// for each key provided by the other sources instead.
+type LookupSource interface {
+	ConfigSource
+
+	// Lookup returns the value for key, if the source has one.
+	Lookup(key string) (any, bool)
+}
+
+// WatchableSource is a ConfigSource that reports changes.
+// LayeredConfig reloads the source and notifies its watchers on change.
+type WatchableSource interface {
+	ConfigSource
+
+	// Watch calls onChange whenever the source's settings may have changed,
+	// until stop is called.
+	Watch(onChange func()) (stop func(), err error)
+}
+
+// OriginConfig is an optional interface for Config implementations that
+// know which source provided each value.
+type OriginConfig interface {
+	// Origin returns the name of the source that provided the value at key,
+	// or "" if the key is not set.
+	Origin(key string) string
+}
+
+// MapSource returns a ConfigSource serving settings from memory, for
+// defaults and tests. The map is copied.
+func MapSource(name string, settings map[string]any) ConfigSource {
+	return &mapSource{name: name, settings: flattenSettings(settings)}
+}
+
+type mapSource struct {
+	name     string
+	settings map[string]any
+}
+
+func (s *mapSource) Name() string { return s.name }
+
+func (s *mapSource) Load(context.Context) (map[string]any, error) {
+	return s.settings, nil
+}
+
+// EnvSource returns a ConfigSource reading environment variables with prefix.
+// With prefix "APP", the key "database.max_open" is read from
+// APP_DATABASE_MAX_OPEN. Only keys provided by other sources are looked up.
+func EnvSource(prefix string) ConfigSource {
+	return &envSource{prefix: prefix, lookupEnv: os.LookupEnv}
+}
+
+type envSource struct {
+	prefix    string
+	lookupEnv func(string) (string, bool)
+}
+
+func (s *envSource) Name() string { return "env" }
+
+func (s *envSource) Load(context.Context) (map[string]any, error) {
+	return nil, nil
+}
+
+func (s *envSource) Lookup(key string) (any, bool) {
+	name := strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	if s.prefix != "" {
+		name = strings.ToUpper(s.prefix) + "_" + name
+	}
+	return s.lookupEnv(name)
+}
+
+// flattenSettings converts nested maps to dotted, lowercase keys.
+// Keys that are already dotted are kept as they are.
+func flattenSettings(settings map[string]any) map[string]any {
+	flat := make(map[string]any, len(settings))
+	var walk func(prefix string, m map[string]any)
+	walk = func(prefix string, m map[string]any) {
+		for key, value := range m {
+			key = strings.ToLower(joinPath(prefix, key))
+			switch nested := value.(type) {
+			case map[string]any:
+				walk(key, nested)
+			case map[any]any:
+				walk(key, toStringMap(nested))
+			default:
+				flat[key] = value
+			}
+		}
+	}
+	walk("", settings)
+	return flat
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,90 @@
+package hyperion
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TypedConfig is an optional interface for Config implementations with
+// native typed accessors beyond the Config interface.
+//
+// Use the GetDuration and GetStringMap functions rather than asserting this
+// interface directly; they convert from Config.Get for implementations
+// that do not implement it.
+type TypedConfig interface {
+	// GetDuration returns the value for the given key as a time.Duration.
+	GetDuration(key string) time.Duration
+
+	// GetStringMap returns the value for the given key as a map.
+	GetStringMap(key string) map[string]any
+}
+
+// GetDuration returns the value at key as a time.Duration.
+//
+// Strings are parsed with time.ParseDuration ("30s", "1m30s"); a bare
+// number is taken as nanoseconds. It returns 0 if the key is not set or
+// the value cannot be converted.
+func GetDuration(cfg Config, key string) time.Duration {
+	if typed, ok := cfg.(TypedConfig); ok {
+		return typed.GetDuration(key)
+	}
+	d, _ := toDuration(cfg.Get(key))
+	return d
+}
+
+// GetStringMap returns the value at key as a map with string keys.
+// It returns an empty map if the key is not set or is not a map.
+func GetStringMap(cfg Config, key string) map[string]any {
+	if typed, ok := cfg.(TypedConfig); ok {
+		return typed.GetStringMap(key)
+	}
+	return toStringMap(cfg.Get(key))
+}
+
+// toDuration converts a configuration value to a time.Duration.
+func toDuration(value any) (time.Duration, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case time.Duration:
+		return v, nil
+	case int:
+		return time.Duration(v), nil
+	case int64:
+		return time.Duration(v), nil
+	case int32:
+		return time.Duration(v), nil
+	case uint:
+		return time.Duration(v), nil
+	case uint64:
+		return time.Duration(v), nil
+	case float64:
+		return time.Duration(v), nil
+	case string:
+		s := strings.TrimSpace(v)
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return time.Duration(n), nil
+		}
+		return time.ParseDuration(s)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to time.Duration", value)
+	}
+}
+
+// toStringMap converts a configuration value to a map with string keys.
+func toStringMap(value any) map[string]any {
+	switch v := value.(type) {
+	case map[string]any:
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, val := range v {
+			m[fmt.Sprint(key)] = val
+		}
+		return m
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,255 @@
+package hyperion
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldError describes a configuration field that failed validation.
+type FieldError struct {
+	// Path is the full configuration key of the field, e.g. "database.port".
+	Path string
+
+	// Rule is the validate rule that failed, e.g. "max=65535".
+	Rule string
+
+	// Message describes the failure.
+	Message string
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError reports every field of a bound configuration that
+// failed validation. It is returned by Bind.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return "config validation failed: " + strings.Join(msgs, "; ")
+}
+
+// validateValue checks the validate tags of v, then calls the Validate
+// method of ptr if it has one.
+func validateValue(v reflect.Value, path string, ptr any) error {
+	var errs []FieldError
+	if err := validateStruct(v, path, &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+
+	if validator, ok := ptr.(interface{ Validate() error }); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("invalid %s: %w", displayKey(path), err)
+		}
+	}
+	return nil
+}
+
+// validateStruct collects field errors for v and its nested structs.
+// It returns an error only for malformed validate tags.
+func validateStruct(v reflect.Value, path string, errs *[]FieldError) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return validateStruct(v.Elem(), path, errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateStruct(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		fieldPath := joinFieldPath(path, field)
+
+		if tag := field.Tag.Get("validate"); tag != "" {
+			fe, err := checkRules(fv, tag)
+			if err != nil {
+				return fmt.Errorf("invalid validate tag on %s: %w", displayKey(fieldPath), err)
+			}
+			if fe != nil {
+				fe.Path = fieldPath
+				*errs = append(*errs, *fe)
+				continue
+			}
+		}
+
+		if fv.Type() != durationType {
+			if err := validateStruct(fv, fieldPath, errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkRules applies the comma-separated rules in tag to v and returns the
+// first failure. A rule may list alternatives separated by "|", e.g.
+// "hostname|ip", and passes if any of them does.
+func checkRules(v reflect.Value, tag string) (*FieldError, error) {
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "omitempty" {
+			if v.IsZero() {
+				return nil, nil
+			}
+			continue
+		}
+
+		var msgs []string
+		for _, alternative := range strings.Split(rule, "|") {
+			msg, err := checkRule(v, alternative)
+			if err != nil {
+				return nil, err
+			}
+			if msg == "" {
+				msgs = nil
+				break
+			}
+			msgs = append(msgs, msg)
+		}
+
+		if len(msgs) > 0 {
+			return &FieldError{Rule: rule, Message: strings.Join(msgs, " or ")}, nil
+		}
+	}
+	return nil, nil
+}
+
+// checkRule applies a single rule to v and returns a failure message, or ""
+// if v satisfies it.
+func checkRule(v reflect.Value, rule string) (string, error) {
+	name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+	switch name {
+	case "required":
+		if v.IsZero() || (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0 {
+			return "is required", nil
+		}
+	case "min", "max":
+		limit, err := parseLimit(v, param)
+		if err != nil {
+			return "", err
+		}
+		got, subject := measure(v)
+		if name == "min" && got < limit {
+			return fmt.Sprintf("%s must be at least %s", subject, param), nil
+		}
+		if name == "max" && got > limit {
+			return fmt.Sprintf("%s must be at most %s", subject, param), nil
+		}
+	case "oneof":
+		if options := strings.Fields(param); !containsValue(options, v) {
+			return fmt.Sprintf("must be one of [%s], got %v", strings.Join(options, " "), v.Interface()), nil
+		}
+	case "hostname":
+		if v.Kind() != reflect.String {
+			return "", fmt.Errorf("rule %q needs a string field", name)
+		}
+		if !isHostname(v.String()) {
+			return fmt.Sprintf("must be a valid hostname, got %q", v.String()), nil
+		}
+	case "ip":
+		if v.Kind() != reflect.String {
+			return "", fmt.Errorf("rule %q needs a string field", name)
+		}
+		if net.ParseIP(v.String()) == nil {
+			return fmt.Sprintf("must be a valid IP address, got %q", v.String()), nil
+		}
+	default:
+		return "", fmt.Errorf("unknown rule %q", name)
+	}
+	return "", nil
+}
+
+// isHostname reports whether s is an RFC 1123 hostname: dot-separated
+// labels of letters, digits and hyphens, neither starting nor ending with
+// a hyphen.
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// measure returns the number compared by min and max: the length of
+// strings, slices and maps, and the value of numbers.
+func measure(v reflect.Value) (float64, string) {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return float64(v.Len()), "length"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), "value"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), "value"
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), "value"
+	default:
+		return 0, "value"
+	}
+}
+
+// parseLimit parses a min or max parameter for v.
+// Durations accept units; all other kinds take a number.
+func parseLimit(v reflect.Value, param string) (float64, error) {
+	if v.Type() == durationType {
+		if d, err := time.ParseDuration(param); err == nil {
+			return float64(d), nil
+		}
+	}
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit %q", param)
+	}
+	return limit, nil
+}
+
+// containsValue reports whether the string form of v is one of options.
+func containsValue(options []string, v reflect.Value) bool {
+	s := fmt.Sprint(v.Interface())
+	for _, option := range options {
+		if s == option {
+			return true
+		}
+	}
+	return false
+}
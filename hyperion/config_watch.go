@@ -0,0 +1,67 @@
+package hyperion
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WatchKey registers callback for changes to key and the keys nested below it.
+// A prefix of "log" matches "log" and "log.level" but not "logger.level".
+// Keys are compared case-insensitively. An empty prefix matches every key.
+//
+// ChangeRejected events carry no key and are always delivered, whatever the
+// prefix, so subscribers learn that a reload they care about was refused.
+//
+// Example:
+//
+//	stop, err := hyperion.WatchKey(watcher, "log", func(e hyperion.ChangeEvent) {
+//	    if e.Key == "log.level" {
+//	        logger.SetLevel(parseLevel(e.Value))
+//	    }
+//	})
+func WatchKey(watcher ConfigWatcher, prefix string, callback func(event ChangeEvent)) (stop func(), err error) {
+	return watcher.Watch(func(event ChangeEvent) {
+		if event.Type == ChangeRejected || KeyHasPrefix(event.Key, prefix) {
+			callback(event)
+		}
+	})
+}
+
+// KeyHasPrefix reports whether key equals prefix or is nested below it.
+// Keys are compared case-insensitively.
+func KeyHasPrefix(key, prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	if len(key) < len(prefix) || !strings.EqualFold(key[:len(prefix)], prefix) {
+		return false
+	}
+	return len(key) == len(prefix) || key[len(prefix)] == '.'
+}
+
+// DiffSettings compares two flattened configuration snapshots (key to value)
+// and returns one ChangeEvent per added, modified or removed key, sorted by key.
+// Values are compared with reflect.DeepEqual.
+func DiffSettings(before, after map[string]any) []ChangeEvent {
+	var events []ChangeEvent
+	for key, newValue := range after {
+		oldValue, existed := before[key]
+		switch {
+		case !existed:
+			events = append(events, ChangeEvent{Key: key, Value: newValue, Type: ChangeAdded})
+		case !reflect.DeepEqual(oldValue, newValue):
+			events = append(events, ChangeEvent{Key: key, Value: newValue, OldValue: oldValue, Type: ChangeModified})
+		}
+	}
+	for key, oldValue := range before {
+		if _, exists := after[key]; !exists {
+			events = append(events, ChangeEvent{Key: key, OldValue: oldValue, Type: ChangeRemoved})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Key < events[j].Key
+	})
+	return events
+}
@@ -0,0 +1,98 @@
+package hyperion_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mapoio/hyperion"
+)
+
+func TestDiffSettings(t *testing.T) {
+	before := map[string]any{
+		"log.level":     "info",
+		"log.output":    "stdout",
+		"database.host": "localhost",
+		"tags":          []any{"a", "b"},
+	}
+	after := map[string]any{
+		"log.level":     "debug",
+		"log.output":    "stdout",
+		"database.port": 5432,
+		"tags":          []any{"a", "b"},
+	}
+
+	want := []hyperion.ChangeEvent{
+		{Key: "database.host", OldValue: "localhost", Type: hyperion.ChangeRemoved},
+		{Key: "database.port", Value: 5432, Type: hyperion.ChangeAdded},
+		{Key: "log.level", Value: "debug", OldValue: "info", Type: hyperion.ChangeModified},
+	}
+	if got := hyperion.DiffSettings(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffSettings() = %+v, want %+v", got, want)
+	}
+
+	if got := hyperion.DiffSettings(after, after); len(got) != 0 {
+		t.Errorf("DiffSettings() of identical snapshots = %+v, want none", got)
+	}
+}
+
+func TestKeyHasPrefix(t *testing.T) {
+	tests := []struct {
+		key, prefix string
+		want        bool
+	}{
+		{"log.level", "log", true},
+		{"log", "log", true},
+		{"LOG.Level", "log", true},
+		{"logger.level", "log", false},
+		{"lo", "log", false},
+		{"database.pool.size", "database.pool", true},
+		{"anything", "", true},
+	}
+	for _, tt := range tests {
+		if got := hyperion.KeyHasPrefix(tt.key, tt.prefix); got != tt.want {
+			t.Errorf("KeyHasPrefix(%q, %q) = %v, want %v", tt.key, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestChangeTypeString(t *testing.T) {
+	for typ, want := range map[hyperion.ChangeType]string{
+		hyperion.ChangeModified: "modified",
+		hyperion.ChangeAdded:    "added",
+		hyperion.ChangeRemoved:  "removed",
+		hyperion.ChangeRejected: "rejected",
+	} {
+		if got := typ.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", typ, got, want)
+		}
+	}
+}
+
+func TestWatchKey(t *testing.T) {
+	watcher := newMapConfig(map[string]any{})
+	var got []hyperion.ChangeEvent
+	stop, err := hyperion.WatchKey(watcher, "log", func(e hyperion.ChangeEvent) {
+		got = append(got, e)
+	})
+	if err != nil {
+		t.Fatalf("WatchKey() error = %v", err)
+	}
+	defer stop()
+
+	rejected := hyperion.ChangeEvent{Type: hyperion.ChangeRejected, Err: errors.New("invalid config")}
+	events := []hyperion.ChangeEvent{
+		{Key: "log.level", Value: "debug"},
+		{Key: "logger.level", Value: "debug"},
+		{Key: "database.host", Value: "db"},
+		rejected,
+	}
+	for _, e := range events {
+		watcher.emit(e)
+	}
+
+	want := []hyperion.ChangeEvent{events[0], rejected}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered %+v, want %+v", got, want)
+	}
+}
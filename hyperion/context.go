@@ -64,6 +64,7 @@ type Context interface {
 	// Logger returns the logger associated with this context.
 	// When using OpenTelemetry adapters, logs are automatically correlated
 	// with the current trace via trace ID and span ID.
+	// Fields from registered ContextFieldExtractors are added as well.
 	Logger() Logger
 
 	// DB returns the database executor associated with this context.
@@ -152,15 +153,23 @@ type hyperionContext struct {
 	meter        Meter
 	span         Span          // Current active span (nil if no span)
 	interceptors []Interceptor // Global interceptors from fx (can be empty)
+
+	fieldExtractors []ContextFieldExtractor // Log field extractors from fx (can be empty)
 }
 
 func (c *hyperionContext) Logger() Logger {
 	// If logger implements ContextAwareLogger, bind it to current context
 	// for automatic trace context injection
-	if contextAware, ok := c.logger.(ContextAwareLogger); ok {
-		return contextAware.WithContext(c.Context)
+	logger := c.logger
+	if contextAware, ok := logger.(ContextAwareLogger); ok {
+		logger = contextAware.WithContext(c.Context)
+	}
+
+	// Add fields extracted from the context (request ID, tenant, ...)
+	if fields := extractContextFields(c.Context, c.fieldExtractors); len(fields) > 0 {
+		logger = logger.With(fields...)
 	}
-	return c.logger
+	return logger
 }
 
 func (c *hyperionContext) DB() Executor {
@@ -187,13 +196,14 @@ func (c *hyperionContext) Span() Span {
 // It preserves all the other fields (logger, db, tracer, meter, span, interceptors) from the current context.
 func (c *hyperionContext) withContext(ctx context.Context) *hyperionContext {
 	return &hyperionContext{
-		Context:      ctx,
-		logger:       c.logger,
-		db:           c.db,
-		tracer:       c.tracer,
-		meter:        c.meter,
-		span:         c.span,
-		interceptors: c.interceptors,
+		Context:         ctx,
+		logger:          c.logger,
+		db:              c.db,
+		tracer:          c.tracer,
+		meter:           c.meter,
+		span:            c.span,
+		interceptors:    c.interceptors,
+		fieldExtractors: c.fieldExtractors,
 	}
 }
 
@@ -228,13 +238,14 @@ func WithDB(ctx Context, db Executor) Context {
 	}
 
 	return &hyperionContext{
-		Context:      hctx.Context,
-		logger:       hctx.logger,
-		db:           db, // Replace DB
-		tracer:       hctx.tracer,
-		meter:        hctx.meter,
-		span:         hctx.span,
-		interceptors: hctx.interceptors,
+		Context:         hctx.Context,
+		logger:          hctx.logger,
+		db:              db, // Replace DB
+		tracer:          hctx.tracer,
+		meter:           hctx.meter,
+		span:            hctx.span,
+		interceptors:    hctx.interceptors,
+		fieldExtractors: hctx.fieldExtractors,
 	}
 }
 
@@ -253,13 +264,14 @@ func WithLogger(ctx Context, logger Logger) Context {
 	}
 
 	return &hyperionContext{
-		Context:      hctx.Context,
-		logger:       logger, // Replace Logger
-		db:           hctx.db,
-		tracer:       hctx.tracer,
-		meter:        hctx.meter,
-		span:         hctx.span,
-		interceptors: hctx.interceptors,
+		Context:         hctx.Context,
+		logger:          logger, // Replace Logger
+		db:              hctx.db,
+		tracer:          hctx.tracer,
+		meter:           hctx.meter,
+		span:            hctx.span,
+		interceptors:    hctx.interceptors,
+		fieldExtractors: hctx.fieldExtractors,
 	}
 }
 
@@ -278,13 +290,14 @@ func WithTracer(ctx Context, tracer Tracer) Context {
 	}
 
 	return &hyperionContext{
-		Context:      hctx.Context,
-		logger:       hctx.logger,
-		db:           hctx.db,
-		tracer:       tracer, // Replace Tracer
-		meter:        hctx.meter,
-		span:         hctx.span,
-		interceptors: hctx.interceptors,
+		Context:         hctx.Context,
+		logger:          hctx.logger,
+		db:              hctx.db,
+		tracer:          tracer, // Replace Tracer
+		meter:           hctx.meter,
+		span:            hctx.span,
+		interceptors:    hctx.interceptors,
+		fieldExtractors: hctx.fieldExtractors,
 	}
 }
 
@@ -310,13 +323,14 @@ func WithContext(ctx Context, stdCtx context.Context) Context {
 	}
 
 	return &hyperionContext{
-		Context:      stdCtx, // Replace underlying context
-		logger:       hctx.logger,
-		db:           hctx.db,
-		tracer:       hctx.tracer,
-		meter:        hctx.meter,
-		span:         hctx.span,
-		interceptors: hctx.interceptors,
+		Context:         stdCtx, // Replace underlying context
+		logger:          hctx.logger,
+		db:              hctx.db,
+		tracer:          hctx.tracer,
+		meter:           hctx.meter,
+		span:            hctx.span,
+		interceptors:    hctx.interceptors,
+		fieldExtractors: hctx.fieldExtractors,
 	}
 }
 
@@ -335,13 +349,14 @@ func WithSpan(ctx Context, span Span) Context {
 	}
 
 	return &hyperionContext{
-		Context:      hctx.Context,
-		logger:       hctx.logger,
-		db:           hctx.db,
-		tracer:       hctx.tracer,
-		meter:        hctx.meter,
-		span:         span, // Set new span
-		interceptors: hctx.interceptors,
+		Context:         hctx.Context,
+		logger:          hctx.logger,
+		db:              hctx.db,
+		tracer:          hctx.tracer,
+		meter:           hctx.meter,
+		span:            span, // Set new span
+		interceptors:    hctx.interceptors,
+		fieldExtractors: hctx.fieldExtractors,
 	}
 }
 
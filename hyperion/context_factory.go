@@ -32,6 +32,8 @@ type contextFactory struct {
 	db       Database
 	meter    Meter
 	registry InterceptorRegistry // Registry to dynamically fetch interceptors
+
+	fieldExtractors []ContextFieldExtractor // Applied on every Context.Logger() call
 }
 
 // NewContextFactory creates a new ContextFactory with the given dependencies.
@@ -74,6 +76,8 @@ func (f *contextFactory) New(ctx context.Context) Context {
 		db:           f.db.Executor(),
 		meter:        f.meter,
 		interceptors: interceptors, // Inject interceptors from registry
+
+		fieldExtractors: f.fieldExtractors,
 	}
 }
 
@@ -93,3 +97,19 @@ func WithRegistry(registry InterceptorRegistry) FactoryOption {
 		f.registry = registry
 	}
 }
+
+// WithContextFieldExtractors adds extractors whose fields are attached to
+// the logger returned by Context.Logger() for every context the factory creates.
+//
+// Extractors run in the order given, after any previously added extractors.
+//
+// Example with fx:
+//
+//	fx.Provide(func(..., extractors []ContextFieldExtractor) ContextFactory {
+//	    return NewContextFactory(..., WithContextFieldExtractors(extractors...))
+//	})
+func WithContextFieldExtractors(extractors ...ContextFieldExtractor) FactoryOption {
+	return func(f *contextFactory) {
+		f.fieldExtractors = append(f.fieldExtractors, extractors...)
+	}
+}
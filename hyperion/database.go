@@ -1,6 +1,9 @@
 package hyperion
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 // Database provides database connectivity and transaction management.
 type Database interface {
@@ -18,11 +21,24 @@ type Database interface {
 // It abstracts GORM, sqlx, and other database libraries.
 type Executor interface {
 	// Exec executes a query without returning rows.
-	Exec(ctx context.Context, sql string, args ...any) error
+	// The Result reports the rows affected, e.g. to detect a lost update
+	// in optimistic locking.
+	Exec(ctx context.Context, sql string, args ...any) (Result, error)
 
 	// Query executes a query that returns rows into dest.
+	// dest is a pointer to a slice for all rows, or to a struct or scalar
+	// for the first row, left unchanged when there are no rows.
 	Query(ctx context.Context, dest any, sql string, args ...any) error
 
+	// QueryRow scans the first row of a query into dest, a pointer to a
+	// struct or scalar. Returns an error wrapping ErrNotFound if the query
+	// returns no rows.
+	QueryRow(ctx context.Context, dest any, sql string, args ...any) error
+
+	// QueryIter executes a query and returns a cursor over its rows, for
+	// result sets too large to load at once. The caller must Close it.
+	QueryIter(ctx context.Context, sql string, args ...any) (Rows, error)
+
 	// Begin starts a new transaction and returns a transaction executor.
 	Begin(ctx context.Context) (Executor, error)
 
@@ -40,6 +56,51 @@ type Executor interface {
 	Unwrap() any
 }
 
+// Result summarizes an executed statement. It has the methods of
+// database/sql's Result, which satisfies it.
+type Result interface {
+	// LastInsertId returns the ID generated by the database for an INSERT.
+	// Not every database supports it; PostgreSQL needs RETURNING instead.
+	LastInsertId() (int64, error)
+
+	// RowsAffected returns the number of rows changed by an UPDATE, INSERT
+	// or DELETE. MySQL counts rows changed rather than rows matched.
+	RowsAffected() (int64, error)
+}
+
+// Rows is a cursor over the rows of a query, returned by Executor.QueryIter.
+//
+// Example:
+//
+//	rows, err := ctx.DB().QueryIter(ctx, "SELECT id, email FROM users")
+//	if err != nil {
+//	    return err
+//	}
+//	defer rows.Close()
+//	for rows.Next() {
+//	    var user User
+//	    if err := rows.Scan(&user); err != nil {
+//	        return err
+//	    }
+//	    process(user)
+//	}
+//	return rows.Err()
+type Rows interface {
+	// Next advances to the next row, returning false at the end or on error.
+	Next() bool
+
+	// Scan copies the current row into dest, a pointer to a struct or
+	// scalar, mapping columns as Query does.
+	Scan(dest any) error
+
+	// Err returns the error, if any, that ended the iteration.
+	Err() error
+
+	// Close releases the cursor and its connection. It is safe to call
+	// more than once.
+	Close() error
+}
+
 // UnitOfWork manages transaction boundaries.
 // It provides a declarative way to handle database transactions.
 type UnitOfWork interface {
@@ -49,6 +110,9 @@ type UnitOfWork interface {
 	//
 	// The Context passed to fn will have its DB() method return
 	// the transaction executor instead of the default executor.
+	//
+	// If ctx already carries a transaction, fn joins it
+	// (PropagationRequired).
 	WithTransaction(ctx Context, fn func(txCtx Context) error) error
 
 	// WithTransactionOptions executes fn within a database transaction with options.
@@ -58,12 +122,55 @@ type UnitOfWork interface {
 // TransactionOptions configures transaction behavior.
 type TransactionOptions struct {
 	// Isolation sets the transaction isolation level.
+	// It only applies when a new transaction is started.
 	Isolation IsolationLevel
 
 	// ReadOnly indicates the transaction should be read-only.
+	// It only applies when a new transaction is started.
 	ReadOnly bool
+
+	// Propagation controls how fn relates to a transaction already carried
+	// by the context. The zero value is PropagationRequired.
+	Propagation Propagation
 }
 
+// Propagation decides whether a unit of work joins, nests in, or stays out
+// of the transaction carried by its context.
+type Propagation int
+
+const (
+	// PropagationRequired joins the current transaction, or starts one if
+	// there is none. When joined, an error from fn is returned without
+	// rolling back; the outermost unit of work decides.
+	PropagationRequired Propagation = iota
+
+	// PropagationRequiresNew always starts an independent transaction,
+	// which commits or rolls back regardless of the current one. It holds
+	// a second connection while the current transaction stays open.
+	PropagationRequiresNew
+
+	// PropagationNested runs fn under a savepoint of the current
+	// transaction, so an error or panic rolls back only fn's changes.
+	// Starts a transaction if there is none.
+	PropagationNested
+
+	// PropagationMandatory joins the current transaction and returns
+	// ErrNoTransaction if there is none.
+	PropagationMandatory
+
+	// PropagationNever runs fn without a transaction and returns
+	// ErrTransactionExists if the context carries one.
+	PropagationNever
+)
+
+var (
+	// ErrNoTransaction is returned by PropagationMandatory outside a transaction.
+	ErrNoTransaction = errors.New("no transaction in context")
+
+	// ErrTransactionExists is returned by PropagationNever inside a transaction.
+	ErrTransactionExists = errors.New("transaction already in context")
+)
+
 // IsolationLevel represents the transaction isolation level.
 type IsolationLevel int
 
@@ -0,0 +1,135 @@
+package hyperion
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// TransactionHook is a callback registered with OnCommit or OnRollback.
+// ctx is the Context the transaction or savepoint was started from, so its
+// DB() is no longer the finished transaction.
+type TransactionHook func(ctx Context) error
+
+// OnCommit registers fn to run after the transaction carried by ctx
+// commits, e.g. to publish events or evict caches only for writes that
+// happened. Inside a savepoint (PropagationNested), fn waits for the
+// outermost commit and is dropped if the savepoint rolls back.
+//
+// Errors and panics from fn are logged; they do not affect the
+// transaction, which has already committed. Outside a transaction fn runs
+// immediately.
+//
+// Example:
+//
+//	err := uow.WithTransaction(ctx, func(txCtx hyperion.Context) error {
+//	    if err := orderRepo.Create(txCtx, order); err != nil {
+//	        return err
+//	    }
+//	    hyperion.OnCommit(txCtx, func(ctx hyperion.Context) error {
+//	        return events.Publish(ctx, OrderCreated{ID: order.ID})
+//	    })
+//	    return nil
+//	})
+func OnCommit(ctx Context, fn TransactionHook) {
+	hooks, ok := ctx.Value(transactionHooksKey{}).(*TransactionHooks)
+	if !ok {
+		runTransactionHooks(ctx, "commit", []TransactionHook{fn})
+		return
+	}
+	hooks.mu.Lock()
+	hooks.commit = append(hooks.commit, fn)
+	hooks.mu.Unlock()
+}
+
+// OnRollback registers fn to run after the transaction carried by ctx
+// rolls back, or after the savepoint it was registered in does. Errors and
+// panics from fn are logged. Outside a transaction fn is dropped.
+func OnRollback(ctx Context, fn TransactionHook) {
+	hooks, ok := ctx.Value(transactionHooksKey{}).(*TransactionHooks)
+	if !ok {
+		return
+	}
+	hooks.mu.Lock()
+	hooks.rollback = append(hooks.rollback, fn)
+	hooks.mu.Unlock()
+}
+
+// transactionHooksKey is the context key of the current TransactionHooks.
+type transactionHooksKey struct{}
+
+// TransactionHooks holds the OnCommit and OnRollback callbacks of one
+// transaction or savepoint. It is used by UnitOfWork implementations:
+// create one with WithTransactionHooks or WithSavepointHooks when fn
+// starts, and call Commit or Rollback when it ends.
+type TransactionHooks struct {
+	mu       sync.Mutex
+	parent   *TransactionHooks // Savepoint only
+	commit   []TransactionHook
+	rollback []TransactionHook
+}
+
+// WithTransactionHooks returns a copy of ctx that collects hooks for a new,
+// independent transaction, and the hooks themselves.
+func WithTransactionHooks(ctx Context) (Context, *TransactionHooks) {
+	hooks := &TransactionHooks{}
+	return WithContext(ctx, context.WithValue(ctx, transactionHooksKey{}, hooks)), hooks
+}
+
+// WithSavepointHooks returns a copy of ctx that collects hooks for a
+// savepoint of the transaction in ctx, and the hooks themselves. When
+// committed, they move to the enclosing transaction.
+func WithSavepointHooks(ctx Context) (Context, *TransactionHooks) {
+	parent, _ := ctx.Value(transactionHooksKey{}).(*TransactionHooks)
+	hooks := &TransactionHooks{parent: parent}
+	return WithContext(ctx, context.WithValue(ctx, transactionHooksKey{}, hooks)), hooks
+}
+
+// Commit runs the commit hooks in registration order, or hands them and
+// the rollback hooks to the enclosing transaction for a savepoint.
+func (h *TransactionHooks) Commit(ctx Context) {
+	commit, rollback := h.take()
+	if h.parent != nil {
+		h.parent.mu.Lock()
+		h.parent.commit = append(h.parent.commit, commit...)
+		h.parent.rollback = append(h.parent.rollback, rollback...)
+		h.parent.mu.Unlock()
+		return
+	}
+	runTransactionHooks(ctx, "commit", commit)
+}
+
+// Rollback drops the commit hooks and runs the rollback hooks in
+// registration order.
+func (h *TransactionHooks) Rollback(ctx Context) {
+	_, rollback := h.take()
+	runTransactionHooks(ctx, "rollback", rollback)
+}
+
+// take removes and returns the registered hooks, so each runs at most once.
+func (h *TransactionHooks) take() (commit, rollback []TransactionHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	commit, rollback = h.commit, h.rollback
+	h.commit, h.rollback = nil, nil
+	return commit, rollback
+}
+
+// runTransactionHooks runs hooks in order, logging their errors and panics.
+func runTransactionHooks(ctx Context, event string, hooks []TransactionHook) {
+	for _, fn := range hooks {
+		if err := runTransactionHook(ctx, fn); err != nil {
+			ctx.Logger().Error("transaction hook failed", "event", event, "error", err)
+		}
+	}
+}
+
+// runTransactionHook runs fn, turning a panic into an error.
+func runTransactionHook(ctx Context, fn TransactionHook) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("transaction hook panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,120 @@
+package hyperion
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newHooksContext(logger Logger) Context {
+	return New(context.Background(), logger, &noopExecutor{}, NewNoOpTracer(), &noOpMeter{})
+}
+
+// recordHook returns a hook appending name to calls.
+func recordHook(calls *[]string, name string) TransactionHook {
+	return func(Context) error {
+		*calls = append(*calls, name)
+		return nil
+	}
+}
+
+func TestTransactionHooks_Commit(t *testing.T) {
+	ctx := newHooksContext(NewNoOpLogger())
+	txCtx, hooks := WithTransactionHooks(ctx)
+
+	var calls []string
+	OnCommit(txCtx, recordHook(&calls, "commit-1"))
+	OnRollback(txCtx, recordHook(&calls, "rollback"))
+	OnCommit(txCtx, recordHook(&calls, "commit-2"))
+	if len(calls) != 0 {
+		t.Fatalf("hooks ran before the transaction ended: %v", calls)
+	}
+
+	hooks.Commit(ctx)
+	hooks.Commit(ctx)
+	if want := []string{"commit-1", "commit-2"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestTransactionHooks_Rollback(t *testing.T) {
+	ctx := newHooksContext(NewNoOpLogger())
+	txCtx, hooks := WithTransactionHooks(ctx)
+
+	var calls []string
+	OnCommit(txCtx, recordHook(&calls, "commit"))
+	OnRollback(txCtx, recordHook(&calls, "rollback"))
+
+	hooks.Rollback(ctx)
+	if want := []string{"rollback"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestTransactionHooks_Savepoint(t *testing.T) {
+	ctx := newHooksContext(NewNoOpLogger())
+	txCtx, hooks := WithTransactionHooks(ctx)
+
+	var calls []string
+	OnCommit(txCtx, recordHook(&calls, "outer"))
+
+	// A released savepoint hands its hooks to the transaction
+	spCtx, released := WithSavepointHooks(txCtx)
+	OnCommit(spCtx, recordHook(&calls, "released"))
+	OnRollback(spCtx, recordHook(&calls, "released-rollback"))
+	released.Commit(txCtx)
+	if len(calls) != 0 {
+		t.Fatalf("savepoint hooks ran before the transaction committed: %v", calls)
+	}
+
+	// A rolled back savepoint runs its rollback hooks at once
+	spCtx, rolledBack := WithSavepointHooks(txCtx)
+	OnCommit(spCtx, recordHook(&calls, "rolled-back"))
+	OnRollback(spCtx, recordHook(&calls, "rolled-back-rollback"))
+	rolledBack.Rollback(txCtx)
+	if want := []string{"rolled-back-rollback"}; !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls after savepoint rollback = %v, want %v", calls, want)
+	}
+
+	calls = nil
+	hooks.Commit(ctx)
+	if want := []string{"outer", "released"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls after commit = %v, want %v", calls, want)
+	}
+}
+
+func TestTransactionHooks_OutsideTransaction(t *testing.T) {
+	ctx := newHooksContext(NewNoOpLogger())
+
+	var calls []string
+	OnCommit(ctx, recordHook(&calls, "commit"))
+	OnRollback(ctx, recordHook(&calls, "rollback"))
+	if want := []string{"commit"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestTransactionHooks_ErrorsAndPanics(t *testing.T) {
+	logger := &captureLogger{}
+	ctx := newHooksContext(logger)
+	txCtx, hooks := WithTransactionHooks(ctx)
+
+	var calls []string
+	OnCommit(txCtx, func(Context) error { return errors.New("publish failed") })
+	OnCommit(txCtx, func(Context) error { panic("boom") })
+	OnCommit(txCtx, recordHook(&calls, "after"))
+
+	hooks.Commit(ctx)
+	if want := []string{"after"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v: a failing hook stopped the others", calls, want)
+	}
+	if len(logger.errorCalls) != 2 {
+		t.Fatalf("logged %d errors, want 2", len(logger.errorCalls))
+	}
+	for _, call := range logger.errorCalls {
+		if call.msg != "transaction hook failed" {
+			t.Errorf("logged %q, want %q", call.msg, "transaction hook failed")
+		}
+	}
+}
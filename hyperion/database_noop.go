@@ -33,14 +33,22 @@ func (db *noopDatabase) Close() error {
 // noopExecutor is a no-op implementation of Executor interface.
 type noopExecutor struct{}
 
-func (e *noopExecutor) Exec(ctx context.Context, sql string, args ...any) error {
-	return ErrNoOpDatabase
+func (e *noopExecutor) Exec(ctx context.Context, sql string, args ...any) (Result, error) {
+	return nil, ErrNoOpDatabase
 }
 
 func (e *noopExecutor) Query(ctx context.Context, dest any, sql string, args ...any) error {
 	return ErrNoOpDatabase
 }
 
+func (e *noopExecutor) QueryRow(ctx context.Context, dest any, sql string, args ...any) error {
+	return ErrNoOpDatabase
+}
+
+func (e *noopExecutor) QueryIter(ctx context.Context, sql string, args ...any) (Rows, error) {
+	return nil, ErrNoOpDatabase
+}
+
 func (e *noopExecutor) Begin(ctx context.Context) (Executor, error) {
 	return e, nil
 }
@@ -0,0 +1,174 @@
+package hyperion
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxErrorDepth bounds how deep DescribeError follows wrapped errors,
+// protecting against pathological or cyclic Unwrap implementations.
+const maxErrorDepth = 32
+
+// maxStackDepth is the maximum number of frames captured by CaptureStack.
+const maxStackDepth = 64
+
+// StackTracer is implemented by errors that carry the stack trace of the
+// point where they were created. Adapters prefer this stack over one
+// captured at the log site.
+type StackTracer interface {
+	// StackTrace returns the program counters of the captured stack,
+	// innermost frame first, as returned by runtime.Callers.
+	StackTrace() []uintptr
+}
+
+// ErrorDetail is the structured form of an error used by adapters when
+// logging errors and recording them on spans.
+//
+// Causes mirrors the wrapped error tree: one entry for an error with an
+// Unwrap() error method, one entry per branch for errors.Join and other
+// errors with an Unwrap() []error method.
+type ErrorDetail struct {
+	Message string        `json:"message"`
+	Type    string        `json:"type"`
+	Stack   string        `json:"stack,omitempty"`
+	Causes  []ErrorDetail `json:"causes,omitempty"`
+}
+
+// DescribeError builds the ErrorDetail tree for err.
+// Stack is set on the root only, from the first StackTracer in the chain.
+// It returns a zero ErrorDetail if err is nil.
+func DescribeError(err error) ErrorDetail {
+	if err == nil {
+		return ErrorDetail{}
+	}
+
+	detail := describeError(err, 0)
+	if pcs := ErrorStack(err); pcs != nil {
+		detail.Stack = FormatStack(pcs)
+	}
+	return detail
+}
+
+// describeError builds the detail for err and its causes, skipping the
+// wrappers added by WithStack.
+func describeError(err error, depth int) ErrorDetail {
+	for {
+		se, ok := err.(*stackError)
+		if !ok {
+			break
+		}
+		err = se.err
+	}
+
+	detail := ErrorDetail{
+		Message: err.Error(),
+		Type:    ErrorTypeName(err),
+	}
+	if depth >= maxErrorDepth {
+		return detail
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := x.Unwrap(); cause != nil {
+			detail.Causes = []ErrorDetail{describeError(cause, depth+1)}
+		}
+	case interface{ Unwrap() []error }:
+		for _, cause := range x.Unwrap() {
+			if cause != nil {
+				detail.Causes = append(detail.Causes, describeError(cause, depth+1))
+			}
+		}
+	}
+	return detail
+}
+
+// ErrorTypeName returns the Go type name of err, e.g. "*fs.PathError".
+// Stack wrappers added by WithStack are skipped.
+func ErrorTypeName(err error) string {
+	for {
+		se, ok := err.(*stackError)
+		if !ok {
+			break
+		}
+		err = se.err
+	}
+	return fmt.Sprintf("%T", err)
+}
+
+// ErrorStack returns the stack carried by err or any error it wraps,
+// or nil if none of them implements StackTracer.
+func ErrorStack(err error) []uintptr {
+	var st StackTracer
+	if errors.As(err, &st) {
+		return st.StackTrace()
+	}
+	return nil
+}
+
+// WithStack annotates err with the stack trace at the point WithStack
+// was called. The returned error has the same message and unwraps to err.
+// If err is nil or already carries a stack, it is returned unchanged.
+//
+// Example:
+//
+//	if err := row.Scan(&user); err != nil {
+//	    return hyperion.WithStack(err)
+//	}
+func WithStack(err error) error {
+	if err == nil || ErrorStack(err) != nil {
+		return err
+	}
+	return &stackError{err: err, stack: CaptureStack(1)}
+}
+
+// CaptureStack returns the current stack, skipping skip frames above the
+// caller of CaptureStack (0 identifies the caller itself).
+func CaptureStack(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs) // skip [Callers, CaptureStack]
+	return pcs[:n]
+}
+
+// FormatStack renders a stack in the same layout as Go panics:
+// one "function\n\tfile:line" entry per frame.
+func FormatStack(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// stackError is the error returned by WithStack.
+type stackError struct {
+	err   error
+	stack []uintptr
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// StackTrace implements StackTracer.
+func (e *stackError) StackTrace() []uintptr {
+	return e.stack
+}
@@ -0,0 +1,99 @@
+package hyperion_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/mapoio/hyperion"
+)
+
+func TestDescribeError(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		if got := hyperion.DescribeError(nil); got.Message != "" || got.Causes != nil {
+			t.Errorf("DescribeError(nil) = %+v, want zero value", got)
+		}
+	})
+
+	t.Run("wrapped chain", func(t *testing.T) {
+		pathErr := &fs.PathError{Op: "open", Path: "/missing", Err: fs.ErrNotExist}
+		err := fmt.Errorf("load config: %w", pathErr)
+
+		got := hyperion.DescribeError(err)
+		if got.Type != "*fmt.wrapError" || got.Message != err.Error() {
+			t.Errorf("root = %s %q", got.Type, got.Message)
+		}
+		if len(got.Causes) != 1 || got.Causes[0].Type != "*fs.PathError" {
+			t.Fatalf("causes = %+v, want one *fs.PathError", got.Causes)
+		}
+		if leaf := got.Causes[0].Causes; len(leaf) != 1 || leaf[0].Message != fs.ErrNotExist.Error() {
+			t.Errorf("leaf = %+v, want fs.ErrNotExist", leaf)
+		}
+		if got.Stack != "" {
+			t.Errorf("Stack = %q, want empty for error without stack", got.Stack)
+		}
+	})
+
+	t.Run("joined errors", func(t *testing.T) {
+		err := errors.Join(errors.New("first"), nil, errors.New("second"))
+
+		got := hyperion.DescribeError(err)
+		if len(got.Causes) != 2 {
+			t.Fatalf("causes = %+v, want 2", got.Causes)
+		}
+		if got.Causes[0].Message != "first" || got.Causes[1].Message != "second" {
+			t.Errorf("causes = %+v", got.Causes)
+		}
+	})
+
+	t.Run("stack from error", func(t *testing.T) {
+		err := fmt.Errorf("query: %w", hyperion.WithStack(errors.New("boom")))
+
+		got := hyperion.DescribeError(err)
+		if !strings.Contains(got.Stack, "TestDescribeError") {
+			t.Errorf("Stack should contain the WithStack call site, got:\n%s", got.Stack)
+		}
+		// The stack wrapper is transparent in the chain
+		if len(got.Causes) != 1 || got.Causes[0].Type != "*errors.errorString" {
+			t.Errorf("causes = %+v, want the wrapped *errors.errorString", got.Causes)
+		}
+	})
+}
+
+func TestWithStack(t *testing.T) {
+	if hyperion.WithStack(nil) != nil {
+		t.Error("WithStack(nil) should return nil")
+	}
+
+	base := errors.New("boom")
+	err := hyperion.WithStack(base)
+	if err.Error() != "boom" || !errors.Is(err, base) {
+		t.Errorf("WithStack should preserve message and unwrap to the original error")
+	}
+	if hyperion.ErrorTypeName(err) != "*errors.errorString" {
+		t.Errorf("ErrorTypeName = %q, want *errors.errorString", hyperion.ErrorTypeName(err))
+	}
+	if hyperion.WithStack(err) != err {
+		t.Error("WithStack should not re-wrap an error that already carries a stack")
+	}
+	if hyperion.ErrorStack(base) != nil {
+		t.Error("ErrorStack should return nil for an error without stack")
+	}
+}
+
+func TestFormatStack(t *testing.T) {
+	if hyperion.FormatStack(nil) != "" {
+		t.Error("FormatStack(nil) should be empty")
+	}
+
+	stack := hyperion.FormatStack(hyperion.CaptureStack(0))
+	first, _, _ := strings.Cut(stack, "\n")
+	if !strings.HasSuffix(first, "TestFormatStack") {
+		t.Errorf("first frame = %q, want the caller of CaptureStack", first)
+	}
+	if !strings.Contains(stack, "\n\t") || !strings.Contains(stack, "error_detail_test.go:") {
+		t.Errorf("unexpected stack layout:\n%s", stack)
+	}
+}
@@ -0,0 +1,10 @@
+package hyperion
+
+import "errors"
+
+// ErrNotFound reports that a requested entity does not exist.
+//
+// Return it, or an error wrapping it, from data access code so callers and
+// framework helpers can recognize the case with errors.Is. TypedCache uses
+// it to cache "not found" results of loaders.
+var ErrNotFound = errors.New("not found")
@@ -0,0 +1,254 @@
+package hyperiontest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+// CacheHarness describes how RunCacheSuite creates caches.
+type CacheHarness struct {
+	// New creates an empty cache. Required.
+	New func(t *testing.T) hyperion.Cache
+
+	// Advance moves the cache's clock forward by d, so TTL expiry can be
+	// tested without sleeping. If nil, the suite sleeps for d.
+	Advance func(d time.Duration)
+}
+
+// RunCacheSuite runs the hyperion.Cache conformance tests against h.
+func RunCacheSuite(t *testing.T, h CacheHarness) {
+	t.Helper()
+
+	if h.New == nil {
+		t.Fatal("hyperiontest: CacheHarness.New is required")
+	}
+	if h.Advance == nil {
+		h.Advance = time.Sleep
+	}
+
+	t.Run("GetMissing", h.testGetMissing)
+	t.Run("SetGet", h.testSetGet)
+	t.Run("Overwrite", h.testOverwrite)
+	t.Run("Delete", h.testDelete)
+	t.Run("Exists", h.testExists)
+	t.Run("TTL", h.testTTL)
+	t.Run("MGet", h.testMGet)
+	t.Run("MSet", h.testMSet)
+	t.Run("Clear", h.testClear)
+	t.Run("ValueIsolation", h.testValueIsolation)
+	t.Run("Concurrent", h.testConcurrent)
+}
+
+func (h CacheHarness) newCache(t *testing.T) hyperion.Cache {
+	t.Helper()
+	cache := h.New(t)
+	if cache == nil {
+		t.Fatal("CacheHarness.New returned nil")
+	}
+	return cache
+}
+
+// mustSet stores value under key and fails the test on error.
+func mustSet(t *testing.T, cache hyperion.Cache, key, value string, ttl time.Duration) {
+	t.Helper()
+	if err := cache.Set(context.Background(), key, []byte(value), ttl); err != nil {
+		t.Fatalf("Set(%q) failed: %v", key, err)
+	}
+}
+
+// expectValue fails the test unless key holds value.
+func expectValue(t *testing.T, cache hyperion.Cache, key, value string) {
+	t.Helper()
+	got, err := cache.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%q) failed: %v", key, err)
+	}
+	if string(got) != value {
+		t.Errorf("Get(%q) = %q, want %q", key, got, value)
+	}
+}
+
+// expectMissing fails the test unless Get reports key as a cache miss.
+func expectMissing(t *testing.T, cache hyperion.Cache, key string) {
+	t.Helper()
+	if got, err := cache.Get(context.Background(), key); !errors.Is(err, hyperion.ErrCacheMiss) {
+		t.Errorf("Get(%q) = %q, %v, want ErrCacheMiss", key, got, err)
+	}
+}
+
+func (h CacheHarness) testGetMissing(t *testing.T) {
+	cache := h.newCache(t)
+	expectMissing(t, cache, "missing")
+}
+
+func (h CacheHarness) testSetGet(t *testing.T) {
+	cache := h.newCache(t)
+	mustSet(t, cache, "key", "value", 0)
+	expectValue(t, cache, "key", "value")
+
+	// Empty values are values, not misses
+	mustSet(t, cache, "empty", "", 0)
+	expectValue(t, cache, "empty", "")
+}
+
+func (h CacheHarness) testOverwrite(t *testing.T) {
+	cache := h.newCache(t)
+	mustSet(t, cache, "key", "first", 0)
+	mustSet(t, cache, "key", "second", 0)
+	expectValue(t, cache, "key", "second")
+}
+
+func (h CacheHarness) testDelete(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newCache(t)
+	mustSet(t, cache, "key", "value", 0)
+
+	if err := cache.Delete(ctx, "key"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	expectMissing(t, cache, "key")
+
+	if err := cache.Delete(ctx, "missing"); err != nil {
+		t.Errorf("Delete of a missing key returned %v, want nil", err)
+	}
+}
+
+func (h CacheHarness) testExists(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newCache(t)
+	mustSet(t, cache, "key", "value", 0)
+
+	for key, want := range map[string]bool{"key": true, "missing": false} {
+		got, err := cache.Exists(ctx, key)
+		if err != nil {
+			t.Fatalf("Exists(%q) failed: %v", key, err)
+		}
+		if got != want {
+			t.Errorf("Exists(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func (h CacheHarness) testTTL(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newCache(t)
+	mustSet(t, cache, "short", "value", 50*time.Millisecond)
+	mustSet(t, cache, "forever", "value", 0)
+	expectValue(t, cache, "short", "value")
+
+	h.Advance(100 * time.Millisecond)
+
+	expectMissing(t, cache, "short")
+	if ok, err := cache.Exists(ctx, "short"); err != nil || ok {
+		t.Errorf("Exists(short) after expiry = %v, %v, want false, nil", ok, err)
+	}
+	expectValue(t, cache, "forever", "value")
+}
+
+func (h CacheHarness) testMGet(t *testing.T) {
+	cache := h.newCache(t)
+	mustSet(t, cache, "a", "1", 0)
+	mustSet(t, cache, "b", "2", 0)
+
+	got, err := cache.MGet(context.Background(), "a", "missing", "b")
+	if err != nil {
+		t.Fatalf("MGet failed: %v", err)
+	}
+	if len(got) != 2 || string(got["a"]) != "1" || string(got["b"]) != "2" {
+		t.Errorf("MGet = %q, want a=1 and b=2 only", got)
+	}
+
+	if got, err := cache.MGet(context.Background()); err != nil || len(got) != 0 {
+		t.Errorf("MGet() = %q, %v, want an empty result", got, err)
+	}
+}
+
+func (h CacheHarness) testMSet(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newCache(t)
+	items := map[string][]byte{"a": []byte("1"), "b": []byte("2")}
+	if err := cache.MSet(ctx, items, 50*time.Millisecond); err != nil {
+		t.Fatalf("MSet failed: %v", err)
+	}
+	expectValue(t, cache, "a", "1")
+	expectValue(t, cache, "b", "2")
+
+	h.Advance(100 * time.Millisecond)
+	expectMissing(t, cache, "a")
+	expectMissing(t, cache, "b")
+
+	if err := cache.MSet(ctx, nil, 0); err != nil {
+		t.Errorf("MSet(nil) failed: %v", err)
+	}
+}
+
+func (h CacheHarness) testClear(t *testing.T) {
+	cache := h.newCache(t)
+	mustSet(t, cache, "a", "1", 0)
+	mustSet(t, cache, "b", "2", 0)
+
+	if err := cache.Clear(context.Background()); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	expectMissing(t, cache, "a")
+	expectMissing(t, cache, "b")
+
+	mustSet(t, cache, "a", "3", 0)
+	expectValue(t, cache, "a", "3")
+}
+
+func (h CacheHarness) testValueIsolation(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newCache(t)
+
+	value := []byte("value")
+	if err := cache.Set(ctx, "key", value, 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	copy(value, "XXXXX")
+	expectValue(t, cache, "key", "value")
+
+	got, err := cache.Get(ctx, "key")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	copy(got, "XXXXX")
+	expectValue(t, cache, "key", "value")
+}
+
+func (h CacheHarness) testConcurrent(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newCache(t)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				key := fmt.Sprintf("key-%d-%d", g, i%5)
+				value := []byte(key)
+				if err := cache.Set(ctx, key, value, 0); err != nil {
+					t.Errorf("Set(%q) failed: %v", key, err)
+					return
+				}
+				if got, err := cache.Get(ctx, key); err == nil && !bytes.Equal(got, value) {
+					t.Errorf("Get(%q) = %q, want %q", key, got, value)
+					return
+				}
+				if _, err := cache.MGet(ctx, key, "missing"); err != nil {
+					t.Errorf("MGet failed: %v", err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
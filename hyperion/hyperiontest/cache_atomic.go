@@ -0,0 +1,257 @@
+package hyperiontest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+// RunAtomicCacheSuite runs the hyperion.AtomicCache conformance tests
+// against h. The caches created by h.New must implement AtomicCache.
+func RunAtomicCacheSuite(t *testing.T, h CacheHarness) {
+	t.Helper()
+
+	if h.New == nil {
+		t.Fatal("hyperiontest: CacheHarness.New is required")
+	}
+	if h.Advance == nil {
+		h.Advance = time.Sleep
+	}
+
+	t.Run("IncrBy", h.testIncrBy)
+	t.Run("IncrTTL", h.testIncrTTL)
+	t.Run("IncrNotInteger", h.testIncrNotInteger)
+	t.Run("IncrOverflow", h.testIncrOverflow)
+	t.Run("SetNX", h.testSetNX)
+	t.Run("CompareAndSwap", h.testCompareAndSwap)
+	t.Run("ExpireTTL", h.testExpireTTL)
+	t.Run("GetSet", h.testGetSet)
+	t.Run("ConcurrentIncr", h.testConcurrentIncr)
+}
+
+func (h CacheHarness) newAtomicCache(t *testing.T) hyperion.AtomicCache {
+	t.Helper()
+	cache, ok := h.newCache(t).(hyperion.AtomicCache)
+	if !ok {
+		t.Fatal("CacheHarness.New returned a cache that does not implement hyperion.AtomicCache")
+	}
+	return cache
+}
+
+// expectCount fails the test unless incr returned want without error.
+func expectCount(t *testing.T, op string, got int64, err error, want int64) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s failed: %v", op, err)
+	}
+	if got != want {
+		t.Errorf("%s = %d, want %d", op, got, want)
+	}
+}
+
+func (h CacheHarness) testIncrBy(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newAtomicCache(t)
+
+	n, err := cache.Incr(ctx, "counter", 0)
+	expectCount(t, "Incr", n, err, 1)
+	n, err = cache.IncrBy(ctx, "counter", 10, 0)
+	expectCount(t, "IncrBy(10)", n, err, 11)
+	n, err = cache.IncrBy(ctx, "counter", -20, 0)
+	expectCount(t, "IncrBy(-20)", n, err, -9)
+	expectValue(t, cache, "counter", "-9")
+
+	// Counters continue from values written with Set
+	mustSet(t, cache, "preset", "41", 0)
+	n, err = cache.Incr(ctx, "preset", 0)
+	expectCount(t, "Incr(preset)", n, err, 42)
+}
+
+func (h CacheHarness) testIncrTTL(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newAtomicCache(t)
+
+	_, _ = cache.Incr(ctx, "window", 100*time.Millisecond)
+	h.Advance(60 * time.Millisecond)
+
+	// Later increments do not extend the window
+	n, err := cache.Incr(ctx, "window", 100*time.Millisecond)
+	expectCount(t, "Incr", n, err, 2)
+	h.Advance(60 * time.Millisecond)
+	expectMissing(t, cache, "window")
+
+	n, err = cache.Incr(ctx, "window", 100*time.Millisecond)
+	expectCount(t, "Incr after expiry", n, err, 1)
+}
+
+func (h CacheHarness) testIncrNotInteger(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newAtomicCache(t)
+	mustSet(t, cache, "text", "abc", 0)
+
+	if _, err := cache.Incr(ctx, "text", 0); !errors.Is(err, hyperion.ErrNotInteger) {
+		t.Errorf("Incr of a non-integer = %v, want ErrNotInteger", err)
+	}
+	expectValue(t, cache, "text", "abc")
+}
+
+func (h CacheHarness) testIncrOverflow(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newAtomicCache(t)
+	mustSet(t, cache, "max", "9223372036854775807", 0)
+	mustSet(t, cache, "min", "-9223372036854775808", 0)
+
+	if _, err := cache.Incr(ctx, "max", 0); !errors.Is(err, hyperion.ErrNotInteger) {
+		t.Errorf("Incr past the int64 maximum = %v, want ErrNotInteger", err)
+	}
+	if _, err := cache.IncrBy(ctx, "min", -1, 0); !errors.Is(err, hyperion.ErrNotInteger) {
+		t.Errorf("IncrBy past the int64 minimum = %v, want ErrNotInteger", err)
+	}
+	expectValue(t, cache, "max", "9223372036854775807")
+	expectValue(t, cache, "min", "-9223372036854775808")
+}
+
+func (h CacheHarness) testSetNX(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newAtomicCache(t)
+
+	ok, err := cache.SetNX(ctx, "lock", []byte("a"), 50*time.Millisecond)
+	if err != nil || !ok {
+		t.Fatalf("SetNX of a new key = %v, %v, want true", ok, err)
+	}
+	ok, err = cache.SetNX(ctx, "lock", []byte("b"), 0)
+	if err != nil || ok {
+		t.Errorf("SetNX of an existing key = %v, %v, want false", ok, err)
+	}
+	expectValue(t, cache, "lock", "a")
+
+	h.Advance(100 * time.Millisecond)
+	if ok, err := cache.SetNX(ctx, "lock", []byte("c"), 0); err != nil || !ok {
+		t.Errorf("SetNX after expiry = %v, %v, want true", ok, err)
+	}
+	expectValue(t, cache, "lock", "c")
+}
+
+func (h CacheHarness) testCompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newAtomicCache(t)
+
+	if ok, err := cache.CompareAndSwap(ctx, "missing", nil, []byte("x"), 0); err != nil || ok {
+		t.Errorf("CompareAndSwap of a missing key = %v, %v, want false", ok, err)
+	}
+	expectMissing(t, cache, "missing")
+
+	mustSet(t, cache, "key", "v1", 0)
+	if ok, err := cache.CompareAndSwap(ctx, "key", []byte("other"), []byte("v2"), 0); err != nil || ok {
+		t.Errorf("CompareAndSwap with a stale value = %v, %v, want false", ok, err)
+	}
+	expectValue(t, cache, "key", "v1")
+
+	if ok, err := cache.CompareAndSwap(ctx, "key", []byte("v1"), []byte("v2"), 50*time.Millisecond); err != nil || !ok {
+		t.Fatalf("CompareAndSwap with the current value = %v, %v, want true", ok, err)
+	}
+	expectValue(t, cache, "key", "v2")
+
+	h.Advance(100 * time.Millisecond)
+	expectMissing(t, cache, "key")
+}
+
+func (h CacheHarness) testExpireTTL(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newAtomicCache(t)
+
+	if _, err := cache.TTL(ctx, "missing"); !errors.Is(err, hyperion.ErrCacheMiss) {
+		t.Errorf("TTL of a missing key = %v, want ErrCacheMiss", err)
+	}
+	if ok, err := cache.Expire(ctx, "missing", time.Minute); err != nil || ok {
+		t.Errorf("Expire of a missing key = %v, %v, want false", ok, err)
+	}
+
+	mustSet(t, cache, "key", "value", 0)
+	if ttl, err := cache.TTL(ctx, "key"); err != nil || ttl != 0 {
+		t.Errorf("TTL without expiration = %s, %v, want 0", ttl, err)
+	}
+
+	if ok, err := cache.Expire(ctx, "key", time.Hour); err != nil || !ok {
+		t.Fatalf("Expire = %v, %v, want true", ok, err)
+	}
+	if ttl, err := cache.TTL(ctx, "key"); err != nil || ttl <= 59*time.Minute || ttl > time.Hour {
+		t.Errorf("TTL after Expire(1h) = %s, %v, want about 1h", ttl, err)
+	}
+
+	if ok, err := cache.Expire(ctx, "key", 0); err != nil || !ok {
+		t.Fatalf("Expire(0) = %v, %v, want true", ok, err)
+	}
+	if ttl, err := cache.TTL(ctx, "key"); err != nil || ttl != 0 {
+		t.Errorf("TTL after Expire(0) = %s, %v, want 0", ttl, err)
+	}
+
+	if _, err := cache.Expire(ctx, "key", 50*time.Millisecond); err != nil {
+		t.Fatalf("Expire failed: %v", err)
+	}
+	h.Advance(100 * time.Millisecond)
+	expectMissing(t, cache, "key")
+}
+
+func (h CacheHarness) testGetSet(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newAtomicCache(t)
+
+	old, found, err := cache.GetSet(ctx, "key", []byte("v1"), 0)
+	if err != nil || found || old != nil {
+		t.Errorf("GetSet of a new key = %q, %v, %v, want nil, false", old, found, err)
+	}
+	old, found, err = cache.GetSet(ctx, "key", []byte("v2"), 50*time.Millisecond)
+	if err != nil || !found || string(old) != "v1" {
+		t.Errorf("GetSet = %q, %v, %v, want v1, true", old, found, err)
+	}
+	expectValue(t, cache, "key", "v2")
+
+	h.Advance(100 * time.Millisecond)
+	expectMissing(t, cache, "key")
+}
+
+func (h CacheHarness) testConcurrentIncr(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newAtomicCache(t)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if _, err := cache.Incr(ctx, "counter", 0); err != nil {
+					t.Errorf("Incr failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	expectValue(t, cache, "counter", "400")
+
+	// Exactly one SetNX wins
+	var wins sync.WaitGroup
+	var mu sync.Mutex
+	winners := 0
+	for g := 0; g < 8; g++ {
+		wins.Add(1)
+		go func() {
+			defer wins.Done()
+			if ok, err := cache.SetNX(ctx, "once", []byte("x"), 0); err == nil && ok {
+				mu.Lock()
+				winners++
+				mu.Unlock()
+			}
+		}()
+	}
+	wins.Wait()
+	if winners != 1 {
+		t.Errorf("SetNX winners = %d, want 1", winners)
+	}
+}
@@ -0,0 +1,144 @@
+package hyperiontest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+// RunTaggedCacheSuite runs the hyperion.TaggedCache conformance tests
+// against h. The caches created by h.New must implement TaggedCache.
+//
+// DeletePrefix tests are skipped for caches returning
+// hyperion.ErrDeletePrefixUnsupported.
+func RunTaggedCacheSuite(t *testing.T, h CacheHarness) {
+	t.Helper()
+
+	if h.New == nil {
+		t.Fatal("hyperiontest: CacheHarness.New is required")
+	}
+	if h.Advance == nil {
+		h.Advance = time.Sleep
+	}
+
+	t.Run("InvalidateTags", h.testInvalidateTags)
+	t.Run("InvalidateSharedTag", h.testInvalidateSharedTag)
+	t.Run("TaggedTTL", h.testTaggedTTL)
+	t.Run("DeletePrefix", h.testDeletePrefix)
+}
+
+func (h CacheHarness) newTaggedCache(t *testing.T) hyperion.TaggedCache {
+	t.Helper()
+	cache, ok := h.newCache(t).(hyperion.TaggedCache)
+	if !ok {
+		t.Fatal("CacheHarness.New returned a cache that does not implement hyperion.TaggedCache")
+	}
+	return cache
+}
+
+// mustSetTagged stores value under key with tags and fails the test on error.
+func mustSetTagged(t *testing.T, cache hyperion.TaggedCache, key, value string, ttl time.Duration, tags ...string) {
+	t.Helper()
+	if err := cache.SetWithTags(context.Background(), key, []byte(value), ttl, tags...); err != nil {
+		t.Fatalf("SetWithTags(%q) failed: %v", key, err)
+	}
+}
+
+func (h CacheHarness) testInvalidateTags(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newTaggedCache(t)
+	mustSetTagged(t, cache, "user:1:profile", "p", 0, "user:1")
+	mustSetTagged(t, cache, "user:1:perms", "r", 0, "user:1")
+	mustSetTagged(t, cache, "user:2:profile", "p", 0, "user:2")
+	mustSet(t, cache, "untagged", "u", 0)
+	expectValue(t, cache, "user:1:profile", "p")
+
+	if err := cache.InvalidateTags(ctx, "user:1"); err != nil {
+		t.Fatalf("InvalidateTags failed: %v", err)
+	}
+	expectMissing(t, cache, "user:1:profile")
+	expectMissing(t, cache, "user:1:perms")
+	expectValue(t, cache, "user:2:profile", "p")
+	expectValue(t, cache, "untagged", "u")
+
+	if err := cache.InvalidateTags(ctx, "unknown"); err != nil {
+		t.Errorf("InvalidateTags of an unknown tag returned %v, want nil", err)
+	}
+	if err := cache.InvalidateTags(ctx); err != nil {
+		t.Errorf("InvalidateTags() returned %v, want nil", err)
+	}
+
+	// The tag is usable again
+	mustSetTagged(t, cache, "user:1:profile", "p2", 0, "user:1")
+	expectValue(t, cache, "user:1:profile", "p2")
+	if err := cache.InvalidateTags(ctx, "user:1"); err != nil {
+		t.Fatalf("InvalidateTags failed: %v", err)
+	}
+	expectMissing(t, cache, "user:1:profile")
+}
+
+func (h CacheHarness) testInvalidateSharedTag(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newTaggedCache(t)
+	mustSetTagged(t, cache, "team:7:members", "m", 0, "team:7", "user:1")
+	mustSetTagged(t, cache, "team:7:name", "n", 0, "team:7")
+	mustSetTagged(t, cache, "team:8:members", "m", 0, "team:8", "user:2")
+
+	if err := cache.InvalidateTags(ctx, "user:1", "user:2"); err != nil {
+		t.Fatalf("InvalidateTags failed: %v", err)
+	}
+	expectMissing(t, cache, "team:7:members")
+	expectMissing(t, cache, "team:8:members")
+	expectValue(t, cache, "team:7:name", "n")
+}
+
+func (h CacheHarness) testTaggedTTL(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newTaggedCache(t)
+	mustSetTagged(t, cache, "short", "s", 50*time.Millisecond, "tag")
+	mustSetTagged(t, cache, "long", "l", time.Hour, "tag")
+
+	h.Advance(100 * time.Millisecond)
+	expectMissing(t, cache, "short")
+	expectValue(t, cache, "long", "l")
+
+	if err := cache.InvalidateTags(ctx, "tag"); err != nil {
+		t.Fatalf("InvalidateTags failed: %v", err)
+	}
+	expectMissing(t, cache, "long")
+}
+
+func (h CacheHarness) testDeletePrefix(t *testing.T) {
+	ctx := context.Background()
+	cache := h.newTaggedCache(t)
+	mustSet(t, cache, "page:users:1", "1", 0)
+	mustSet(t, cache, "page:users:2", "2", 0)
+	mustSetTagged(t, cache, "page:users:3", "3", 0, "users")
+	mustSet(t, cache, "page:teams:1", "1", 0)
+	mustSet(t, cache, "user:1", "1", 0)
+
+	err := cache.DeletePrefix(ctx, "page:users:")
+	if errors.Is(err, hyperion.ErrDeletePrefixUnsupported) {
+		t.Skip("cache does not support DeletePrefix")
+	}
+	if err != nil {
+		t.Fatalf("DeletePrefix failed: %v", err)
+	}
+	expectMissing(t, cache, "page:users:1")
+	expectMissing(t, cache, "page:users:2")
+	expectMissing(t, cache, "page:users:3")
+	expectValue(t, cache, "page:teams:1", "1")
+	expectValue(t, cache, "user:1", "1")
+
+	// Glob characters are matched literally
+	mustSet(t, cache, "a*b", "1", 0)
+	mustSet(t, cache, "axb", "2", 0)
+	if err := cache.DeletePrefix(ctx, "a*"); err != nil {
+		t.Fatalf("DeletePrefix failed: %v", err)
+	}
+	expectMissing(t, cache, "a*b")
+	expectValue(t, cache, "axb", "2")
+}
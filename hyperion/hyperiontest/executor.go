@@ -0,0 +1,769 @@
+package hyperiontest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mapoio/hyperion"
+)
+
+// ExecutorHarness describes how RunExecutorSuite opens databases.
+type ExecutorHarness struct {
+	// New opens an empty database, closed by the harness when the test
+	// ends. Statements use "?" placeholders and portable SQL. Required.
+	New func(t *testing.T) hyperion.Database
+
+	// UnitOfWork returns the UnitOfWork for a database opened by New.
+	// If nil, the UnitOfWork tests are skipped.
+	UnitOfWork func(db hyperion.Database) hyperion.UnitOfWork
+}
+
+// RunExecutorSuite runs the hyperion.Executor and hyperion.UnitOfWork
+// conformance tests against h.
+func RunExecutorSuite(t *testing.T, h ExecutorHarness) {
+	t.Helper()
+
+	if h.New == nil {
+		t.Fatal("hyperiontest: ExecutorHarness.New is required")
+	}
+
+	t.Run("ExecQuery", h.testExecQuery)
+	t.Run("QueryScalar", h.testQueryScalar)
+	t.Run("QueryStruct", h.testQueryStruct)
+	t.Run("QueryEmpty", h.testQueryEmpty)
+	t.Run("RowsAffected", h.testRowsAffected)
+	t.Run("QueryRow", h.testQueryRow)
+	t.Run("QueryIter", h.testQueryIter)
+	t.Run("QueryIterInTransaction", h.testQueryIterInTransaction)
+	t.Run("Errors", h.testExecutorErrors)
+	t.Run("BeginCommit", h.testBeginCommit)
+	t.Run("BeginRollback", h.testBeginRollback)
+	t.Run("NotInTransaction", h.testNotInTransaction)
+	t.Run("Unwrap", h.testUnwrap)
+	t.Run("UnitOfWorkCommit", h.testUnitOfWorkCommit)
+	t.Run("UnitOfWorkRollback", h.testUnitOfWorkRollback)
+	t.Run("UnitOfWorkPanic", h.testUnitOfWorkPanic)
+	t.Run("PropagationRequired", h.testPropagationRequired)
+	t.Run("PropagationRequiresNew", h.testPropagationRequiresNew)
+	t.Run("PropagationNested", h.testPropagationNested)
+	t.Run("PropagationNestedPanic", h.testPropagationNestedPanic)
+	t.Run("PropagationMandatory", h.testPropagationMandatory)
+	t.Run("PropagationNever", h.testPropagationNever)
+	t.Run("HooksCommit", h.testHooksCommit)
+	t.Run("HooksRollback", h.testHooksRollback)
+	t.Run("HooksNested", h.testHooksNested)
+}
+
+// suiteUser is a row of suite_users. Fields are untagged: executors map
+// them to snake_case columns.
+type suiteUser struct {
+	Name         string
+	EmailAddress string
+	ID           int64
+}
+
+// open opens a database with an empty suite_users table.
+func (h ExecutorHarness) open(t *testing.T) hyperion.Database {
+	t.Helper()
+	db := h.New(t)
+	if db == nil {
+		t.Fatal("ExecutorHarness.New returned nil")
+	}
+	mustExec(t, db.Executor(), `CREATE TABLE suite_users (
+		id BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		email_address VARCHAR(255) NOT NULL
+	)`)
+	return db
+}
+
+func mustExec(t *testing.T, exec hyperion.Executor, sql string, args ...any) {
+	t.Helper()
+	if _, err := exec.Exec(context.Background(), sql, args...); err != nil {
+		t.Fatalf("Exec(%q) failed: %v", sql, err)
+	}
+}
+
+func insertUser(t *testing.T, exec hyperion.Executor, id int64, name string) {
+	t.Helper()
+	mustExec(t, exec, "INSERT INTO suite_users (id, name, email_address) VALUES (?, ?, ?)",
+		id, name, name+"@example.com")
+}
+
+// countUsers returns the number of rows in suite_users seen by exec.
+func countUsers(t *testing.T, exec hyperion.Executor) int64 {
+	t.Helper()
+	var n int64
+	if err := exec.Query(context.Background(), &n, "SELECT COUNT(*) FROM suite_users"); err != nil {
+		t.Fatalf("Query(COUNT) failed: %v", err)
+	}
+	return n
+}
+
+func (h ExecutorHarness) newContext(db hyperion.Database) hyperion.Context {
+	return hyperion.New(context.Background(), hyperion.NewNoOpLogger(), db.Executor(),
+		hyperion.NewNoOpTracer(), hyperion.NewNoOpMeter())
+}
+
+func (h ExecutorHarness) unitOfWork(t *testing.T, db hyperion.Database) hyperion.UnitOfWork {
+	t.Helper()
+	if h.UnitOfWork == nil {
+		t.Skip("ExecutorHarness.UnitOfWork not set")
+	}
+	return h.UnitOfWork(db)
+}
+
+func (h ExecutorHarness) testExecQuery(t *testing.T) {
+	exec := h.open(t).Executor()
+	insertUser(t, exec, 1, "alice")
+	insertUser(t, exec, 2, "bob")
+
+	var users []suiteUser
+	err := exec.Query(context.Background(), &users,
+		"SELECT id, name, email_address FROM suite_users WHERE id >= ? ORDER BY id", 1)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	want := []suiteUser{
+		{ID: 1, Name: "alice", EmailAddress: "alice@example.com"},
+		{ID: 2, Name: "bob", EmailAddress: "bob@example.com"},
+	}
+	if len(users) != len(want) {
+		t.Fatalf("Query returned %d rows, want %d", len(users), len(want))
+	}
+	for i := range want {
+		if users[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, users[i], want[i])
+		}
+	}
+}
+
+func (h ExecutorHarness) testQueryScalar(t *testing.T) {
+	exec := h.open(t).Executor()
+	insertUser(t, exec, 1, "alice")
+
+	if n := countUsers(t, exec); n != 1 {
+		t.Errorf("COUNT(*) = %d, want 1", n)
+	}
+
+	var name string
+	if err := exec.Query(context.Background(), &name, "SELECT name FROM suite_users WHERE id = ?", 1); err != nil {
+		t.Fatalf("Query(name) failed: %v", err)
+	}
+	if name != "alice" {
+		t.Errorf("name = %q, want alice", name)
+	}
+}
+
+func (h ExecutorHarness) testQueryStruct(t *testing.T) {
+	exec := h.open(t).Executor()
+	insertUser(t, exec, 7, "carol")
+
+	var user suiteUser
+	err := exec.Query(context.Background(), &user,
+		"SELECT id, name, email_address FROM suite_users WHERE id = ?", 7)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if want := (suiteUser{ID: 7, Name: "carol", EmailAddress: "carol@example.com"}); user != want {
+		t.Errorf("Query = %+v, want %+v", user, want)
+	}
+}
+
+func (h ExecutorHarness) testQueryEmpty(t *testing.T) {
+	exec := h.open(t).Executor()
+
+	var users []suiteUser
+	if err := exec.Query(context.Background(), &users, "SELECT id, name, email_address FROM suite_users"); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(users) != 0 {
+		t.Errorf("Query returned %d rows from an empty table", len(users))
+	}
+}
+
+func (h ExecutorHarness) testRowsAffected(t *testing.T) {
+	ctx := context.Background()
+	exec := h.open(t).Executor()
+	insertUser(t, exec, 1, "alice")
+	insertUser(t, exec, 2, "bob")
+	insertUser(t, exec, 3, "carol")
+
+	tests := []struct {
+		sql  string
+		args []any
+		want int64
+	}{
+		{"UPDATE suite_users SET name = ? WHERE id <= ?", []any{"renamed", 2}, 2},
+		{"UPDATE suite_users SET name = ? WHERE id = ?", []any{"nobody", 42}, 0},
+		{"DELETE FROM suite_users WHERE id = ?", []any{3}, 1},
+	}
+	for _, tt := range tests {
+		result, err := exec.Exec(ctx, tt.sql, tt.args...)
+		if err != nil {
+			t.Fatalf("Exec(%q) failed: %v", tt.sql, err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			t.Fatalf("RowsAffected failed: %v", err)
+		}
+		if n != tt.want {
+			t.Errorf("Exec(%q) affected %d rows, want %d", tt.sql, n, tt.want)
+		}
+	}
+}
+
+func (h ExecutorHarness) testQueryRow(t *testing.T) {
+	ctx := context.Background()
+	exec := h.open(t).Executor()
+	insertUser(t, exec, 1, "alice")
+
+	var user suiteUser
+	if err := exec.QueryRow(ctx, &user, "SELECT id, name, email_address FROM suite_users WHERE id = ?", 1); err != nil {
+		t.Fatalf("QueryRow failed: %v", err)
+	}
+	if want := (suiteUser{ID: 1, Name: "alice", EmailAddress: "alice@example.com"}); user != want {
+		t.Errorf("QueryRow = %+v, want %+v", user, want)
+	}
+
+	var name string
+	if err := exec.QueryRow(ctx, &name, "SELECT name FROM suite_users WHERE id = ?", 1); err != nil || name != "alice" {
+		t.Errorf("QueryRow(name) = %q, %v; want alice", name, err)
+	}
+
+	err := exec.QueryRow(ctx, &user, "SELECT id, name, email_address FROM suite_users WHERE id = ?", 2)
+	if !errors.Is(err, hyperion.ErrNotFound) {
+		t.Errorf("QueryRow without rows error = %v, want ErrNotFound", err)
+	}
+	if err := exec.QueryRow(ctx, &user, "SELECT id FROM missing_table"); err == nil || errors.Is(err, hyperion.ErrNotFound) {
+		t.Errorf("QueryRow on a missing table error = %v, want a query error", err)
+	}
+}
+
+func (h ExecutorHarness) testQueryIter(t *testing.T) {
+	ctx := context.Background()
+	exec := h.open(t).Executor()
+	for i, name := range []string{"alice", "bob", "carol"} {
+		insertUser(t, exec, int64(i+1), name)
+	}
+
+	rows, err := exec.QueryIter(ctx, "SELECT id, name, email_address FROM suite_users WHERE id > ? ORDER BY id", 0)
+	if err != nil {
+		t.Fatalf("QueryIter failed: %v", err)
+	}
+	var names []string
+	for rows.Next() {
+		var user suiteUser
+		if err := rows.Scan(&user); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		names = append(names, user.Name)
+	}
+	if err := rows.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+	if err := rows.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if err := rows.Close(); err != nil {
+		t.Errorf("second Close failed: %v", err)
+	}
+	if len(names) != 3 || names[0] != "alice" || names[2] != "carol" {
+		t.Errorf("iterated names = %v, want [alice bob carol]", names)
+	}
+
+	// Scalars, and stopping early
+	rows, err = exec.QueryIter(ctx, "SELECT id FROM suite_users ORDER BY id")
+	if err != nil {
+		t.Fatalf("QueryIter(id) failed: %v", err)
+	}
+	var id int64
+	if !rows.Next() {
+		t.Fatalf("Next() = false, Err() = %v", rows.Err())
+	}
+	if err := rows.Scan(&id); err != nil || id != 1 {
+		t.Errorf("Scan(id) = %d, %v; want 1", id, err)
+	}
+	if err := rows.Close(); err != nil {
+		t.Errorf("Close before the end failed: %v", err)
+	}
+
+	// The connection is released: the executor still works
+	if n := countUsers(t, exec); n != 3 {
+		t.Errorf("COUNT(*) after QueryIter = %d, want 3", n)
+	}
+
+	if _, err := exec.QueryIter(ctx, "SELECT id FROM missing_table"); err == nil {
+		t.Error("QueryIter on a missing table succeeded")
+	}
+}
+
+func (h ExecutorHarness) testQueryIterInTransaction(t *testing.T) {
+	ctx := context.Background()
+	tx, err := h.open(t).Executor().Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	insertUser(t, tx, 1, "alice")
+
+	rows, err := tx.QueryIter(ctx, "SELECT name FROM suite_users")
+	if err != nil {
+		t.Fatalf("QueryIter failed: %v", err)
+	}
+	defer rows.Close()
+	var count int
+	for rows.Next() {
+		count++
+	}
+	if err := rows.Err(); err != nil || count != 1 {
+		t.Errorf("iterated %d rows (err %v) inside the transaction, want 1", count, err)
+	}
+}
+
+func (h ExecutorHarness) testExecutorErrors(t *testing.T) {
+	ctx := context.Background()
+	exec := h.open(t).Executor()
+	insertUser(t, exec, 1, "alice")
+
+	// Duplicate primary key
+	_, err := exec.Exec(ctx, "INSERT INTO suite_users (id, name, email_address) VALUES (?, ?, ?)", 1, "again", "x")
+	if err == nil {
+		t.Error("Exec with a duplicate key succeeded")
+	}
+
+	var users []suiteUser
+	if err := exec.Query(ctx, &users, "SELECT id FROM missing_table"); err == nil {
+		t.Error("Query on a missing table succeeded")
+	}
+}
+
+func (h ExecutorHarness) testBeginCommit(t *testing.T) {
+	ctx := context.Background()
+	exec := h.open(t).Executor()
+
+	tx, err := exec.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	insertUser(t, tx, 1, "alice")
+	if n := countUsers(t, tx); n != 1 {
+		t.Errorf("COUNT(*) inside transaction = %d, want 1", n)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	if n := countUsers(t, exec); n != 1 {
+		t.Errorf("COUNT(*) after Commit = %d, want 1", n)
+	}
+}
+
+func (h ExecutorHarness) testBeginRollback(t *testing.T) {
+	ctx := context.Background()
+	exec := h.open(t).Executor()
+
+	tx, err := exec.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	insertUser(t, tx, 1, "alice")
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	if n := countUsers(t, exec); n != 0 {
+		t.Errorf("COUNT(*) after Rollback = %d, want 0", n)
+	}
+}
+
+func (h ExecutorHarness) testNotInTransaction(t *testing.T) {
+	exec := h.open(t).Executor()
+
+	if err := exec.Commit(); err == nil {
+		t.Error("Commit outside a transaction succeeded")
+	}
+	if err := exec.Rollback(); err == nil {
+		t.Error("Rollback outside a transaction succeeded")
+	}
+}
+
+func (h ExecutorHarness) testUnwrap(t *testing.T) {
+	db := h.open(t)
+
+	if db.Executor().Unwrap() == nil {
+		t.Error("Unwrap() = nil, want the underlying database handle")
+	}
+	tx, err := db.Executor().Begin(context.Background())
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if tx.Unwrap() == nil {
+		t.Error("transaction Unwrap() = nil, want the underlying transaction handle")
+	}
+}
+
+func (h ExecutorHarness) testUnitOfWorkCommit(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+	ctx := h.newContext(db)
+
+	err := uow.WithTransaction(ctx, func(txCtx hyperion.Context) error {
+		if txCtx.DB() == ctx.DB() {
+			t.Error("transaction context has the outer executor")
+		}
+		insertUser(t, txCtx.DB(), 1, "alice")
+		insertUser(t, txCtx.DB(), 2, "bob")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction failed: %v", err)
+	}
+
+	if n := countUsers(t, db.Executor()); n != 2 {
+		t.Errorf("COUNT(*) after commit = %d, want 2", n)
+	}
+}
+
+func (h ExecutorHarness) testUnitOfWorkRollback(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+	errAbort := errors.New("abort")
+
+	err := uow.WithTransaction(h.newContext(db), func(txCtx hyperion.Context) error {
+		insertUser(t, txCtx.DB(), 1, "alice")
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Errorf("WithTransaction error = %v, want the error returned by fn", err)
+	}
+
+	if n := countUsers(t, db.Executor()); n != 0 {
+		t.Errorf("COUNT(*) after rollback = %d, want 0", n)
+	}
+}
+
+func (h ExecutorHarness) testUnitOfWorkPanic(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("WithTransaction swallowed the panic")
+			}
+		}()
+		_ = uow.WithTransaction(h.newContext(db), func(txCtx hyperion.Context) error {
+			insertUser(t, txCtx.DB(), 1, "alice")
+			panic("boom")
+		})
+	}()
+
+	if n := countUsers(t, db.Executor()); n != 0 {
+		t.Errorf("COUNT(*) after panic = %d, want 0", n)
+	}
+}
+
+// withPropagation runs fn through uow with the given propagation.
+func withPropagation(
+	uow hyperion.UnitOfWork,
+	ctx hyperion.Context,
+	propagation hyperion.Propagation,
+	fn func(txCtx hyperion.Context) error,
+) error {
+	return uow.WithTransactionOptions(ctx, &hyperion.TransactionOptions{Propagation: propagation}, fn)
+}
+
+func (h ExecutorHarness) testPropagationRequired(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+	errAbort := errors.New("abort")
+
+	err := uow.WithTransaction(h.newContext(db), func(txCtx hyperion.Context) error {
+		insertUser(t, txCtx.DB(), 1, "alice")
+		err := uow.WithTransaction(txCtx, func(innerCtx hyperion.Context) error {
+			if n := countUsers(t, innerCtx.DB()); n != 1 {
+				t.Errorf("COUNT(*) in joined transaction = %d, want 1", n)
+			}
+			insertUser(t, innerCtx.DB(), 2, "bob")
+			return nil
+		})
+		if err != nil {
+			t.Errorf("joined WithTransaction failed: %v", err)
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Errorf("WithTransaction error = %v, want the error returned by fn", err)
+	}
+
+	if n := countUsers(t, db.Executor()); n != 0 {
+		t.Errorf("COUNT(*) after outer rollback = %d, want 0: inner work did not join", n)
+	}
+}
+
+func (h ExecutorHarness) testPropagationRequiresNew(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+	errAbort := errors.New("abort")
+
+	// The outer transaction does not touch the table, so databases with
+	// table or file locks let the inner one commit.
+	err := uow.WithTransaction(h.newContext(db), func(txCtx hyperion.Context) error {
+		err := withPropagation(uow, txCtx, hyperion.PropagationRequiresNew, func(innerCtx hyperion.Context) error {
+			if innerCtx.DB() == txCtx.DB() {
+				t.Error("PropagationRequiresNew reused the outer transaction")
+			}
+			insertUser(t, innerCtx.DB(), 1, "alice")
+			return nil
+		})
+		if err != nil {
+			t.Errorf("PropagationRequiresNew failed: %v", err)
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Errorf("WithTransaction error = %v, want the error returned by fn", err)
+	}
+
+	if n := countUsers(t, db.Executor()); n != 1 {
+		t.Errorf("COUNT(*) after outer rollback = %d, want 1: inner transaction was not independent", n)
+	}
+}
+
+func (h ExecutorHarness) testPropagationNested(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+	errAbort := errors.New("abort")
+
+	err := uow.WithTransaction(h.newContext(db), func(txCtx hyperion.Context) error {
+		insertUser(t, txCtx.DB(), 1, "alice")
+
+		err := withPropagation(uow, txCtx, hyperion.PropagationNested, func(nestedCtx hyperion.Context) error {
+			insertUser(t, nestedCtx.DB(), 2, "bob")
+			// A savepoint inside a savepoint must not shadow it
+			err := withPropagation(uow, nestedCtx, hyperion.PropagationNested, func(innerCtx hyperion.Context) error {
+				insertUser(t, innerCtx.DB(), 3, "carol")
+				return nil
+			})
+			if err != nil {
+				t.Errorf("inner PropagationNested failed: %v", err)
+			}
+			return errAbort
+		})
+		if !errors.Is(err, errAbort) {
+			t.Errorf("PropagationNested error = %v, want the error returned by fn", err)
+		}
+		if n := countUsers(t, txCtx.DB()); n != 1 {
+			t.Errorf("COUNT(*) after savepoint rollback = %d, want 1", n)
+		}
+
+		return withPropagation(uow, txCtx, hyperion.PropagationNested, func(nestedCtx hyperion.Context) error {
+			insertUser(t, nestedCtx.DB(), 4, "dave")
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction failed: %v", err)
+	}
+
+	if n := countUsers(t, db.Executor()); n != 2 {
+		t.Errorf("COUNT(*) after commit = %d, want 2", n)
+	}
+}
+
+func (h ExecutorHarness) testPropagationNestedPanic(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+
+	err := uow.WithTransaction(h.newContext(db), func(txCtx hyperion.Context) error {
+		insertUser(t, txCtx.DB(), 1, "alice")
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("PropagationNested swallowed the panic")
+				}
+			}()
+			_ = withPropagation(uow, txCtx, hyperion.PropagationNested, func(nestedCtx hyperion.Context) error {
+				insertUser(t, nestedCtx.DB(), 2, "bob")
+				panic("boom")
+			})
+		}()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction failed: %v", err)
+	}
+
+	if n := countUsers(t, db.Executor()); n != 1 {
+		t.Errorf("COUNT(*) after commit = %d, want 1", n)
+	}
+}
+
+func (h ExecutorHarness) testPropagationMandatory(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+	ctx := h.newContext(db)
+
+	err := withPropagation(uow, ctx, hyperion.PropagationMandatory, func(hyperion.Context) error {
+		t.Error("PropagationMandatory ran fn outside a transaction")
+		return nil
+	})
+	if !errors.Is(err, hyperion.ErrNoTransaction) {
+		t.Errorf("PropagationMandatory error = %v, want ErrNoTransaction", err)
+	}
+
+	err = uow.WithTransaction(ctx, func(txCtx hyperion.Context) error {
+		return withPropagation(uow, txCtx, hyperion.PropagationMandatory, func(innerCtx hyperion.Context) error {
+			if innerCtx.DB() != txCtx.DB() {
+				t.Error("PropagationMandatory did not join the transaction")
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Errorf("WithTransaction failed: %v", err)
+	}
+}
+
+func (h ExecutorHarness) testPropagationNever(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+	ctx := h.newContext(db)
+
+	err := withPropagation(uow, ctx, hyperion.PropagationNever, func(innerCtx hyperion.Context) error {
+		if err := innerCtx.DB().Commit(); err == nil {
+			t.Error("PropagationNever ran fn in a transaction")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("PropagationNever failed: %v", err)
+	}
+
+	err = uow.WithTransaction(ctx, func(txCtx hyperion.Context) error {
+		return withPropagation(uow, txCtx, hyperion.PropagationNever, func(hyperion.Context) error {
+			t.Error("PropagationNever ran fn inside a transaction")
+			return nil
+		})
+	})
+	if !errors.Is(err, hyperion.ErrTransactionExists) {
+		t.Errorf("PropagationNever error = %v, want ErrTransactionExists", err)
+	}
+}
+
+func (h ExecutorHarness) testHooksCommit(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+
+	var committed, rolledBack bool
+	err := uow.WithTransaction(h.newContext(db), func(txCtx hyperion.Context) error {
+		insertUser(t, txCtx.DB(), 1, "alice")
+		hyperion.OnCommit(txCtx, func(ctx hyperion.Context) error {
+			committed = true
+			// The hook runs after the commit, outside the transaction
+			if n := countUsers(t, ctx.DB()); n != 1 {
+				t.Errorf("COUNT(*) in OnCommit = %d, want 1", n)
+			}
+			return nil
+		})
+		hyperion.OnRollback(txCtx, func(hyperion.Context) error {
+			rolledBack = true
+			return nil
+		})
+		if committed {
+			t.Error("OnCommit hook ran before the commit")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction failed: %v", err)
+	}
+	if !committed || rolledBack {
+		t.Errorf("after commit: OnCommit ran = %v, OnRollback ran = %v; want true, false", committed, rolledBack)
+	}
+}
+
+func (h ExecutorHarness) testHooksRollback(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+	ctx := h.newContext(db)
+
+	for _, abort := range []func() error{
+		func() error { return errors.New("abort") },
+		func() error { panic("boom") },
+	} {
+		var committed, rolledBack bool
+		func() {
+			defer func() { _ = recover() }()
+			_ = uow.WithTransaction(ctx, func(txCtx hyperion.Context) error {
+				insertUser(t, txCtx.DB(), 1, "alice")
+				hyperion.OnCommit(txCtx, func(hyperion.Context) error {
+					committed = true
+					return nil
+				})
+				hyperion.OnRollback(txCtx, func(hyperion.Context) error {
+					rolledBack = true
+					return nil
+				})
+				return abort()
+			})
+		}()
+		if committed || !rolledBack {
+			t.Errorf("after rollback: OnCommit ran = %v, OnRollback ran = %v; want false, true", committed, rolledBack)
+		}
+	}
+}
+
+func (h ExecutorHarness) testHooksNested(t *testing.T) {
+	db := h.open(t)
+	uow := h.unitOfWork(t, db)
+	errAbort := errors.New("abort")
+
+	var calls []string
+	record := func(name string) hyperion.TransactionHook {
+		return func(hyperion.Context) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	err := uow.WithTransaction(h.newContext(db), func(txCtx hyperion.Context) error {
+		hyperion.OnCommit(txCtx, record("outer"))
+
+		// A joined call registers on the outer transaction
+		_ = uow.WithTransaction(txCtx, func(innerCtx hyperion.Context) error {
+			hyperion.OnCommit(innerCtx, record("joined"))
+			return nil
+		})
+
+		// A rolled back savepoint drops its commit hooks
+		_ = withPropagation(uow, txCtx, hyperion.PropagationNested, func(nestedCtx hyperion.Context) error {
+			hyperion.OnCommit(nestedCtx, record("rolled back savepoint"))
+			hyperion.OnRollback(nestedCtx, record("savepoint rollback"))
+			return errAbort
+		})
+
+		// A released savepoint waits for the outer commit
+		_ = withPropagation(uow, txCtx, hyperion.PropagationNested, func(nestedCtx hyperion.Context) error {
+			hyperion.OnCommit(nestedCtx, record("released savepoint"))
+			return nil
+		})
+
+		if want := []string{"savepoint rollback"}; !slices.Equal(calls, want) {
+			t.Errorf("hooks run before the outer commit = %v, want %v", calls, want)
+		}
+		calls = nil
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction failed: %v", err)
+	}
+
+	if want := []string{"outer", "joined", "released savepoint"}; !slices.Equal(calls, want) {
+		t.Errorf("hooks run after the outer commit = %v, want %v", calls, want)
+	}
+}
@@ -0,0 +1,201 @@
+package hyperiontest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+// LockerHarness describes how RunLockerSuite creates lockers.
+type LockerHarness struct {
+	// New creates a locker with no locks held. Lockers created by
+	// successive calls within one test share nothing. Required.
+	New func(t *testing.T) hyperion.Locker
+
+	// Advance moves the locker's clock forward by d, so lock expiry can be
+	// tested without sleeping. If nil, the suite sleeps for d.
+	Advance func(d time.Duration)
+}
+
+// RunLockerSuite runs the hyperion.Locker conformance tests against h.
+func RunLockerSuite(t *testing.T, h LockerHarness) {
+	t.Helper()
+
+	if h.New == nil {
+		t.Fatal("hyperiontest: LockerHarness.New is required")
+	}
+	if h.Advance == nil {
+		h.Advance = time.Sleep
+	}
+
+	t.Run("AcquireRelease", h.testAcquireRelease)
+	t.Run("IndependentKeys", h.testIndependentKeys)
+	t.Run("Expiry", h.testLockExpiry)
+	t.Run("Refresh", h.testLockRefresh)
+	t.Run("ReleaseTwice", h.testReleaseTwice)
+	t.Run("FencingTokens", h.testFencingTokens)
+	t.Run("ConcurrentAcquire", h.testConcurrentAcquire)
+}
+
+func (h LockerHarness) newLocker(t *testing.T) hyperion.Locker {
+	t.Helper()
+	locker := h.New(t)
+	if locker == nil {
+		t.Fatal("LockerHarness.New returned nil")
+	}
+	return locker
+}
+
+func mustAcquire(t *testing.T, locker hyperion.Locker, key string, ttl time.Duration) hyperion.Lock {
+	t.Helper()
+	lock, err := locker.Acquire(context.Background(), key, ttl)
+	if err != nil {
+		t.Fatalf("Acquire(%q) failed: %v", key, err)
+	}
+	return lock
+}
+
+// expectHeld fails the test unless acquiring key reports ErrLockHeld.
+func expectHeld(t *testing.T, locker hyperion.Locker, key string) {
+	t.Helper()
+	_, err := locker.Acquire(context.Background(), key, time.Second)
+	if !errors.Is(err, hyperion.ErrLockHeld) {
+		t.Errorf("Acquire(%q) error = %v, want ErrLockHeld", key, err)
+	}
+}
+
+func (h LockerHarness) testAcquireRelease(t *testing.T) {
+	ctx := context.Background()
+	locker := h.newLocker(t)
+
+	lock := mustAcquire(t, locker, "job", time.Second)
+	if lock.Key() != "job" {
+		t.Errorf("Key() = %q, want job", lock.Key())
+	}
+	if lock.Token() <= 0 {
+		t.Errorf("Token() = %d, want > 0", lock.Token())
+	}
+	expectHeld(t, locker, "job")
+
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	mustAcquire(t, locker, "job", time.Second)
+}
+
+func (h LockerHarness) testIndependentKeys(t *testing.T) {
+	locker := h.newLocker(t)
+
+	mustAcquire(t, locker, "a", time.Second)
+	mustAcquire(t, locker, "b", time.Second)
+	expectHeld(t, locker, "a")
+	expectHeld(t, locker, "b")
+}
+
+func (h LockerHarness) testLockExpiry(t *testing.T) {
+	ctx := context.Background()
+	locker := h.newLocker(t)
+
+	stale := mustAcquire(t, locker, "job", 100*time.Millisecond)
+	h.Advance(150 * time.Millisecond)
+
+	current := mustAcquire(t, locker, "job", time.Second)
+	if current.Token() <= stale.Token() {
+		t.Errorf("token after expiry = %d, want > %d", current.Token(), stale.Token())
+	}
+
+	// The expired owner can neither extend nor release the new owner's lock
+	if err := stale.Refresh(ctx, time.Second); !errors.Is(err, hyperion.ErrLockLost) {
+		t.Errorf("stale Refresh error = %v, want ErrLockLost", err)
+	}
+	if err := stale.Release(ctx); !errors.Is(err, hyperion.ErrLockLost) {
+		t.Errorf("stale Release error = %v, want ErrLockLost", err)
+	}
+	expectHeld(t, locker, "job")
+}
+
+func (h LockerHarness) testLockRefresh(t *testing.T) {
+	ctx := context.Background()
+	locker := h.newLocker(t)
+
+	lock := mustAcquire(t, locker, "job", 100*time.Millisecond)
+	h.Advance(60 * time.Millisecond)
+	if err := lock.Refresh(ctx, 200*time.Millisecond); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	h.Advance(100 * time.Millisecond)
+	expectHeld(t, locker, "job")
+
+	if err := lock.Release(ctx); err != nil {
+		t.Errorf("Release after Refresh failed: %v", err)
+	}
+}
+
+func (h LockerHarness) testReleaseTwice(t *testing.T) {
+	ctx := context.Background()
+	locker := h.newLocker(t)
+
+	lock := mustAcquire(t, locker, "job", time.Second)
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if err := lock.Release(ctx); !errors.Is(err, hyperion.ErrLockLost) {
+		t.Errorf("second Release error = %v, want ErrLockLost", err)
+	}
+	if err := lock.Refresh(ctx, time.Second); !errors.Is(err, hyperion.ErrLockLost) {
+		t.Errorf("Refresh after Release error = %v, want ErrLockLost", err)
+	}
+}
+
+func (h LockerHarness) testFencingTokens(t *testing.T) {
+	ctx := context.Background()
+	locker := h.newLocker(t)
+
+	var last int64
+	for i := range 5 {
+		lock := mustAcquire(t, locker, "job", time.Second)
+		if lock.Token() <= last {
+			t.Fatalf("acquisition %d token = %d, want > %d", i, lock.Token(), last)
+		}
+		last = lock.Token()
+		if err := lock.Release(ctx); err != nil {
+			t.Fatalf("Release failed: %v", err)
+		}
+	}
+}
+
+func (h LockerHarness) testConcurrentAcquire(t *testing.T) {
+	ctx := context.Background()
+	locker := h.newLocker(t)
+
+	const workers = 8
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		winners int
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := locker.Acquire(ctx, "job", time.Second)
+			switch {
+			case err == nil:
+				mu.Lock()
+				winners++
+				mu.Unlock()
+			case !errors.Is(err, hyperion.ErrLockHeld):
+				t.Errorf("Acquire failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if winners != 1 {
+		t.Errorf("%d concurrent Acquire calls succeeded, want 1", winners)
+	}
+}
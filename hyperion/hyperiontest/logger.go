@@ -0,0 +1,366 @@
+// Package hyperiontest provides conformance test suites for adapters that
+// implement the hyperion interfaces.
+//
+// Each adapter runs the same suite from its own tests, so implementations
+// of an interface behave the same way from the caller's point of view:
+//
+//	func TestConformance(t *testing.T) {
+//	    hyperiontest.RunLoggerSuite(t, hyperiontest.LoggerHarness{
+//	        New: func(w io.Writer, level hyperion.LogLevel) hyperion.Logger {
+//	            return newTestLogger(w, level)
+//	        },
+//	    })
+//	}
+package hyperiontest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mapoio/hyperion"
+)
+
+// LoggerHarness describes how RunLoggerSuite creates and reads loggers.
+type LoggerHarness struct {
+	// New creates a logger that writes one JSON object per entry to w,
+	// with level as its initial minimum level. Required.
+	New func(w io.Writer, level hyperion.LogLevel) hyperion.Logger
+
+	// TraceContext returns a context carrying an active span together with
+	// the expected trace_id and span_id strings.
+	// If nil, trace correlation checks are skipped.
+	TraceContext func() (ctx context.Context, traceID, spanID string)
+
+	// MessageKey is the JSON key holding the log message. Defaults to "msg".
+	MessageKey string
+
+	// LevelKey is the JSON key holding the level name. Defaults to "level".
+	// Level names are compared case-insensitively.
+	LevelKey string
+}
+
+// RunLoggerSuite runs the hyperion.Logger conformance tests against h.
+func RunLoggerSuite(t *testing.T, h LoggerHarness) {
+	t.Helper()
+
+	if h.New == nil {
+		t.Fatal("hyperiontest: LoggerHarness.New is required")
+	}
+	if h.MessageKey == "" {
+		h.MessageKey = "msg"
+	}
+	if h.LevelKey == "" {
+		h.LevelKey = "level"
+	}
+
+	t.Run("LevelNames", h.testLevelNames)
+	t.Run("LevelFiltering", h.testLevelFiltering)
+	t.Run("Fields", h.testFields)
+	t.Run("TypedFields", h.testTypedFields)
+	t.Run("BadKey", h.testBadKey)
+	t.Run("WithIsImmutable", h.testWithIsImmutable)
+	t.Run("WithError", h.testWithError)
+	t.Run("SetLevel", h.testSetLevel)
+	t.Run("SetLevelAffectsChildren", h.testSetLevelAffectsChildren)
+	t.Run("WithContext", h.testWithContext)
+}
+
+// logBuffer is a concurrency-safe buffer of JSON log lines.
+type logBuffer struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// entries decodes every JSON line written so far.
+func (b *logBuffer) entries(t *testing.T) []map[string]any {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var entries []map[string]any
+	for _, line := range strings.Split(b.buf.String(), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line is not valid JSON: %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (h LoggerHarness) newLogger(t *testing.T, level hyperion.LogLevel) (hyperion.Logger, *logBuffer) {
+	t.Helper()
+	buf := &logBuffer{}
+	logger := h.New(buf, level)
+	if logger == nil {
+		t.Fatal("LoggerHarness.New returned nil")
+	}
+	return logger, buf
+}
+
+func (h LoggerHarness) messages(entries []map[string]any) []string {
+	msgs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		msg, _ := e[h.MessageKey].(string)
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+func (h LoggerHarness) testLevelNames(t *testing.T) {
+	logger, buf := h.newLogger(t, hyperion.DebugLevel)
+
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+
+	entries := buf.entries(t)
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4", len(entries))
+	}
+	for i, want := range []hyperion.LogLevel{
+		hyperion.DebugLevel, hyperion.InfoLevel, hyperion.WarnLevel, hyperion.ErrorLevel,
+	} {
+		got, _ := entries[i][h.LevelKey].(string)
+		if !strings.EqualFold(got, want.String()) {
+			t.Errorf("entry %d: %s = %q, want %q", i, h.LevelKey, got, want)
+		}
+		if entries[i][h.MessageKey] != want.String() {
+			t.Errorf("entry %d: %s = %v, want %q", i, h.MessageKey, entries[i][h.MessageKey], want)
+		}
+	}
+}
+
+func (h LoggerHarness) testLevelFiltering(t *testing.T) {
+	logger, buf := h.newLogger(t, hyperion.WarnLevel)
+
+	if got := logger.GetLevel(); got != hyperion.WarnLevel {
+		t.Errorf("GetLevel() = %v, want %v", got, hyperion.WarnLevel)
+	}
+
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+
+	got := h.messages(buf.entries(t))
+	if strings.Join(got, ",") != "warn,error" {
+		t.Errorf("logged messages = %v, want [warn error]", got)
+	}
+}
+
+func (h LoggerHarness) testFields(t *testing.T) {
+	logger, buf := h.newLogger(t, hyperion.DebugLevel)
+
+	logger.Info("fields", "string", "value", "int", 42, "bool", true)
+
+	entries := buf.entries(t)
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	e := entries[0]
+	if e["string"] != "value" {
+		t.Errorf("string = %v, want value", e["string"])
+	}
+	if e["int"] != float64(42) {
+		t.Errorf("int = %v, want 42", e["int"])
+	}
+	if e["bool"] != true {
+		t.Errorf("bool = %v, want true", e["bool"])
+	}
+}
+
+func (h LoggerHarness) testTypedFields(t *testing.T) {
+	logger, buf := h.newLogger(t, hyperion.DebugLevel)
+
+	hyperion.LogFields(logger, hyperion.InfoLevel, "typed",
+		hyperion.F.String("string", "value"),
+		hyperion.F.Int("int", 42),
+		hyperion.F.Float64("float", 1.5),
+		hyperion.F.Bool("bool", true),
+		hyperion.F.Duration("duration", 0),
+		hyperion.F.Err(errors.New("boom")),
+		hyperion.F.Any("any", []string{"a"}),
+	)
+	hyperion.LogFields(logger, hyperion.DebugLevel, "debug")
+	logger.Warn("mixed", hyperion.F.String("typed", "yes"), "plain", "yes")
+
+	entries := buf.entries(t)
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	e := entries[0]
+	if e["string"] != "value" || e["int"] != float64(42) || e["float"] != 1.5 || e["bool"] != true {
+		t.Errorf("typed entry = %v, want string/int/float/bool fields", e)
+	}
+	if e["error"] != "boom" {
+		t.Errorf("error = %v, want boom", e["error"])
+	}
+	if _, ok := e["duration"]; !ok {
+		t.Errorf("typed entry = %v, want duration field", e)
+	}
+	if anyVal, _ := e["any"].([]any); len(anyVal) != 1 || anyVal[0] != "a" {
+		t.Errorf("any = %v, want [a]", e["any"])
+	}
+
+	if got, _ := entries[1][h.LevelKey].(string); !strings.EqualFold(got, "debug") {
+		t.Errorf("LogFields level = %q, want debug", got)
+	}
+
+	if entries[2]["typed"] != "yes" || entries[2]["plain"] != "yes" {
+		t.Errorf("mixed entry = %v, want typed and plain fields", entries[2])
+	}
+}
+
+func (h LoggerHarness) testBadKey(t *testing.T) {
+	logger, buf := h.newLogger(t, hyperion.DebugLevel)
+
+	logger.Info("dangling key", "ok", 1, "dangling")
+	logger.Info("non-string key", 42, "ok", 1)
+
+	entries := buf.entries(t)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0][hyperion.BadKey] != "dangling" || entries[0]["ok"] != float64(1) {
+		t.Errorf("entry 0 = %v, want %s=dangling and ok=1", entries[0], hyperion.BadKey)
+	}
+	if entries[1][hyperion.BadKey] != float64(42) || entries[1]["ok"] != float64(1) {
+		t.Errorf("entry 1 = %v, want %s=42 and ok=1", entries[1], hyperion.BadKey)
+	}
+}
+
+func (h LoggerHarness) testWithIsImmutable(t *testing.T) {
+	logger, buf := h.newLogger(t, hyperion.DebugLevel)
+
+	child := logger.With("request_id", "abc123")
+	child.Info("child", "extra", 1)
+	logger.Info("parent")
+
+	entries := buf.entries(t)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0]["request_id"] != "abc123" || entries[0]["extra"] != float64(1) {
+		t.Errorf("child entry = %v, want request_id and extra fields", entries[0])
+	}
+	if _, ok := entries[1]["request_id"]; ok {
+		t.Errorf("parent entry = %v, must not inherit child fields", entries[1])
+	}
+}
+
+func (h LoggerHarness) testWithError(t *testing.T) {
+	logger, buf := h.newLogger(t, hyperion.DebugLevel)
+
+	logger.WithError(errors.New("boom")).Error("failed")
+
+	entries := buf.entries(t)
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	if entries[0]["error"] != "boom" {
+		t.Errorf("error = %v, want boom", entries[0]["error"])
+	}
+}
+
+func (h LoggerHarness) testSetLevel(t *testing.T) {
+	logger, buf := h.newLogger(t, hyperion.InfoLevel)
+
+	for _, level := range []hyperion.LogLevel{
+		hyperion.DebugLevel, hyperion.WarnLevel, hyperion.ErrorLevel, hyperion.InfoLevel,
+	} {
+		logger.SetLevel(level)
+		if got := logger.GetLevel(); got != level {
+			t.Errorf("after SetLevel(%v), GetLevel() = %v", level, got)
+		}
+	}
+
+	logger.SetLevel(hyperion.ErrorLevel)
+	logger.Warn("suppressed")
+	logger.SetLevel(hyperion.DebugLevel)
+	logger.Debug("emitted")
+
+	got := h.messages(buf.entries(t))
+	if strings.Join(got, ",") != "emitted" {
+		t.Errorf("logged messages = %v, want [emitted]", got)
+	}
+}
+
+func (h LoggerHarness) testSetLevelAffectsChildren(t *testing.T) {
+	logger, buf := h.newLogger(t, hyperion.DebugLevel)
+
+	child := logger.With("k", "v")
+	logger.SetLevel(hyperion.ErrorLevel)
+	child.Info("suppressed")
+	child.Error("emitted")
+
+	got := h.messages(buf.entries(t))
+	if strings.Join(got, ",") != "emitted" {
+		t.Errorf("logged messages = %v, want [emitted]", got)
+	}
+	if lvl := child.GetLevel(); lvl != hyperion.ErrorLevel {
+		t.Errorf("child GetLevel() = %v, want %v", lvl, hyperion.ErrorLevel)
+	}
+}
+
+func (h LoggerHarness) testWithContext(t *testing.T) {
+	if h.TraceContext == nil {
+		t.Skip("LoggerHarness.TraceContext not set")
+	}
+
+	logger, buf := h.newLogger(t, hyperion.DebugLevel)
+	contextAware, ok := logger.(hyperion.ContextAwareLogger)
+	if !ok {
+		t.Fatal("logger does not implement hyperion.ContextAwareLogger")
+	}
+
+	ctx, traceID, spanID := h.TraceContext()
+	contextAware.WithContext(ctx).Info("traced")
+	contextAware.WithContext(ctx).With("user", "alice").Info("traced with fields")
+
+	// Fields added before binding the context must be preserved.
+	if child, ok := logger.With("request_id", "abc123").(hyperion.ContextAwareLogger); ok {
+		child.WithContext(ctx).Info("child traced")
+	} else {
+		t.Error("child logger does not implement hyperion.ContextAwareLogger")
+	}
+
+	contextAware.WithContext(context.Background()).Info("untraced")
+
+	entries := buf.entries(t)
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4", len(entries))
+	}
+	for i, e := range entries[:3] {
+		if e["trace_id"] != traceID || e["span_id"] != spanID {
+			t.Errorf("entry %d: trace_id/span_id = %v/%v, want %s/%s",
+				i, e["trace_id"], e["span_id"], traceID, spanID)
+		}
+	}
+	if entries[1]["user"] != "alice" {
+		t.Errorf("entry 1: user = %v, want alice", entries[1]["user"])
+	}
+	if entries[2]["request_id"] != "abc123" {
+		t.Errorf("entry 2: request_id = %v, want abc123", entries[2]["request_id"])
+	}
+	if _, ok := entries[3]["trace_id"]; ok {
+		t.Errorf("entry 3: trace_id must not be set without an active span")
+	}
+}
@@ -0,0 +1,200 @@
+package hyperion
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultLeaseTTL is the lock TTL used by LeaderElector.
+const DefaultLeaseTTL = 15 * time.Second
+
+// LeaderElector elects one leader among instances campaigning on the same
+// key, using a Locker lease. The leader refreshes the lease every renew
+// interval; the others retry acquiring it at the same interval.
+//
+// Leadership is lost when the lease cannot be refreshed: at once if the lock
+// was taken over (ErrLockLost), or otherwise when refreshes have failed
+// until one renew interval before the lease would expire. Stepping down
+// early leaves OnElected that interval to stop before another instance can
+// be elected. Work done as leader should still pass the lock's fencing
+// token to the resources it writes, since a stalled process can outlive its
+// lease.
+type LeaderElector struct {
+	locker    Locker
+	logger    Logger
+	onElected func(ctx context.Context, lock Lock)
+	onDemoted func()
+	key       string
+	ttl       time.Duration
+	interval  time.Duration
+	leader    atomic.Bool
+}
+
+// LeaderOption configures a LeaderElector.
+type LeaderOption func(*LeaderElector)
+
+// WithLeaseTTL sets the TTL of the leadership lock. Default: DefaultLeaseTTL.
+func WithLeaseTTL(ttl time.Duration) LeaderOption {
+	return func(e *LeaderElector) {
+		e.ttl = ttl
+	}
+}
+
+// WithRenewInterval sets how often the leader refreshes its lease and
+// followers retry. It must be less than the lease TTL. Default: a third of
+// the lease TTL.
+func WithRenewInterval(interval time.Duration) LeaderOption {
+	return func(e *LeaderElector) {
+		e.interval = interval
+	}
+}
+
+// WithLeaderLogger sets the logger for failed campaigns and refreshes.
+// Defaults to no logging.
+func WithLeaderLogger(logger Logger) LeaderOption {
+	return func(e *LeaderElector) {
+		e.logger = logger
+	}
+}
+
+// OnElected sets the callback run when this instance becomes leader. It
+// runs in its own goroutine with a context cancelled when leadership is
+// lost or Run stops, and must return promptly after that.
+func OnElected(fn func(ctx context.Context, lock Lock)) LeaderOption {
+	return func(e *LeaderElector) {
+		e.onElected = fn
+	}
+}
+
+// OnDemoted sets the callback run when this instance stops being leader,
+// after the OnElected callback has returned.
+func OnDemoted(fn func()) LeaderOption {
+	return func(e *LeaderElector) {
+		e.onDemoted = fn
+	}
+}
+
+// NewLeaderElector creates a LeaderElector campaigning on key.
+//
+// Example:
+//
+//	elector := hyperion.NewLeaderElector(locker, "scheduler",
+//	    hyperion.OnElected(func(ctx context.Context, lock hyperion.Lock) {
+//	        scheduler.Run(ctx)
+//	    }),
+//	)
+//	go elector.Run(ctx)
+func NewLeaderElector(locker Locker, key string, opts ...LeaderOption) *LeaderElector {
+	e := &LeaderElector{
+		locker:    locker,
+		logger:    NewNoOpLogger(),
+		onElected: func(context.Context, Lock) {},
+		onDemoted: func() {},
+		key:       key,
+		ttl:       DefaultLeaseTTL,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.interval <= 0 || e.interval >= e.ttl {
+		e.interval = e.ttl / 3
+	}
+	return e
+}
+
+// IsLeader reports whether this instance currently holds leadership.
+func (e *LeaderElector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Run campaigns for leadership until ctx is done. A held lease is released
+// on return so another instance can take over without waiting for the TTL.
+func (e *LeaderElector) Run(ctx context.Context) {
+	for {
+		start := time.Now()
+		lock, err := e.locker.Acquire(ctx, e.key, e.ttl)
+		switch {
+		case err == nil:
+			e.lead(ctx, lock, start)
+		case !errors.Is(err, ErrLockHeld) && ctx.Err() == nil:
+			e.logger.Warn("leader campaign failed", "key", e.key, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// lead holds leadership until the lease is lost or ctx is done. acquired
+// is when the lease was requested, the latest it can have started.
+func (e *LeaderElector) lead(ctx context.Context, lock Lock, acquired time.Time) {
+	leaderCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	e.leader.Store(true)
+	go func() {
+		defer close(done)
+		e.onElected(leaderCtx, lock)
+	}()
+
+	lost := e.renew(ctx, lock, acquired)
+
+	cancel()
+	<-done
+	if !lost {
+		if err := lock.Release(context.WithoutCancel(ctx)); err != nil && !errors.Is(err, ErrLockLost) {
+			e.logger.Warn("leader release failed", "key", e.key, "error", err)
+		}
+	}
+	e.leader.Store(false)
+	e.onDemoted()
+}
+
+// renew refreshes the lease every interval until ctx is done or the lease
+// is lost, and reports whether it was lost. renewed is when the lease was
+// last granted.
+func (e *LeaderElector) renew(ctx context.Context, lock Lock, renewed time.Time) bool {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	// Step down one interval before the lease can expire
+	deadline := renewed.Add(e.ttl - e.interval)
+	stepDown := time.NewTimer(time.Until(deadline))
+	defer stepDown.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-stepDown.C:
+			e.logger.Warn("leadership lost", "key", e.key, "error", "lease not refreshed in time")
+			return true
+		case <-ticker.C:
+		}
+
+		start := time.Now()
+		refreshCtx, cancel := context.WithDeadline(ctx, deadline)
+		err := lock.Refresh(refreshCtx, e.ttl)
+		cancel()
+
+		switch {
+		case err == nil:
+			deadline = start.Add(e.ttl - e.interval)
+			stepDown.Reset(time.Until(deadline))
+		case errors.Is(err, ErrLockLost):
+			e.logger.Warn("leadership lost", "key", e.key)
+			return true
+		case ctx.Err() != nil:
+			return false
+		case !time.Now().Before(deadline):
+			e.logger.Warn("leadership lost", "key", e.key, "error", err)
+			return true
+		default:
+			e.logger.Warn("leader refresh failed", "key", e.key, "error", err)
+		}
+	}
+}
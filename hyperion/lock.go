@@ -0,0 +1,86 @@
+package hyperion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+var (
+	// ErrLockHeld is returned by Locker.Acquire when another owner holds the lock.
+	ErrLockHeld = errors.New("lock is held by another owner")
+
+	// ErrLockLost is returned by Lock.Refresh and Lock.Release when the lock
+	// expired or was taken over by another owner.
+	ErrLockLost = errors.New("lock lost")
+)
+
+// Locker provides distributed mutual exclusion, for work that only one
+// instance may run at a time: migrations, cron jobs, cache warmers.
+//
+// Locks expire after their TTL unless refreshed, so a crashed owner cannot
+// hold a lock forever. Because an owner can also stall past its TTL, each
+// acquisition carries a fencing token: a number that increases with every
+// acquisition of the key. Pass it to the resources the lock protects and
+// have them reject writes with a token lower than one they have seen.
+//
+// Implementations: NewMemoryLocker (single process, for tests),
+// NewDatabaseLocker (a lock table through Executor) and the redis adapter's
+// NewLocker.
+type Locker interface {
+	// Acquire takes the lock on key for ttl, without waiting.
+	// Returns ErrLockHeld if another owner holds it.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
+
+// Lock is an acquired lock.
+type Lock interface {
+	// Key returns the locked key.
+	Key() string
+
+	// Token returns the fencing token of this acquisition. Tokens of a key
+	// strictly increase with each acquisition.
+	Token() int64
+
+	// Refresh extends the lock to ttl from now.
+	// Returns ErrLockLost if the lock is no longer held.
+	Refresh(ctx context.Context, ttl time.Duration) error
+
+	// Release unlocks the key.
+	// Returns ErrLockLost if the lock was no longer held.
+	Release(ctx context.Context) error
+}
+
+// AcquireWithRetry calls locker.Acquire every interval until it succeeds,
+// fails with an error other than ErrLockHeld, or ctx is done.
+//
+// Example:
+//
+//	lock, err := hyperion.AcquireWithRetry(ctx, locker, "migrations", time.Minute, time.Second)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lock.Release(context.Background())
+func AcquireWithRetry(ctx context.Context, locker Locker, key string, ttl, interval time.Duration) (Lock, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		lock, err := locker.Acquire(ctx, key, ttl)
+		if !errors.Is(err, ErrLockHeld) {
+			return lock, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for lock %s: %w", key, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// newLockOwner returns a random identifier for one acquisition of a lock.
+func newLockOwner() string {
+	return fmt.Sprintf("%016x%016x", rand.Uint64(), rand.Uint64())
+}
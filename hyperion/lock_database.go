@@ -0,0 +1,180 @@
+package hyperion
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultLockTable is the table used by NewDatabaseLocker.
+const DefaultLockTable = "hyperion_locks"
+
+// DatabaseLocker is a Locker over a lock table, reached through Executor so
+// it works with any database adapter.
+//
+// Each key is one row holding the current owner, the fencing token and the
+// expiry in unix milliseconds. Expiry is taken from the application clock,
+// so instances sharing a table need clocks that agree to well within the
+// lock TTL. Rows are kept after release so tokens keep increasing.
+//
+// Create the table with CreateTable or an equivalent migration:
+//
+//	CREATE TABLE hyperion_locks (
+//	    name       VARCHAR(255) PRIMARY KEY,
+//	    owner      VARCHAR(64)  NOT NULL,
+//	    token      BIGINT       NOT NULL,
+//	    expires_at BIGINT       NOT NULL
+//	)
+type DatabaseLocker struct {
+	exec  Executor
+	now   func() time.Time
+	table string
+}
+
+// DatabaseLockerOption configures a DatabaseLocker.
+type DatabaseLockerOption func(*DatabaseLocker)
+
+// WithLockTable sets the lock table name. Default: DefaultLockTable.
+func WithLockTable(table string) DatabaseLockerOption {
+	return func(l *DatabaseLocker) {
+		l.table = table
+	}
+}
+
+// Ensure DatabaseLocker implements Locker interface.
+var _ Locker = (*DatabaseLocker)(nil)
+
+// NewDatabaseLocker creates a Locker storing locks in a table through exec.
+// exec should not be a transaction executor: lock changes must be visible
+// to other instances immediately.
+func NewDatabaseLocker(exec Executor, opts ...DatabaseLockerOption) *DatabaseLocker {
+	l := &DatabaseLocker{exec: exec, now: time.Now, table: DefaultLockTable}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// CreateTable creates the lock table if it does not exist.
+func (l *DatabaseLocker) CreateTable(ctx context.Context) error {
+	_, err := l.exec.Exec(ctx, "CREATE TABLE IF NOT EXISTS "+l.table+
+		" (name VARCHAR(255) PRIMARY KEY, owner VARCHAR(64) NOT NULL,"+
+		" token BIGINT NOT NULL, expires_at BIGINT NOT NULL)")
+	if err != nil {
+		return fmt.Errorf("create lock table %s: %w", l.table, err)
+	}
+	return nil
+}
+
+// lockRow is a row of the lock table.
+type lockRow struct {
+	Owner     string `db:"owner"`
+	Token     int64  `db:"token"`
+	ExpiresAt int64  `db:"expires_at"`
+}
+
+// Acquire takes the lock on key for ttl, or returns ErrLockHeld.
+//
+// An expired row is taken over with a conditional UPDATE; a missing row is
+// INSERTed, where the primary key makes concurrent inserts race safely.
+// Either way the row is read back to learn who won.
+func (l *DatabaseLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	now := l.now()
+	owner := newLockOwner()
+	expiresAt := now.Add(ttl).UnixMilli()
+
+	_, err := l.exec.Exec(ctx, "UPDATE "+l.table+
+		" SET owner = ?, token = token + 1, expires_at = ? WHERE name = ? AND expires_at <= ?",
+		owner, expiresAt, key, now.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("acquire lock %s: %w", key, err)
+	}
+
+	row, found, err := l.read(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		_, insertErr := l.exec.Exec(ctx, "INSERT INTO "+l.table+
+			" (name, owner, token, expires_at) VALUES (?, ?, 1, ?)",
+			key, owner, expiresAt)
+		// A failed insert usually means another owner inserted first;
+		// only report it if no row shows up.
+		row, found, err = l.read(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("acquire lock %s: %w", key, insertErr)
+		}
+	}
+
+	if row.Owner != owner {
+		return nil, ErrLockHeld
+	}
+	return &databaseLock{locker: l, key: key, owner: owner, token: row.Token}, nil
+}
+
+// read returns the row of key.
+func (l *DatabaseLocker) read(ctx context.Context, key string) (lockRow, bool, error) {
+	var rows []lockRow
+	err := l.exec.Query(ctx, &rows,
+		"SELECT owner, token, expires_at FROM "+l.table+" WHERE name = ?", key)
+	if err != nil {
+		return lockRow{}, false, fmt.Errorf("read lock %s: %w", key, err)
+	}
+	if len(rows) == 0 {
+		return lockRow{}, false, nil
+	}
+	return rows[0], true, nil
+}
+
+// databaseLock is a Lock acquired from a DatabaseLocker.
+type databaseLock struct {
+	locker *DatabaseLocker
+	key    string
+	owner  string
+	token  int64
+}
+
+func (d *databaseLock) Key() string  { return d.key }
+func (d *databaseLock) Token() int64 { return d.token }
+
+// Refresh extends the lock to ttl from now.
+func (d *databaseLock) Refresh(ctx context.Context, ttl time.Duration) error {
+	now := d.locker.now()
+	return d.update(ctx, "refresh", now.Add(ttl).UnixMilli(), now)
+}
+
+// Release unlocks the key by expiring its row.
+func (d *databaseLock) Release(ctx context.Context) error {
+	return d.update(ctx, "release", 0, d.locker.now())
+}
+
+// update sets the expiry of the row if this lock still holds it.
+func (d *databaseLock) update(ctx context.Context, op string, expiresAt int64, now time.Time) error {
+	result, err := d.locker.exec.Exec(ctx, "UPDATE "+d.locker.table+
+		" SET expires_at = ? WHERE name = ? AND owner = ? AND expires_at > ?",
+		expiresAt, d.key, d.owner, now.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("%s lock %s: %w", op, d.key, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s lock %s: %w", op, d.key, err)
+	}
+	if n > 0 {
+		return nil
+	}
+
+	// MySQL reports zero rows for an update that matched but changed
+	// nothing, as when refreshing twice within a millisecond.
+	row, found, err := d.locker.read(ctx, d.key)
+	if err != nil {
+		return err
+	}
+	if !found || row.Owner != d.owner || row.ExpiresAt != expiresAt || expiresAt <= now.UnixMilli() {
+		return ErrLockLost
+	}
+	return nil
+}
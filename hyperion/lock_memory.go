@@ -0,0 +1,100 @@
+package hyperion
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLocker is a Locker within a single process, for tests and
+// single-instance deployments.
+type MemoryLocker struct {
+	locks map[string]*memoryLockState
+	now   func() time.Time
+	mu    sync.Mutex
+}
+
+// memoryLockState is the state of one key. It outlives releases, so
+// fencing tokens keep increasing.
+type memoryLockState struct {
+	expiresAt time.Time
+	owner     string
+	token     int64
+}
+
+// Ensure MemoryLocker implements Locker interface.
+var _ Locker = (*MemoryLocker)(nil)
+
+// NewMemoryLocker creates an in-process Locker.
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{locks: make(map[string]*memoryLockState), now: time.Now}
+}
+
+// Acquire takes the lock on key for ttl, or returns ErrLockHeld.
+func (l *MemoryLocker) Acquire(_ context.Context, key string, ttl time.Duration) (Lock, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	state, ok := l.locks[key]
+	if !ok {
+		state = &memoryLockState{}
+		l.locks[key] = state
+	}
+	if state.owner != "" && now.Before(state.expiresAt) {
+		return nil, ErrLockHeld
+	}
+
+	state.owner = newLockOwner()
+	state.token++
+	state.expiresAt = now.Add(ttl)
+	return &memoryLock{locker: l, key: key, owner: state.owner, token: state.token}, nil
+}
+
+// memoryLock is a Lock acquired from a MemoryLocker.
+type memoryLock struct {
+	locker *MemoryLocker
+	key    string
+	owner  string
+	token  int64
+}
+
+func (m *memoryLock) Key() string  { return m.key }
+func (m *memoryLock) Token() int64 { return m.token }
+
+// held returns the key's state if this lock still holds it.
+// The caller must hold the locker's mutex.
+func (m *memoryLock) held(now time.Time) (*memoryLockState, bool) {
+	state := m.locker.locks[m.key]
+	if state.owner != m.owner || !now.Before(state.expiresAt) {
+		return nil, false
+	}
+	return state, true
+}
+
+// Refresh extends the lock to ttl from now.
+func (m *memoryLock) Refresh(_ context.Context, ttl time.Duration) error {
+	m.locker.mu.Lock()
+	defer m.locker.mu.Unlock()
+
+	now := m.locker.now()
+	state, ok := m.held(now)
+	if !ok {
+		return ErrLockLost
+	}
+	state.expiresAt = now.Add(ttl)
+	return nil
+}
+
+// Release unlocks the key.
+func (m *memoryLock) Release(context.Context) error {
+	m.locker.mu.Lock()
+	defer m.locker.mu.Unlock()
+
+	state, ok := m.held(m.locker.now())
+	if !ok {
+		return ErrLockLost
+	}
+	state.owner = ""
+	return nil
+}
@@ -0,0 +1,243 @@
+package hyperion_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+	"github.com/mapoio/hyperion/hyperiontest"
+)
+
+func TestMemoryLocker_Conformance(t *testing.T) {
+	hyperiontest.RunLockerSuite(t, hyperiontest.LockerHarness{
+		New: func(t *testing.T) hyperion.Locker {
+			return hyperion.NewMemoryLocker()
+		},
+	})
+}
+
+func TestAcquireWithRetry(t *testing.T) {
+	ctx := context.Background()
+	locker := hyperion.NewMemoryLocker()
+
+	held, err := locker.Acquire(ctx, "job", 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	lock, err := hyperion.AcquireWithRetry(ctx, locker, "job", time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("AcquireWithRetry failed: %v", err)
+	}
+	if lock.Token() <= held.Token() {
+		t.Errorf("token = %d, want > %d", lock.Token(), held.Token())
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 30*time.Millisecond)
+	defer cancel()
+	_, err = hyperion.AcquireWithRetry(timeout, locker, "job", time.Second, 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("AcquireWithRetry error = %v, want DeadlineExceeded", err)
+	}
+}
+
+// electorEvents records LeaderElector callbacks.
+type electorEvents struct {
+	elected chan hyperion.Lock
+	demoted chan struct{}
+}
+
+func newElector(locker hyperion.Locker, opts ...hyperion.LeaderOption) (*hyperion.LeaderElector, electorEvents) {
+	events := electorEvents{
+		elected: make(chan hyperion.Lock, 10),
+		demoted: make(chan struct{}, 10),
+	}
+	opts = append([]hyperion.LeaderOption{
+		hyperion.WithLeaseTTL(time.Second),
+		hyperion.WithRenewInterval(10 * time.Millisecond),
+		hyperion.OnElected(func(ctx context.Context, lock hyperion.Lock) {
+			events.elected <- lock
+			<-ctx.Done()
+		}),
+		hyperion.OnDemoted(func() { events.demoted <- struct{}{} }),
+	}, opts...)
+	return hyperion.NewLeaderElector(locker, "leader", opts...), events
+}
+
+func waitFor[T any](t *testing.T, ch <-chan T, what string) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+		var zero T
+		return zero
+	}
+}
+
+func TestLeaderElector_Failover(t *testing.T) {
+	locker := hyperion.NewMemoryLocker()
+	first, firstEvents := newElector(locker)
+	second, secondEvents := newElector(locker)
+
+	ctx1, stop1 := context.WithCancel(context.Background())
+	done1 := make(chan struct{})
+	go func() {
+		first.Run(ctx1)
+		close(done1)
+	}()
+	lock1 := waitFor(t, firstEvents.elected, "first election")
+	if !first.IsLeader() {
+		t.Error("IsLeader() = false after election")
+	}
+
+	ctx2, stop2 := context.WithCancel(context.Background())
+	defer stop2()
+	go second.Run(ctx2)
+	time.Sleep(50 * time.Millisecond)
+	if second.IsLeader() {
+		t.Fatal("second elector leads while the first holds the lease")
+	}
+
+	// Stopping the leader releases the lease for the follower
+	stop1()
+	waitFor(t, firstEvents.demoted, "first demotion")
+	<-done1
+	if first.IsLeader() {
+		t.Error("IsLeader() = true after Run returned")
+	}
+
+	lock2 := waitFor(t, secondEvents.elected, "failover")
+	if lock2.Token() <= lock1.Token() {
+		t.Errorf("failover token = %d, want > %d", lock2.Token(), lock1.Token())
+	}
+}
+
+func TestLeaderElector_LeaseLost(t *testing.T) {
+	elector, events := newElector(hyperion.NewMemoryLocker())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go elector.Run(ctx)
+
+	// Releasing behind the elector's back makes its next refresh fail
+	lock := waitFor(t, events.elected, "election")
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	waitFor(t, events.demoted, "demotion")
+
+	again := waitFor(t, events.elected, "re-election")
+	if again.Token() <= lock.Token() {
+		t.Errorf("re-election token = %d, want > %d", again.Token(), lock.Token())
+	}
+}
+
+// failingLocker hands out locks whose Refresh always fails.
+type failingLocker struct {
+	hyperion.Locker
+}
+
+type failingLock struct {
+	hyperion.Lock
+}
+
+func (l failingLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (hyperion.Lock, error) {
+	lock, err := l.Locker.Acquire(ctx, key, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return failingLock{lock}, nil
+}
+
+func (failingLock) Refresh(context.Context, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func TestLeaderElector_RefreshFailures(t *testing.T) {
+	elector, events := newElector(failingLocker{hyperion.NewMemoryLocker()},
+		hyperion.WithLeaseTTL(200*time.Millisecond), hyperion.WithRenewInterval(50*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go elector.Run(ctx)
+
+	waitFor(t, events.elected, "election")
+	start := time.Now()
+	waitFor(t, events.demoted, "demotion")
+
+	// Transient errors are retried, but leadership ends before the lease
+	// can expire
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond || elapsed >= 200*time.Millisecond {
+		t.Errorf("demoted after %v, want one renew interval before the 200ms lease expires", elapsed)
+	}
+}
+
+// flakyLocker hands out locks whose Refresh fails while failing is set.
+type flakyLocker struct {
+	hyperion.Locker
+	failing *atomic.Bool
+}
+
+type flakyLock struct {
+	hyperion.Lock
+	failing *atomic.Bool
+}
+
+func (l flakyLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (hyperion.Lock, error) {
+	lock, err := l.Locker.Acquire(ctx, key, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return flakyLock{lock, l.failing}, nil
+}
+
+func (l flakyLock) Refresh(ctx context.Context, ttl time.Duration) error {
+	if l.failing.Load() {
+		return errors.New("connection refused")
+	}
+	return l.Lock.Refresh(ctx, ttl)
+}
+
+func TestLeaderElector_NoOverlap(t *testing.T) {
+	locker := flakyLocker{hyperion.NewMemoryLocker(), &atomic.Bool{}}
+	var active atomic.Int32
+	var overlapped atomic.Bool
+	elected := make(chan struct{}, 10)
+	demoted := make(chan struct{}, 10)
+
+	newTracked := func() *hyperion.LeaderElector {
+		return hyperion.NewLeaderElector(locker, "leader",
+			hyperion.WithLeaseTTL(200*time.Millisecond),
+			hyperion.WithRenewInterval(50*time.Millisecond),
+			hyperion.OnElected(func(ctx context.Context, _ hyperion.Lock) {
+				if active.Add(1) > 1 {
+					overlapped.Store(true)
+				}
+				elected <- struct{}{}
+				<-ctx.Done()
+				active.Add(-1)
+			}),
+			hyperion.OnDemoted(func() { demoted <- struct{}{} }),
+		)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go newTracked().Run(ctx)
+	waitFor(t, elected, "first election")
+
+	// While refreshes fail, the lease expires and the other elector (or the
+	// same one, campaigning again) takes over
+	locker.failing.Store(true)
+	go newTracked().Run(ctx)
+	waitFor(t, demoted, "demotion")
+	waitFor(t, elected, "failover")
+	locker.failing.Store(false)
+
+	if overlapped.Load() {
+		t.Error("two electors ran OnElected at the same time")
+	}
+}
@@ -0,0 +1,44 @@
+package hyperion
+
+import "context"
+
+// ContextFieldExtractor extracts log fields from a context.Context.
+//
+// Extractors are applied on every hyperion.Context.Logger() call, so values
+// stored in the context by middleware (request IDs, tenant IDs, authenticated
+// principals, job IDs) appear in every log entry without each handler calling
+// Logger.With. The returned slice uses the same key-value form as the Logger
+// methods and may also contain Field values. Returning nil adds nothing.
+//
+// Extractors are called on hot paths and must be cheap and safe for
+// concurrent use.
+//
+// Extractors are contributed via the "hyperion.context_fields" fx group:
+//
+//	fx.Provide(
+//	    fx.Annotate(
+//	        func() hyperion.ContextFieldExtractor {
+//	            return func(ctx context.Context) []any {
+//	                if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+//	                    return []any{"request_id", id}
+//	                }
+//	                return nil
+//	            }
+//	        },
+//	        fx.ResultTags(`group:"hyperion.context_fields"`),
+//	    ),
+//	)
+type ContextFieldExtractor func(ctx context.Context) []any
+
+// extractContextFields runs all extractors against ctx and concatenates
+// their fields. It returns nil when no extractor produces a field.
+func extractContextFields(ctx context.Context, extractors []ContextFieldExtractor) []any {
+	var fields []any
+	for _, extract := range extractors {
+		if extract == nil {
+			continue
+		}
+		fields = append(fields, extract(ctx)...)
+	}
+	return fields
+}
@@ -0,0 +1,114 @@
+package hyperion
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.uber.org/fx"
+)
+
+type requestIDKey struct{}
+
+// withLogger records the fields passed to With and the bound context.
+type withLogger struct {
+	noopLogger
+	boundCtx context.Context
+	fields   []any
+}
+
+func (l *withLogger) With(fields ...any) Logger {
+	return &withLogger{boundCtx: l.boundCtx, fields: append(append([]any{}, l.fields...), fields...)}
+}
+
+func (l *withLogger) WithContext(ctx context.Context) Logger {
+	return &withLogger{boundCtx: ctx, fields: l.fields}
+}
+
+func requestIDExtractor(ctx context.Context) []any {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return []any{"request_id", id}
+	}
+	return nil
+}
+
+func TestContextFieldExtractors(t *testing.T) {
+	tenantExtractor := func(context.Context) []any {
+		return []any{F.String("tenant", "acme")}
+	}
+
+	factory := NewContextFactory(&withLogger{}, NewNoOpTracer(), NewNoOpDatabase(), NewNoOpMeter(),
+		WithContextFieldExtractors(requestIDExtractor, nil),
+		WithContextFieldExtractors(tenantExtractor),
+	)
+
+	stdCtx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	ctx := factory.New(stdCtx)
+
+	logger, ok := ctx.Logger().(*withLogger)
+	if !ok {
+		t.Fatalf("Logger() = %T, want *withLogger", ctx.Logger())
+	}
+	if logger.boundCtx != stdCtx {
+		t.Error("expected logger to be bound to the context before extraction")
+	}
+	want := []any{"request_id", "req-1", F.String("tenant", "acme")}
+	if !reflect.DeepEqual(logger.fields, want) {
+		t.Errorf("fields = %v, want %v", logger.fields, want)
+	}
+
+	t.Run("derived contexts keep extractors", func(t *testing.T) {
+		cancelCtx, cancel := ctx.WithCancel()
+		defer cancel()
+		for name, c := range map[string]Context{
+			"WithCancel": cancelCtx,
+			"WithLogger": WithLogger(ctx, &withLogger{}),
+			"WithDB":     WithDB(ctx, NewNoOpDatabase().Executor()),
+			"WithSpan":   WithSpan(ctx, &noopSpan{}),
+		} {
+			if got := c.Logger().(*withLogger).fields; !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: fields = %v, want %v", name, got, want)
+			}
+		}
+	})
+
+	t.Run("no fields skips With", func(t *testing.T) {
+		base := &withLogger{}
+		ctx := NewContextFactory(base, NewNoOpTracer(), NewNoOpDatabase(), NewNoOpMeter(),
+			WithContextFieldExtractors(requestIDExtractor),
+		).New(context.Background())
+
+		if got := ctx.Logger().(*withLogger).fields; got != nil {
+			t.Errorf("fields = %v, want none", got)
+		}
+	})
+}
+
+func TestContextModule_FieldExtractorGroup(t *testing.T) {
+	var factory ContextFactory
+
+	app := fx.New(
+		CoreModule,
+		fx.Provide(func() Logger { return &withLogger{} }),
+		fx.Provide(NewNoOpTracer),
+		fx.Provide(NewNoOpDatabase),
+		fx.Provide(NewNoOpMeter),
+		fx.Provide(
+			fx.Annotate(
+				func() ContextFieldExtractor { return requestIDExtractor },
+				fx.ResultTags(`group:"hyperion.context_fields"`),
+			),
+		),
+		fx.Populate(&factory),
+		fx.NopLogger,
+	)
+	if err := app.Err(); err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+
+	ctx := factory.New(context.WithValue(context.Background(), requestIDKey{}, "req-2"))
+	want := []any{"request_id", "req-2"}
+	if got := ctx.Logger().(*withLogger).fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("fields = %v, want %v", got, want)
+	}
+}
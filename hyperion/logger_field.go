@@ -0,0 +1,250 @@
+package hyperion
+
+import (
+	"math"
+	"time"
+)
+
+// BadKey is the key used for values that are not preceded by a string key
+// in a variadic key-value list, and for a trailing key without a value.
+// Adapters log such values under BadKey instead of dropping them.
+const BadKey = "!BADKEY"
+
+// FieldType identifies how a Field's value is stored.
+type FieldType uint8
+
+const (
+	// AnyType stores an arbitrary value in Field.Interface.
+	AnyType FieldType = iota
+
+	// StringType stores a string in Field.String.
+	StringType
+
+	// Int64Type stores an integer in Field.Integer.
+	Int64Type
+
+	// Float64Type stores the IEEE 754 bits of a float64 in Field.Integer.
+	Float64Type
+
+	// BoolType stores 1 or 0 in Field.Integer.
+	BoolType
+
+	// DurationType stores nanoseconds in Field.Integer.
+	DurationType
+
+	// TimeType stores Unix nanoseconds in Field.Integer and the
+	// *time.Location in Field.Interface.
+	TimeType
+
+	// ErrorType stores an error in Field.Interface.
+	ErrorType
+)
+
+// Field is a typed log field.
+//
+// Fields carry their value without boxing it into an interface, so adapters
+// can map them directly to their native field type (e.g. zap.Field).
+// Construct fields with the F namespace rather than by hand:
+//
+//	logger.Info("request served",
+//	    hyperion.F.String("method", r.Method),
+//	    hyperion.F.Int("status", status),
+//	    hyperion.F.Duration("latency", time.Since(start)),
+//	)
+//
+// Fields may be mixed with plain key-value pairs in the variadic Logger
+// methods; a Field counts as a complete pair on its own.
+type Field struct {
+	Interface any
+	Key       string
+	String    string
+	Integer   int64
+	Type      FieldType
+}
+
+// Value returns the field's value as an interface.
+// It is the slow path used by adapters without native typed fields.
+func (f Field) Value() any {
+	switch f.Type {
+	case StringType:
+		return f.String
+	case Int64Type:
+		return f.Integer
+	case Float64Type:
+		return math.Float64frombits(uint64(f.Integer))
+	case BoolType:
+		return f.Integer == 1
+	case DurationType:
+		return time.Duration(f.Integer)
+	case TimeType:
+		t := time.Unix(0, f.Integer)
+		if loc, ok := f.Interface.(*time.Location); ok {
+			t = t.In(loc)
+		}
+		return t
+	default:
+		return f.Interface
+	}
+}
+
+// fieldConstructors groups the typed Field constructors under F.
+type fieldConstructors struct{}
+
+// F provides typed Field constructors, e.g. hyperion.F.String("key", "value").
+var F fieldConstructors
+
+// String constructs a field with a string value.
+func (fieldConstructors) String(key, value string) Field {
+	return Field{Key: key, Type: StringType, String: value}
+}
+
+// Int constructs a field with an int value.
+func (fieldConstructors) Int(key string, value int) Field {
+	return Field{Key: key, Type: Int64Type, Integer: int64(value)}
+}
+
+// Int64 constructs a field with an int64 value.
+func (fieldConstructors) Int64(key string, value int64) Field {
+	return Field{Key: key, Type: Int64Type, Integer: value}
+}
+
+// Float64 constructs a field with a float64 value.
+func (fieldConstructors) Float64(key string, value float64) Field {
+	return Field{Key: key, Type: Float64Type, Integer: int64(math.Float64bits(value))}
+}
+
+// Bool constructs a field with a bool value.
+func (fieldConstructors) Bool(key string, value bool) Field {
+	var i int64
+	if value {
+		i = 1
+	}
+	return Field{Key: key, Type: BoolType, Integer: i}
+}
+
+// Duration constructs a field with a time.Duration value.
+func (fieldConstructors) Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: DurationType, Integer: int64(value)}
+}
+
+// Time constructs a field with a time.Time value.
+func (fieldConstructors) Time(key string, value time.Time) Field {
+	return Field{Key: key, Type: TimeType, Integer: value.UnixNano(), Interface: value.Location()}
+}
+
+// Err constructs a field with the key "error".
+func (fieldConstructors) Err(err error) Field {
+	return F.NamedErr("error", err)
+}
+
+// NamedErr constructs a field with an error value under the given key.
+func (fieldConstructors) NamedErr(key string, err error) Field {
+	return Field{Key: key, Type: ErrorType, Interface: err}
+}
+
+// Any constructs a field with an arbitrary value.
+// Prefer the typed constructors on hot paths; Any boxes its value.
+func (fieldConstructors) Any(key string, value any) Field {
+	return Field{Key: key, Type: AnyType, Interface: value}
+}
+
+// FieldLogger is an optional interface that Logger implementations can
+// implement to accept typed fields without boxing them.
+//
+// Use the LogFields function rather than asserting this interface directly;
+// it falls back to the variadic methods for loggers that do not implement it.
+type FieldLogger interface {
+	// LogFields logs msg at level with the given typed fields.
+	// A FatalLevel entry exits the process, as Logger.Fatal does.
+	LogFields(level LogLevel, msg string, fields ...Field)
+}
+
+// LogFields logs msg at level with typed fields.
+// If logger implements FieldLogger, the fields are passed through unboxed.
+//
+// Example:
+//
+//	hyperion.LogFields(logger, hyperion.InfoLevel, "cache hit",
+//	    hyperion.F.String("key", key),
+//	    hyperion.F.Duration("age", age),
+//	)
+func LogFields(logger Logger, level LogLevel, msg string, fields ...Field) {
+	if fl, ok := logger.(FieldLogger); ok {
+		fl.LogFields(level, msg, fields...)
+		return
+	}
+
+	args := make([]any, len(fields))
+	for i, f := range fields {
+		args[i] = f
+	}
+
+	switch level {
+	case DebugLevel:
+		logger.Debug(msg, args...)
+	case WarnLevel:
+		logger.Warn(msg, args...)
+	case ErrorLevel:
+		logger.Error(msg, args...)
+	case FatalLevel:
+		logger.Fatal(msg, args...)
+	default:
+		logger.Info(msg, args...)
+	}
+}
+
+// FieldsFromArgs converts a variadic key-value list into typed fields.
+//
+// Field values are taken as-is. A string followed by a value forms a pair.
+// Any other value, and a trailing string without a value, is reported
+// under BadKey so malformed calls stay visible in the output.
+func FieldsFromArgs(args []any) []Field {
+	if len(args) == 0 {
+		return nil
+	}
+
+	fields := make([]Field, 0, len(args)/2+1)
+	for i := 0; i < len(args); {
+		switch x := args[i].(type) {
+		case Field:
+			fields = append(fields, x)
+			i++
+		case string:
+			if i+1 >= len(args) {
+				fields = append(fields, F.String(BadKey, x))
+				i++
+				continue
+			}
+			fields = append(fields, anyField(x, args[i+1]))
+			i += 2
+		default:
+			fields = append(fields, anyField(BadKey, x))
+			i++
+		}
+	}
+	return fields
+}
+
+// anyField converts value to the most specific Field type.
+func anyField(key string, value any) Field {
+	switch v := value.(type) {
+	case string:
+		return F.String(key, v)
+	case int:
+		return F.Int(key, v)
+	case int64:
+		return F.Int64(key, v)
+	case float64:
+		return F.Float64(key, v)
+	case bool:
+		return F.Bool(key, v)
+	case time.Duration:
+		return F.Duration(key, v)
+	case time.Time:
+		return F.Time(key, v)
+	case error:
+		return F.NamedErr(key, v)
+	default:
+		return F.Any(key, v)
+	}
+}
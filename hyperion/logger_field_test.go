@@ -0,0 +1,120 @@
+package hyperion_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mapoio/hyperion"
+)
+
+func TestFieldConstructors(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	err := errors.New("boom")
+
+	tests := []struct {
+		want  any
+		field hyperion.Field
+		key   string
+	}{
+		{field: hyperion.F.String("s", "v"), key: "s", want: "v"},
+		{field: hyperion.F.Int("i", 7), key: "i", want: int64(7)},
+		{field: hyperion.F.Int64("i64", -7), key: "i64", want: int64(-7)},
+		{field: hyperion.F.Float64("f", 1.5), key: "f", want: 1.5},
+		{field: hyperion.F.Bool("t", true), key: "t", want: true},
+		{field: hyperion.F.Bool("f", false), key: "f", want: false},
+		{field: hyperion.F.Duration("d", time.Second), key: "d", want: time.Second},
+		{field: hyperion.F.Time("ts", ts), key: "ts", want: ts},
+		{field: hyperion.F.Err(err), key: "error", want: err},
+		{field: hyperion.F.NamedErr("cause", err), key: "cause", want: err},
+		{field: hyperion.F.Any("a", 3), key: "a", want: 3},
+	}
+
+	for _, tt := range tests {
+		if tt.field.Key != tt.key {
+			t.Errorf("Key = %q, want %q", tt.field.Key, tt.key)
+		}
+		if got := tt.field.Value(); got != tt.want {
+			t.Errorf("%s: Value() = %v (%T), want %v (%T)", tt.key, got, got, tt.want, tt.want)
+		}
+	}
+}
+
+func TestFieldsFromArgs(t *testing.T) {
+	err := errors.New("boom")
+	fields := hyperion.FieldsFromArgs([]any{
+		"name", "alice",
+		hyperion.F.Int("typed", 1),
+		"count", 3,
+		"err", err,
+		42,
+		"dangling",
+	})
+
+	want := []struct {
+		value any
+		key   string
+		typ   hyperion.FieldType
+	}{
+		{key: "name", value: "alice", typ: hyperion.StringType},
+		{key: "typed", value: int64(1), typ: hyperion.Int64Type},
+		{key: "count", value: int64(3), typ: hyperion.Int64Type},
+		{key: "err", value: err, typ: hyperion.ErrorType},
+		{key: hyperion.BadKey, value: int64(42), typ: hyperion.Int64Type},
+		{key: hyperion.BadKey, value: "dangling", typ: hyperion.StringType},
+	}
+
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d: %v", len(fields), len(want), fields)
+	}
+	for i, w := range want {
+		if fields[i].Key != w.key || fields[i].Type != w.typ || fields[i].Value() != w.value {
+			t.Errorf("field %d = %s/%d/%v, want %s/%d/%v",
+				i, fields[i].Key, fields[i].Type, fields[i].Value(), w.key, w.typ, w.value)
+		}
+	}
+
+	if got := hyperion.FieldsFromArgs(nil); got != nil {
+		t.Errorf("FieldsFromArgs(nil) = %v, want nil", got)
+	}
+}
+
+// argsLogger records the variadic fields passed to it.
+type argsLogger struct {
+	hyperion.Logger
+	level hyperion.LogLevel
+	args  []any
+}
+
+func (l *argsLogger) Debug(msg string, fields ...any) { l.level, l.args = hyperion.DebugLevel, fields }
+func (l *argsLogger) Info(msg string, fields ...any)  { l.level, l.args = hyperion.InfoLevel, fields }
+func (l *argsLogger) Warn(msg string, fields ...any)  { l.level, l.args = hyperion.WarnLevel, fields }
+func (l *argsLogger) Error(msg string, fields ...any) { l.level, l.args = hyperion.ErrorLevel, fields }
+func (l *argsLogger) Fatal(msg string, fields ...any) { l.level, l.args = hyperion.FatalLevel, fields }
+
+func TestLogFields_Fallback(t *testing.T) {
+	logger := &argsLogger{}
+
+	for _, level := range []hyperion.LogLevel{
+		hyperion.DebugLevel, hyperion.InfoLevel, hyperion.WarnLevel, hyperion.ErrorLevel, hyperion.FatalLevel,
+	} {
+		hyperion.LogFields(logger, level, "msg", hyperion.F.String("k", "v"))
+		if logger.level != level {
+			t.Errorf("LogFields(%v) dispatched to %v", level, logger.level)
+		}
+		if len(logger.args) != 1 {
+			t.Fatalf("args = %v, want a single Field", logger.args)
+		}
+		if f, ok := logger.args[0].(hyperion.Field); !ok || f.Key != "k" {
+			t.Errorf("args[0] = %v, want Field k", logger.args[0])
+		}
+	}
+}
+
+func TestLogFields_NoOpLogger(t *testing.T) {
+	logger := hyperion.NewNoOpLogger()
+	if _, ok := logger.(hyperion.FieldLogger); !ok {
+		t.Fatal("no-op logger should implement FieldLogger")
+	}
+	hyperion.LogFields(logger, hyperion.InfoLevel, "msg", hyperion.F.Int("n", 1))
+}
@@ -20,3 +20,6 @@ func (l *noopLogger) WithError(err error) Logger      { return l }
 func (l *noopLogger) SetLevel(level LogLevel)         { l.level = level }
 func (l *noopLogger) GetLevel() LogLevel              { return l.level }
 func (l *noopLogger) Sync() error                     { return nil }
+
+// LogFields implements FieldLogger so typed fields are not boxed for nothing.
+func (l *noopLogger) LogFields(level LogLevel, msg string, fields ...Field) {}
@@ -91,6 +91,10 @@ var ContextModule = fx.Module("hyperion.context",
 			DB       Database
 			Meter    Meter
 			Registry InterceptorRegistry
+
+			// Log field extractors contributed via:
+			//   fx.Annotate(NewTenantExtractor, fx.ResultTags(`group:"hyperion.context_fields"`))
+			FieldExtractors []ContextFieldExtractor `group:"hyperion.context_fields"`
 		}) ContextFactory {
 			return NewContextFactory(
 				params.Logger,
@@ -98,6 +102,7 @@ var ContextModule = fx.Module("hyperion.context",
 				params.DB,
 				params.Meter,
 				WithRegistry(params.Registry),
+				WithContextFieldExtractors(params.FieldExtractors...),
 			)
 		},
 	),
@@ -2,6 +2,7 @@ package hyperion_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -235,13 +236,21 @@ func TestNoOpDatabase(t *testing.T) {
 	}
 
 	// Test Executor methods
-	exec.Exec(ctx, "SELECT 1")
+	if _, err := exec.Exec(ctx, "SELECT 1"); err == nil {
+		t.Error("Exec should return error for NoOp executor")
+	}
 
 	var users []map[string]any
 	err := exec.Query(ctx, &users, "SELECT * FROM users")
 	if err == nil {
 		t.Error("Query should return error for NoOp executor")
 	}
+	if err := exec.QueryRow(ctx, &users, "SELECT * FROM users"); err == nil {
+		t.Error("QueryRow should return error for NoOp executor")
+	}
+	if _, err := exec.QueryIter(ctx, "SELECT * FROM users"); err == nil {
+		t.Error("QueryIter should return error for NoOp executor")
+	}
 
 	tx, err := exec.Begin(ctx)
 	if err != nil {
@@ -272,8 +281,8 @@ func TestNoOpCache(t *testing.T) {
 
 	// Test Get
 	data, err := cache.Get(ctx, "key")
-	if err == nil {
-		t.Error("Get should return error for NoOp")
+	if !errors.Is(err, hyperion.ErrCacheMiss) || !errors.Is(err, hyperion.ErrNoOpCache) {
+		t.Errorf("Get error = %v, want ErrCacheMiss and ErrNoOpCache", err)
 	}
 	if data != nil {
 		t.Error("Get should return nil data")